@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/dysodeng/app/internal/di"
+	"github.com/dysodeng/app/internal/infrastructure/config"
 	"github.com/dysodeng/app/internal/infrastructure/server"
 	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
 )
@@ -29,6 +30,9 @@ func (app *app) run() {
 	// 应用初始化
 	app.initialize()
 
+	// 监听配置变更
+	app.watchConfig()
+
 	// 启动服务
 	app.serve()
 
@@ -44,6 +48,19 @@ func (app *app) initialize() {
 	app.mainApp = mainApp
 }
 
+// watchConfig 监听配置文件变更与 SIGHUP 信号，重载校验失败时保留原配置
+func (app *app) watchConfig() {
+	config.Watch(app.ctx, func(changed []string, err error) {
+		if err != nil {
+			logger.Error(app.ctx, "配置重载失败，保留原配置", logger.ErrorField(err))
+			return
+		}
+		if len(changed) > 0 {
+			logger.Info(app.ctx, "配置已重载", logger.AddField("sections", changed))
+		}
+	})
+}
+
 func (app *app) registerServer(servers ...server.Server) {
 	for _, svc := range servers {
 		if svc.IsEnabled() {
@@ -58,6 +75,7 @@ func (app *app) serve() {
 	// 注册服务
 	app.registerServer(
 		app.mainApp.EventServer,
+		app.mainApp.OutboxServer,
 		app.mainApp.GRPCServer,
 		app.mainApp.HTTPServer,
 		app.mainApp.WSServer,
@@ -98,5 +116,35 @@ func (app *app) waitForInterruptSignal() {
 
 func Execute() {
 	ctx := context.Background()
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case eventReplayCommand:
+			if err := runEventReplay(ctx, os.Args[2:]); err != nil {
+				logger.Fatal(ctx, "事件回放失败", logger.ErrorField(err))
+			}
+			return
+		case migrateCommand:
+			// 参数错误时日志尚未初始化，直接输出到标准错误
+			if err := runMigrate(ctx, os.Args[2:]); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, "migrate:", err)
+				os.Exit(1)
+			}
+			return
+		case configCommand:
+			if err := runConfig(ctx, os.Args[2:]); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, "config:", err)
+				os.Exit(1)
+			}
+			return
+		case piiBackfillCommand:
+			if err := runPIIBackfill(ctx, os.Args[2:]); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, piiBackfillCommand+":", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	newApp(ctx).run()
 }
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+)
+
+// configCommand 远程配置命令名称
+const configCommand = "config"
+
+// defaultConfigFile 默认本地配置文件
+const defaultConfigFile = "configs/config.yaml"
+
+const configUsage = `usage:
+  app config diff [-file PATH] [-include-secrets]                      比较本地配置文件与远程配置
+  app config push [-file PATH] [-prune] [-dry-run] [-include-secrets]  将本地配置文件推送到远程配置`
+
+// runConfig 远程配置命令，连接配置取自本地配置文件的 etcd.config 与环境变量，不要求开启远程配置源
+// 推送的是配置文件原文，环境变量中的配置不会写入远程；密码、密钥类配置默认不比较也不推送(远程中已有的同样保留)，
+// 避免明文写入远程配置中心，需同步时指定 -include-secrets
+//
+//	app config diff
+//	app config push -prune
+func runConfig(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n%s", configUsage)
+	}
+	sub, args := args[0], args[1:]
+	if sub != "diff" && sub != "push" {
+		return fmt.Errorf("unknown subcommand %q\n%s", sub, configUsage)
+	}
+
+	flags := flag.NewFlagSet(configCommand+" "+sub, flag.ExitOnError)
+	file := flags.String("file", defaultConfigFile, "本地配置文件")
+	prune := flags.Bool("prune", false, "删除仅远程存在的配置键")
+	dryRun := flags.Bool("dry-run", false, "仅输出差异，不写入")
+	includeSecrets := flags.Bool("include-secrets", false, "包含密码、密钥类配置，默认跳过")
+	_ = flags.Parse(args)
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	local, err := config.FlattenYAML(data)
+	if err != nil {
+		return err
+	}
+
+	src, namespace, err := config.LoadRemoteSource(*file)
+	if err != nil {
+		return err
+	}
+	cli, err := config.NewEtcdClient(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cli.Close() }()
+
+	remote, err := config.RemoteKeys(ctx, cli, namespace)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("namespace: %s\n", namespace)
+	if !*includeSecrets {
+		if omitted := config.OmitSecrets(local); omitted > 0 {
+			fmt.Printf("%d secret key(s) skipped, use -include-secrets to include them\n", omitted)
+		}
+		config.OmitSecrets(remote)
+	}
+
+	changes := config.DiffRemote(local, remote)
+	for _, change := range changes {
+		switch change.Op {
+		case config.RemoteAdd:
+			fmt.Printf("+ %s = %s\n", change.Key, displayValue(change.Key, change.Local))
+		case config.RemoteUpdate:
+			fmt.Printf("~ %s: %s -> %s\n", change.Key, displayValue(change.Key, change.Remote), displayValue(change.Key, change.Local))
+		case config.RemoteDelete:
+			fmt.Printf("- %s = %s\n", change.Key, displayValue(change.Key, change.Remote))
+		}
+	}
+	fmt.Printf("%d difference(s)\n", len(changes))
+
+	if sub == "diff" || len(changes) == 0 {
+		return nil
+	}
+	if *dryRun {
+		fmt.Println("dry run, nothing written")
+		return nil
+	}
+	written, err := config.PushRemote(ctx, cli, namespace, changes, *prune)
+	fmt.Printf("%d key(s) written\n", written)
+	return err
+}
+
+// displayValue 输出配置值，密码、密钥类配置脱敏
+func displayValue(key, value string) string {
+	if value != `""` && config.IsSecretKey(key) {
+		return "******"
+	}
+	return fmt.Sprintf("%q", value)
+}
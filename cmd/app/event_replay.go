@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dysodeng/app/internal/di"
+	"github.com/dysodeng/app/internal/infrastructure/event"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+)
+
+// eventReplayCommand 事件回放命令名称
+const eventReplayCommand = "event:replay"
+
+// runEventReplay 从事件存储回放事件到指定处理器
+//
+//	app event:replay -handler FileUploadedHandler -aggregate-id 1 -since "2026-10-01 00:00:00" -dry-run
+func runEventReplay(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet(eventReplayCommand, flag.ExitOnError)
+	handlerName := flags.String("handler", "", "事件处理器名称(必填)，如 FileUploadedHandler")
+	aggregateID := flags.String("aggregate-id", "", "聚合根ID")
+	aggregateName := flags.String("aggregate-name", "", "聚合根名称")
+	eventTypes := flags.String("type", "", "事件类型，多个以逗号分隔，默认为处理器关注的全部事件类型")
+	since := flags.String("since", "", "事件发生时间起(含)，格式 2006-01-02 15:04:05 或 RFC3339")
+	until := flags.String("until", "", "事件发生时间止(不含)，格式 2006-01-02 15:04:05 或 RFC3339")
+	dryRun := flags.Bool("dry-run", false, "仅统计匹配的事件，不执行处理器")
+	continueOnError := flags.Bool("continue-on-error", false, "处理器失败时继续回放后续事件")
+	_ = flags.Parse(args)
+
+	query := event.EventStoreQuery{
+		AggregateID:   *aggregateID,
+		AggregateName: *aggregateName,
+	}
+	if *eventTypes != "" {
+		query.EventTypes = strings.Split(*eventTypes, ",")
+	}
+	var err error
+	if query.StartTime, err = parseReplayTime(*since); err != nil {
+		return fmt.Errorf("invalid since: %w", err)
+	}
+	if query.EndTime, err = parseReplayTime(*until); err != nil {
+		return fmt.Errorf("invalid until: %w", err)
+	}
+
+	mainApp, err := di.InitApp(ctx)
+	if err != nil {
+		return fmt.Errorf("应用初始化失败: %w", err)
+	}
+	defer func() {
+		if err := mainApp.Stop(ctx); err != nil {
+			logger.Error(ctx, "应用停止失败", logger.ErrorField(err))
+		}
+	}()
+
+	handler, ok := mainApp.EventHandlerRegistry.Find(*handlerName)
+	if !ok {
+		var names []string
+		for _, h := range mainApp.EventHandlerRegistry.Handlers() {
+			names = append(names, fmt.Sprintf("%T", h))
+		}
+		return fmt.Errorf("event handler %q not found, available: %s", *handlerName, strings.Join(names, ", "))
+	}
+
+	result, err := mainApp.EventReplayer.Replay(ctx, query, handler, event.ReplayOptions{
+		DryRun:          *dryRun,
+		ContinueOnError: *continueOnError,
+	})
+	if result != nil {
+		fmt.Printf("matched: %d, handled: %d, failed: %d\n", result.Matched, result.Handled, result.Failed)
+	}
+	return err
+}
+
+func parseReplayTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation(time.DateTime, value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dysodeng/app/internal/di/provider"
+	"github.com/dysodeng/app/internal/infrastructure/migration"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+)
+
+// migrateCommand 数据库迁移命令名称
+const migrateCommand = "migrate"
+
+const migrateUsage = `usage:
+  app migrate status                    查看迁移执行状态
+  app migrate up [-to ID] [-dry-run]    执行迁移，指定 -to 时迁移到该版本(含)为止
+  app migrate down [-to ID] [-dry-run]  回滚最后一个迁移，指定 -to 时回滚其后的全部迁移
+  app migrate new [-dir DIR] NAME       生成迁移文件骨架
+  app migrate seed                      填充初始数据`
+
+// runMigrate 数据库迁移命令，仅初始化配置、日志与数据库，不启动应用服务
+//
+//	app migrate status
+//	app migrate up -dry-run
+//	app migrate down -to user_202610191000
+//	app migrate new add_user_nickname
+//	app migrate seed
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n%s", migrateUsage)
+	}
+	sub, args := args[0], args[1:]
+
+	flags := flag.NewFlagSet(migrateCommand+" "+sub, flag.ExitOnError)
+	to := flags.String("to", "", "目标迁移ID")
+	dryRun := flags.Bool("dry-run", false, "仅输出将执行的SQL，不执行")
+	dir := flags.String("dir", migration.DefaultDir, "迁移文件目录")
+	_ = flags.Parse(args)
+
+	var version []string
+	if *to != "" {
+		version = append(version, *to)
+	}
+
+	switch sub {
+	case "new":
+		if flags.NArg() != 1 {
+			return fmt.Errorf("migration name required\n%s", migrateUsage)
+		}
+		path, name, err := migration.Create(*dir, flags.Arg(0), time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created %s\nappend %s to allMigrations in migration.go (runs in migration id time order)\n", path, name)
+		return nil
+	case "status", "up", "down", "seed":
+	default:
+		return fmt.Errorf("unknown subcommand %q\n%s", sub, migrateUsage)
+	}
+
+	tx, err := migrateDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	switch sub {
+	case "status":
+		return printMigrationStatus(ctx, tx)
+	case "up":
+		if *dryRun {
+			return migration.MigrateDryRun(ctx, tx, os.Stdout, version...)
+		}
+		return migration.Migrate(ctx, tx, version...)
+	case "down":
+		if *dryRun {
+			return migration.RollbackDryRun(ctx, tx, os.Stdout, version...)
+		}
+		return migration.Rollback(ctx, tx, version...)
+	default:
+		return migration.Seed(ctx, tx)
+	}
+}
+
+// migrateDB 初始化迁移所需的数据库连接，忽略 Database.Migration.Enabled 以免重复迁移
+func migrateDB() (transactions.TransactionManager, error) {
+	cfg, err := provider.ProvideConfig()
+	if err != nil {
+		return nil, fmt.Errorf("配置加载失败: %w", err)
+	}
+	logger.InitLogger(cfg.App.Debug)
+
+	conn, err := db.Initialize(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return transactions.NewGormTransactionManager(conn), nil
+}
+
+func printMigrationStatus(ctx context.Context, tx transactions.TransactionManager) error {
+	status, err := migration.Status(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tSTATUS")
+	pending := 0
+	for _, s := range status {
+		state := "applied"
+		if !s.Applied {
+			state = "pending"
+			pending++
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", s.ID, state)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("total: %d, pending: %d\n", len(status), pending)
+	return nil
+}
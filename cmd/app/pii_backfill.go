@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/dysodeng/app/internal/infrastructure/migration"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
+)
+
+// piiBackfillCommand 敏感字段重写命令名称
+const piiBackfillCommand = "pii:backfill"
+
+// runPIIBackfill 按当前加密配置重写存量敏感字段，用于开启加密、轮换密钥或关闭加密后处理存量数据
+//
+//	app pii:backfill -dry-run
+//	app pii:backfill -batch 1000
+func runPIIBackfill(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet(piiBackfillCommand, flag.ExitOnError)
+	batchSize := flags.Int("batch", 500, "每批处理的行数")
+	dryRun := flags.Bool("dry-run", false, "仅统计需要重写的行数，不写入")
+	_ = flags.Parse(args)
+
+	tx, err := migrateDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	results, err := migration.BackfillPII(ctx, tx, *batchSize, *dryRun)
+	for _, result := range results {
+		fmt.Printf("%s: scanned %d, updated %d\n", result.Table, result.Scanned, result.Updated)
+	}
+	if err == nil && *dryRun {
+		fmt.Println("dry run, nothing written")
+	}
+	return err
+}
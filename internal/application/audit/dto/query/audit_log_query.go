@@ -0,0 +1,16 @@
+package query
+
+import "time"
+
+// AuditLogListQuery 审计日志列表查询
+type AuditLogListQuery struct {
+	ActorType  string
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	StartTime  *time.Time
+	EndTime    *time.Time
+	Cursor     string
+	PageSize   int
+}
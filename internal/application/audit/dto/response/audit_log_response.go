@@ -0,0 +1,59 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/domain/audit/model"
+)
+
+// AuditLogResponse 审计日志响应
+type AuditLogResponse struct {
+	ID         uuid.UUID           `json:"id"`
+	ActorType  string              `json:"actor_type"`
+	ActorID    string              `json:"actor_id"`
+	Action     string              `json:"action"`
+	TargetType string              `json:"target_type"`
+	TargetID   string              `json:"target_id"`
+	Before     json.RawMessage     `json:"before"`
+	After      json.RawMessage     `json:"after"`
+	Changes    []model.FieldChange `json:"changes"`
+	IP         string              `json:"ip"`
+	UserAgent  string              `json:"user_agent"`
+	TraceID    string              `json:"trace_id"`
+	OccurredAt time.Time           `json:"occurred_at"`
+}
+
+// FromDomainModel 从领域模型转换
+func (r *AuditLogResponse) FromDomainModel(l *model.AuditLog) {
+	r.ID = l.ID
+	r.ActorType = l.ActorType
+	r.ActorID = l.ActorID
+	r.Action = l.Action
+	r.TargetType = l.TargetType
+	r.TargetID = l.TargetID
+	r.Before = rawJSON(l.Before)
+	r.After = rawJSON(l.After)
+	r.Changes = l.Changes
+	r.IP = l.IP
+	r.UserAgent = l.UserAgent
+	r.TraceID = l.TraceID
+	r.OccurredAt = l.OccurredAt
+}
+
+// AuditLogListResponse 审计日志列表响应，按游标翻页
+type AuditLogListResponse struct {
+	Record     []AuditLogResponse `json:"record"`
+	Total      int64              `json:"total"` // 仅首页统计，翻页时为-1
+	NextCursor string             `json:"next_cursor"`
+	HasMore    bool               `json:"has_more"`
+}
+
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(s)
+}
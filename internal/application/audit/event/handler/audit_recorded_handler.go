@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+
+	auditEvent "github.com/dysodeng/app/internal/domain/audit/event"
+	"github.com/dysodeng/app/internal/domain/audit/model"
+	"github.com/dysodeng/app/internal/domain/audit/repository"
+	"github.com/dysodeng/app/internal/infrastructure/event"
+)
+
+// AuditRecordedHandler 管理操作审计事件处理器，将审计事件追加到审计日志
+type AuditRecordedHandler struct {
+	event.DomainEventHandler[auditEvent.AuditRecorded]
+	auditLogRepository repository.AuditLogRepository
+}
+
+// NewAuditRecordedHandler 创建管理操作审计事件处理器
+func NewAuditRecordedHandler(auditLogRepository repository.AuditLogRepository) *AuditRecordedHandler {
+	return &AuditRecordedHandler{
+		auditLogRepository: auditLogRepository,
+	}
+}
+
+// Handle 事件处理
+func (h *AuditRecordedHandler) Handle(ctx context.Context, event any) error {
+	domainEvent, err := h.ParseDomainEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	payload := domainEvent.Payload()
+	return h.auditLogRepository.Append(ctx, &model.AuditLog{
+		EventID:    domainEvent.EventID(),
+		ActorType:  payload.ActorType,
+		ActorID:    payload.ActorID,
+		Action:     payload.Action,
+		TargetType: payload.TargetType,
+		TargetID:   payload.TargetID,
+		Before:     string(payload.Before),
+		After:      string(payload.After),
+		Changes:    payload.Changes,
+		IP:         payload.IP,
+		UserAgent:  payload.UserAgent,
+		TraceID:    payload.TraceID,
+		OccurredAt: payload.OccurredAt,
+	})
+}
+
+// InterestedEventTypes 返回感兴趣的事件列表
+func (h *AuditRecordedHandler) InterestedEventTypes() []string {
+	return []string{auditEvent.AuditRecordedEventType}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/dysodeng/app/internal/application/audit/dto/query"
+	"github.com/dysodeng/app/internal/application/audit/dto/response"
+	"github.com/dysodeng/app/internal/domain/audit/model"
+	"github.com/dysodeng/app/internal/domain/audit/repository"
+	sharedErrors "github.com/dysodeng/app/internal/domain/shared/errors"
+	"github.com/dysodeng/app/internal/domain/shared/port"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+const (
+	exportPageSize = 500
+	exportMaxRows  = 100000 // 单次导出上限，超出部分需缩小查询范围
+)
+
+// AuditLogApplicationService 审计日志应用服务
+type AuditLogApplicationService interface {
+	// List 审计日志列表
+	List(ctx context.Context, qry *query.AuditLogListQuery) (*response.AuditLogListResponse, error)
+	// Export 按查询条件导出审计日志为CSV
+	Export(ctx context.Context, qry *query.AuditLogListQuery, w io.Writer) error
+}
+
+type auditLogApplicationService struct {
+	baseTraceSpanName  string
+	auditLogRepository repository.AuditLogRepository
+	auditRecorder      port.AuditRecorder
+}
+
+func NewAuditLogApplicationService(
+	auditLogRepository repository.AuditLogRepository,
+	auditRecorder port.AuditRecorder,
+) AuditLogApplicationService {
+	return &auditLogApplicationService{
+		baseTraceSpanName:  "application.audit.AuditLogApplicationService",
+		auditLogRepository: auditLogRepository,
+		auditRecorder:      auditRecorder,
+	}
+}
+
+func (svc *auditLogApplicationService) List(ctx context.Context, qry *query.AuditLogListQuery) (*response.AuditLogListResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".List")
+	defer span.End()
+
+	// 仅首页统计总数
+	list, page, err := svc.auditLogRepository.FindList(spanCtx, svc.buildQuery(qry, qry.Cursor, qry.PageSize, qry.Cursor == ""))
+	if err != nil {
+		return nil, svc.translateError(spanCtx, "审计日志查询失败", err)
+	}
+
+	res := &response.AuditLogListResponse{
+		Record:     make([]response.AuditLogResponse, len(list)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for i := range list {
+		res.Record[i].FromDomainModel(&list[i])
+	}
+	return res, nil
+}
+
+func (svc *auditLogApplicationService) Export(ctx context.Context, qry *query.AuditLogListQuery, w io.Writer) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Export")
+	defer span.End()
+
+	// 先查询首页，查询条件有误时尚未写出任何内容
+	list, page, err := svc.auditLogRepository.FindList(spanCtx, svc.buildQuery(qry, "", exportPageSize, false))
+	if err != nil {
+		return svc.translateError(spanCtx, "审计日志导出失败", err)
+	}
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{
+		"occurred_at", "actor_type", "actor_id", "action", "target_type", "target_id",
+		"changes", "ip", "user_agent", "trace_id",
+	})
+
+	rows := 0
+	for {
+		for i := range list {
+			if err = writer.Write(auditLogRecord(&list[i])); err != nil {
+				return err
+			}
+		}
+		rows += len(list)
+		writer.Flush()
+		if err = writer.Error(); err != nil {
+			return err
+		}
+		if !page.HasMore || rows >= exportMaxRows {
+			break
+		}
+		list, page, err = svc.auditLogRepository.FindList(spanCtx, svc.buildQuery(qry, page.NextCursor, exportPageSize, false))
+		if err != nil {
+			logger.Error(spanCtx, "审计日志导出中断", logger.ErrorField(err))
+			return err
+		}
+	}
+
+	// 导出审计日志本身也记录审计
+	if err = svc.auditRecorder.Record(spanCtx, model.ActionExport, "audit_log", "", nil, qry); err != nil {
+		logger.Error(spanCtx, "审计日志导出审计记录失败", logger.ErrorField(err))
+	}
+	return nil
+}
+
+func (svc *auditLogApplicationService) buildQuery(qry *query.AuditLogListQuery, cursor string, pageSize int, withTotal bool) repository.AuditLogQuery {
+	return repository.AuditLogQuery{
+		ActorType:  qry.ActorType,
+		ActorID:    qry.ActorID,
+		Action:     qry.Action,
+		TargetType: qry.TargetType,
+		TargetID:   qry.TargetID,
+		StartTime:  qry.StartTime,
+		EndTime:    qry.EndTime,
+		PageRequest: sharedVO.PageRequest{
+			Cursor:    cursor,
+			PageSize:  pageSize,
+			WithTotal: withTotal,
+		},
+	}
+}
+
+func (svc *auditLogApplicationService) translateError(ctx context.Context, message string, err error) error {
+	if sharedVO.IsInvalidPageRequest(err) {
+		return sharedErrors.NewCommonError(sharedErrors.CodeCommonValidationError, "分页游标无效", err)
+	}
+	logger.Error(ctx, message, logger.ErrorField(err))
+	return sharedErrors.NewCommonError(sharedErrors.CodeCommonOperationFailed, message, err)
+}
+
+func auditLogRecord(l *model.AuditLog) []string {
+	changes, _ := sonic.MarshalString(l.Changes)
+	return []string{
+		l.OccurredAt.Format(time.DateTime),
+		l.ActorType,
+		l.ActorID,
+		l.Action,
+		l.TargetType,
+		l.TargetID,
+		changes,
+		l.IP,
+		l.UserAgent,
+		l.TraceID,
+	}
+}
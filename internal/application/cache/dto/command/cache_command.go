@@ -0,0 +1,7 @@
+package command
+
+// PurgeCacheCommand 清理缓存
+type PurgeCacheCommand struct {
+	Namespace string
+	Tags      []string // 为空时清空整个命名空间
+}
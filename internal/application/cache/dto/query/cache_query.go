@@ -0,0 +1,8 @@
+package query
+
+// CacheKeyQuery 缓存key查询
+type CacheKeyQuery struct {
+	Namespace string
+	Key       string   // 业务key，如 id:xxx
+	Tags      []string // 写入缓存时使用的标签
+}
@@ -0,0 +1,42 @@
+package response
+
+import (
+	"time"
+
+	"github.com/dysodeng/app/internal/domain/shared/port"
+)
+
+// TagVersionResponse 标签版本
+type TagVersionResponse struct {
+	Tag     string `json:"tag"`
+	Version string `json:"version"`
+}
+
+// CacheKeyResponse 缓存key诊断信息
+type CacheKeyResponse struct {
+	Namespace    string               `json:"namespace"`
+	Key          string               `json:"key"`
+	Tags         []TagVersionResponse `json:"tags"`
+	Exists       bool                 `json:"exists"`
+	Negative     bool                 `json:"negative"`
+	Fresh        bool                 `json:"fresh"`
+	ExpireAt     *time.Time           `json:"expire_at"`
+	LoadDuration string               `json:"load_duration"`
+	Size         int                  `json:"size"`
+}
+
+// FromKeyInfo 从缓存诊断信息转换
+func (r *CacheKeyResponse) FromKeyInfo(info *port.CacheKeyInfo) {
+	r.Namespace = info.Namespace
+	r.Key = info.Key
+	r.Tags = make([]TagVersionResponse, len(info.Tags))
+	for i, tv := range info.Tags {
+		r.Tags[i] = TagVersionResponse{Tag: tv.Tag, Version: tv.Version}
+	}
+	r.Exists = info.Exists
+	r.Negative = info.Negative
+	r.Fresh = info.Fresh
+	r.ExpireAt = info.ExpireAt
+	r.LoadDuration = info.LoadDuration.String()
+	r.Size = info.Size
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dysodeng/app/internal/application/cache/dto/command"
+	"github.com/dysodeng/app/internal/application/cache/dto/query"
+	"github.com/dysodeng/app/internal/application/cache/dto/response"
+	auditModel "github.com/dysodeng/app/internal/domain/audit/model"
+	sharedErrors "github.com/dysodeng/app/internal/domain/shared/errors"
+	"github.com/dysodeng/app/internal/domain/shared/port"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+// errCacheNamespaceNotFound 缓存命名空间不存在
+var errCacheNamespaceNotFound = sharedErrors.NewCommonError(sharedErrors.CodeCommonNotFound, "缓存命名空间不存在", nil)
+
+// CacheApplicationService 缓存诊断应用服务
+type CacheApplicationService interface {
+	// Namespaces 缓存命名空间列表
+	Namespaces(ctx context.Context) []string
+	// Inspect 查看缓存key及其标签版本
+	Inspect(ctx context.Context, qry *query.CacheKeyQuery) (*response.CacheKeyResponse, error)
+	// Purge 按标签失效或清空命名空间
+	Purge(ctx context.Context, cmd *command.PurgeCacheCommand) error
+}
+
+type cacheApplicationService struct {
+	baseTraceSpanName string
+	cacheInspector    port.CacheInspector
+	auditRecorder     port.AuditRecorder
+}
+
+func NewCacheApplicationService(cacheInspector port.CacheInspector, auditRecorder port.AuditRecorder) CacheApplicationService {
+	return &cacheApplicationService{
+		baseTraceSpanName: "application.cache.CacheApplicationService",
+		cacheInspector:    cacheInspector,
+		auditRecorder:     auditRecorder,
+	}
+}
+
+func (svc *cacheApplicationService) Namespaces(ctx context.Context) []string {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Namespaces")
+	defer span.End()
+
+	return svc.cacheInspector.Namespaces(spanCtx)
+}
+
+func (svc *cacheApplicationService) Inspect(ctx context.Context, qry *query.CacheKeyQuery) (*response.CacheKeyResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Inspect")
+	defer span.End()
+
+	info, err := svc.cacheInspector.Inspect(spanCtx, qry.Namespace, qry.Key, qry.Tags...)
+	if errors.Is(err, port.ErrCacheNamespaceNotFound) {
+		return nil, errCacheNamespaceNotFound
+	}
+	if err != nil {
+		logger.Error(spanCtx, "缓存查询失败", logger.ErrorField(err))
+		return nil, sharedErrors.ErrCommonOperationFailed.WrapNew(err)
+	}
+
+	var res response.CacheKeyResponse
+	res.FromKeyInfo(info)
+	return &res, nil
+}
+
+func (svc *cacheApplicationService) Purge(ctx context.Context, cmd *command.PurgeCacheCommand) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Purge")
+	defer span.End()
+
+	var err error
+	if len(cmd.Tags) > 0 {
+		err = svc.cacheInspector.InvalidateTags(spanCtx, cmd.Namespace, cmd.Tags...)
+	} else {
+		err = svc.cacheInspector.Purge(spanCtx, cmd.Namespace)
+	}
+	if errors.Is(err, port.ErrCacheNamespaceNotFound) {
+		return errCacheNamespaceNotFound
+	}
+	if err != nil {
+		logger.Error(spanCtx, "缓存清理失败", logger.ErrorField(err))
+		return sharedErrors.ErrCommonOperationFailed.WrapNew(err)
+	}
+	logger.Info(spanCtx, "缓存已清理", logger.AddField("namespace", cmd.Namespace), logger.AddField("tags", cmd.Tags))
+	if err = svc.auditRecorder.Record(spanCtx, auditModel.ActionPurge, "cache_namespace", cmd.Namespace, nil, cmd); err != nil {
+		logger.Error(spanCtx, "缓存清理审计记录失败", logger.ErrorField(err))
+	}
+	return nil
+}
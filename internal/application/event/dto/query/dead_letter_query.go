@@ -0,0 +1,10 @@
+package query
+
+// DeadLetterListQuery 死信列表查询
+type DeadLetterListQuery struct {
+	Status    uint8
+	EventType string
+	Handler   string
+	Page      int
+	PageSize  int
+}
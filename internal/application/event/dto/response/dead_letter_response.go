@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/dysodeng/app/internal/domain/shared/port"
+)
+
+// DeadLetterResponse 死信响应
+type DeadLetterResponse struct {
+	ID        uint64    `json:"id"`
+	EventID   string    `json:"event_id"`
+	EventType string    `json:"event_type"`
+	Handler   string    `json:"handler"`
+	Payload   string    `json:"payload"`
+	Error     string    `json:"error"`
+	Attempts  int       `json:"attempts"`
+	Status    uint8     `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FromDeadLetter 从死信转换
+func (r *DeadLetterResponse) FromDeadLetter(record *port.DeadLetter) {
+	r.ID = record.ID
+	r.EventID = record.EventID
+	r.EventType = record.EventType
+	r.Handler = record.Handler
+	r.Payload = record.Payload
+	r.Error = record.Error
+	r.Attempts = record.Attempts
+	r.Status = record.Status
+	r.CreatedAt = record.CreatedAt
+	r.UpdatedAt = record.UpdatedAt
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/dysodeng/app/internal/application/event/dto/query"
+	"github.com/dysodeng/app/internal/application/event/dto/response"
+	auditModel "github.com/dysodeng/app/internal/domain/audit/model"
+	sharedErrors "github.com/dysodeng/app/internal/domain/shared/errors"
+	"github.com/dysodeng/app/internal/domain/shared/port"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+// DeadLetterApplicationService 事件死信应用服务
+type DeadLetterApplicationService interface {
+	// List 死信列表
+	List(ctx context.Context, qry *query.DeadLetterListQuery) ([]response.DeadLetterResponse, int64, error)
+	// Info 死信详情
+	Info(ctx context.Context, id uint64) (*response.DeadLetterResponse, error)
+	// Replay 重放死信
+	Replay(ctx context.Context, id uint64) error
+	// Discard 丢弃死信
+	Discard(ctx context.Context, id uint64) error
+}
+
+type deadLetterApplicationService struct {
+	baseTraceSpanName string
+	deadLetterQueue   port.DeadLetterQueue
+	auditRecorder     port.AuditRecorder
+}
+
+func NewDeadLetterApplicationService(
+	deadLetterQueue port.DeadLetterQueue,
+	auditRecorder port.AuditRecorder,
+) DeadLetterApplicationService {
+	return &deadLetterApplicationService{
+		baseTraceSpanName: "application.event.DeadLetterApplicationService",
+		deadLetterQueue:   deadLetterQueue,
+		auditRecorder:     auditRecorder,
+	}
+}
+
+func (svc *deadLetterApplicationService) List(ctx context.Context, qry *query.DeadLetterListQuery) ([]response.DeadLetterResponse, int64, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".List")
+	defer span.End()
+
+	records, total, err := svc.deadLetterQueue.List(spanCtx, port.DeadLetterFilter{
+		Status:    qry.Status,
+		EventType: qry.EventType,
+		Handler:   qry.Handler,
+		Page:      qry.Page,
+		PageSize:  qry.PageSize,
+	})
+	if err != nil {
+		logger.Error(spanCtx, "死信列表查询失败", logger.ErrorField(err))
+		return nil, 0, sharedErrors.ErrCommonOperationFailed.WrapNew(err)
+	}
+
+	list := make([]response.DeadLetterResponse, len(records))
+	for i := range records {
+		list[i].FromDeadLetter(&records[i])
+	}
+	return list, total, nil
+}
+
+func (svc *deadLetterApplicationService) Info(ctx context.Context, id uint64) (*response.DeadLetterResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Info")
+	defer span.End()
+
+	record, err := svc.deadLetterQueue.Find(spanCtx, id)
+	if err != nil {
+		return nil, svc.translateError(spanCtx, "死信查询失败", err)
+	}
+
+	var res response.DeadLetterResponse
+	res.FromDeadLetter(record)
+	return &res, nil
+}
+
+func (svc *deadLetterApplicationService) Replay(ctx context.Context, id uint64) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Replay")
+	defer span.End()
+
+	if err := svc.deadLetterQueue.Replay(spanCtx, id); err != nil {
+		return svc.translateError(spanCtx, "死信重放失败", err)
+	}
+	logger.Info(spanCtx, "死信已重放", logger.AddField("id", id))
+	svc.audit(spanCtx, auditModel.ActionReplay, id)
+	return nil
+}
+
+func (svc *deadLetterApplicationService) Discard(ctx context.Context, id uint64) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Discard")
+	defer span.End()
+
+	if err := svc.deadLetterQueue.Discard(spanCtx, id); err != nil {
+		return svc.translateError(spanCtx, "死信丢弃失败", err)
+	}
+	logger.Info(spanCtx, "死信已丢弃", logger.AddField("id", id))
+	svc.audit(spanCtx, auditModel.ActionDiscard, id)
+	return nil
+}
+
+// audit 记录死信处理审计，失败仅记录日志不影响业务
+func (svc *deadLetterApplicationService) audit(ctx context.Context, action string, id uint64) {
+	if err := svc.auditRecorder.Record(ctx, action, "event_dead_letter", strconv.FormatUint(id, 10), nil, nil); err != nil {
+		logger.Error(ctx, "死信审计记录失败", logger.ErrorField(err))
+	}
+}
+
+func (svc *deadLetterApplicationService) translateError(ctx context.Context, message string, err error) error {
+	switch {
+	case errors.Is(err, port.ErrDeadLetterNotFound):
+		return sharedErrors.NewCommonError(sharedErrors.CodeCommonNotFound, "死信不存在", err)
+	case errors.Is(err, port.ErrDeadLetterNotPending):
+		return sharedErrors.NewCommonError(sharedErrors.CodeCommonOperationFailed, "死信已处理", err)
+	default:
+		logger.Error(ctx, message, logger.ErrorField(err))
+		return sharedErrors.NewCommonError(sharedErrors.CodeCommonOperationFailed, message, err)
+	}
+}
@@ -6,8 +6,9 @@ import (
 	"github.com/google/uuid"
 
 	fileModel "github.com/dysodeng/app/internal/domain/file/model"
+	fileRepo "github.com/dysodeng/app/internal/domain/file/repository"
 	fileDomainSvc "github.com/dysodeng/app/internal/domain/file/service"
-	fileVO "github.com/dysodeng/app/internal/domain/file/valueobject"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
 	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
 )
 
@@ -35,10 +36,10 @@ func (t *TracedFileDomainService) Info(ctx context.Context, id uuid.UUID) (*file
 	return t.inner.Info(spanCtx, id)
 }
 
-func (t *TracedFileDomainService) List(ctx context.Context, mediaType fileVO.MediaType, keyword, orderBy, orderType string, page, pageSize int) ([]fileModel.File, int64, error) {
+func (t *TracedFileDomainService) List(ctx context.Context, query fileRepo.FileQuery) ([]fileModel.File, sharedVO.PageInfo, error) {
 	spanCtx, span := trace.Tracer().Start(ctx, t.baseSpan+".List")
 	defer span.End()
-	return t.inner.List(spanCtx, mediaType, keyword, orderBy, orderType, page, pageSize)
+	return t.inner.List(spanCtx, query)
 }
 
 func (t *TracedFileDomainService) Delete(ctx context.Context, id uuid.UUID, ids []uuid.UUID) error {
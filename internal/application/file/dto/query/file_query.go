@@ -0,0 +1,18 @@
+package query
+
+import "time"
+
+// FileListQuery 文件列表查询
+// Cursor 非空时按游标翻页并忽略 Page；WithTotal 为true时统计总数，大表慎用
+type FileListQuery struct {
+	MediaType uint8
+	Keyword   string
+	StartTime *time.Time
+	EndTime   *time.Time
+	OrderBy   string
+	OrderType string
+	Cursor    string
+	Page      int
+	PageSize  int
+	WithTotal bool
+}
@@ -36,6 +36,8 @@ func (f *FileResponse) FromDomainModel(file *model.File) {
 
 // FileListResponse 文件列表响应
 type FileListResponse struct {
-	Total int64          `json:"total"`
-	Items []FileResponse `json:"items"`
+	Total      int64          `json:"total"` // 未统计总数时为-1
+	Items      []FileResponse `json:"items"`
+	NextCursor string         `json:"next_cursor"`
+	HasMore    bool           `json:"has_more"`
 }
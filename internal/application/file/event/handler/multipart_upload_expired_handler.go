@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+
+	fileEvent "github.com/dysodeng/app/internal/domain/file/event"
+	fileModel "github.com/dysodeng/app/internal/domain/file/model"
+	filePort "github.com/dysodeng/app/internal/domain/file/port"
+	fileRepository "github.com/dysodeng/app/internal/domain/file/repository"
+	"github.com/dysodeng/app/internal/infrastructure/event"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+)
+
+// MultipartUploadExpiredHandler 分片上传过期事件处理器
+// 过期时仍未完成的分片上传将被取消，并清理存储中已上传的分片
+type MultipartUploadExpiredHandler struct {
+	event.DomainEventHandler[fileEvent.MultipartUploadExpired]
+	uploaderRepository fileRepository.UploaderRepository
+	storage            filePort.FileStorage
+}
+
+// NewMultipartUploadExpiredHandler 创建分片上传过期事件处理器
+func NewMultipartUploadExpiredHandler(
+	uploaderRepository fileRepository.UploaderRepository,
+	storage filePort.FileStorage,
+) *MultipartUploadExpiredHandler {
+	return &MultipartUploadExpiredHandler{
+		uploaderRepository: uploaderRepository,
+		storage:            storage,
+	}
+}
+
+// Handle 事件处理
+func (h *MultipartUploadExpiredHandler) Handle(ctx context.Context, event any) error {
+	domainEvent, err := h.ParseDomainEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	payload := domainEvent.Payload()
+
+	mu, err := h.uploaderRepository.FindMultipartUploadByUploadId(ctx, payload.UploadID)
+	if err != nil {
+		return err
+	}
+	if mu == nil || mu.UploadID == "" || !mu.IsUploading() {
+		// 上传已完成或已取消
+		return nil
+	}
+
+	if err = h.storage.AbortMultipartUpload(ctx, mu.Path, mu.UploadID); err != nil {
+		logger.Warn(ctx, "取消过期分片上传失败", logger.AddField("upload_id", mu.UploadID), logger.ErrorField(err))
+	}
+	if err = h.uploaderRepository.MultipartUploadStatus(ctx, mu.UploadID, fileModel.MultipartUploadStatusAborted); err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "分片上传已过期取消",
+		logger.AddField("upload_id", mu.UploadID),
+		logger.AddField("path", mu.Path),
+	)
+	return nil
+}
+
+// InterestedEventTypes 返回感兴趣的事件列表
+func (h *MultipartUploadExpiredHandler) InterestedEventTypes() []string {
+	return []string{fileEvent.MultipartUploadExpiredEventType}
+}
@@ -5,9 +5,15 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/dysodeng/app/internal/application/file/dto/query"
 	"github.com/dysodeng/app/internal/application/file/dto/response"
+	auditModel "github.com/dysodeng/app/internal/domain/audit/model"
+	"github.com/dysodeng/app/internal/domain/file/repository"
 	"github.com/dysodeng/app/internal/domain/file/service"
+	"github.com/dysodeng/app/internal/domain/file/valueobject"
 	"github.com/dysodeng/app/internal/domain/shared/errors"
+	"github.com/dysodeng/app/internal/domain/shared/port"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
 	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
 	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
 )
@@ -16,17 +22,23 @@ import (
 type FileApplicationService interface {
 	// FileInfo 获取文件信息
 	FileInfo(ctx context.Context, id string) (*response.FileResponse, error)
+	// List 文件列表
+	List(ctx context.Context, qry *query.FileListQuery) (*response.FileListResponse, error)
+	// Delete 删除文件
+	Delete(ctx context.Context, id string) error
 }
 
 type fileApplicationService struct {
 	baseTraceSpanName string
 	fileDomainService service.FileDomainService
+	auditRecorder     port.AuditRecorder
 }
 
-func NewFileApplicationService(fileDomainService service.FileDomainService) FileApplicationService {
+func NewFileApplicationService(fileDomainService service.FileDomainService, auditRecorder port.AuditRecorder) FileApplicationService {
 	return &fileApplicationService{
 		baseTraceSpanName: "application.file.FileApplicationService",
 		fileDomainService: fileDomainService,
+		auditRecorder:     auditRecorder,
 	}
 }
 
@@ -58,3 +70,63 @@ func (svc *fileApplicationService) FileInfo(ctx context.Context, id string) (*re
 		CreatedAt: info.CreatedAt,
 	}, nil
 }
+
+func (svc *fileApplicationService) List(ctx context.Context, qry *query.FileListQuery) (*response.FileListResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".List")
+	defer span.End()
+
+	list, page, err := svc.fileDomainService.List(spanCtx, repository.FileQuery{
+		MediaType: valueobject.MediaType(qry.MediaType),
+		Keyword:   qry.Keyword,
+		StartTime: qry.StartTime,
+		EndTime:   qry.EndTime,
+		PageRequest: sharedVO.PageRequest{
+			Cursor:    qry.Cursor,
+			Page:      qry.Page,
+			PageSize:  qry.PageSize,
+			OrderBy:   qry.OrderBy,
+			OrderType: qry.OrderType,
+			WithTotal: qry.WithTotal,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.FileListResponse{
+		Total:      page.Total,
+		Items:      make([]response.FileResponse, len(list)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for i := range list {
+		res.Items[i].FromDomainModel(&list[i])
+	}
+	return res, nil
+}
+
+func (svc *fileApplicationService) Delete(ctx context.Context, id string) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Delete")
+	defer span.End()
+
+	fileId, err := uuid.Parse(id)
+	if err != nil {
+		logger.Warn(spanCtx, "文件ID格式错误", logger.ErrorField(err))
+		return errors.NewFileError("FILE_ID_INVALID", "文件ID格式错误", nil).Wrap(err)
+	}
+
+	info, err := svc.fileDomainService.Info(spanCtx, fileId)
+	if err != nil {
+		return err
+	}
+	if err = svc.fileDomainService.Delete(spanCtx, fileId, nil); err != nil {
+		return err
+	}
+
+	var before response.FileResponse
+	before.FromDomainModel(info)
+	if err = svc.auditRecorder.Record(spanCtx, auditModel.ActionDelete, "file", id, before, nil); err != nil {
+		logger.Error(spanCtx, "文件删除审计记录失败", logger.ErrorField(err))
+	}
+	return nil
+}
@@ -76,9 +76,12 @@ func (svc *uploaderApplicationService) UploadFile(ctx context.Context, file *mul
 		return nil, err
 	}
 
-	// 持久化（事务）
+	// 持久化并发布领域事件（同一事务）
 	if err = svc.txManager.Transaction(spanCtx, func(txCtx context.Context) error {
-		return svc.fileRepository.Save(txCtx, f)
+		if err := svc.fileRepository.Save(txCtx, f); err != nil {
+			return err
+		}
+		return svc.publishFileUploaded(txCtx, f)
 	}); err != nil {
 		logger.Error(spanCtx, "保存文件记录失败", logger.ErrorField(err))
 		return nil, fileErrors.ErrFileRecordSaveFailed.Wrap(err)
@@ -86,17 +89,6 @@ func (svc *uploaderApplicationService) UploadFile(ctx context.Context, file *mul
 
 	f.Path = svc.storage.FullURL(spanCtx, f.Path)
 
-	// 发布领域事件
-	evt := fileEvent.NewFileUploadedEvent(f.ID, f.Name.String(), f.Path, f.Size)
-	if err = svc.eventPublisher.Publish(spanCtx, domainEvent.DomainEvent[any]{
-		Type:          evt.Type,
-		AggregateID:   evt.AggregateID,
-		AggregateName: evt.AggregateName,
-		Payload:       evt.Payload,
-	}); err != nil {
-		logger.Warn(spanCtx, "发布文件上传事件失败", logger.ErrorField(err))
-	}
-
 	fileRes := &response.FileResponse{}
 	fileRes.FromDomainModel(f)
 
@@ -117,7 +109,13 @@ func (svc *uploaderApplicationService) InitMultipartUpload(ctx context.Context,
 	mimeType := fs.TypeByExtension(filename)
 	mu := fileModel.NewMultipartUpload(filename, relPath, uint64(fileSize), mimeType, ext, uploadId)
 
-	if err = svc.uploaderRepository.CreateMultipartUpload(spanCtx, mu); err != nil {
+	// 创建上传记录并调度过期事件（同一事务），超过有效期未完成的上传将被取消
+	if err = svc.txManager.Transaction(spanCtx, func(txCtx context.Context) error {
+		if err := svc.uploaderRepository.CreateMultipartUpload(txCtx, mu); err != nil {
+			return err
+		}
+		return svc.scheduleMultipartUploadExpired(txCtx, mu)
+	}); err != nil {
 		_ = svc.storage.AbortMultipartUpload(spanCtx, relPath, uploadId)
 		logger.Error(spanCtx, "创建分片上传记录失败", logger.ErrorField(err))
 		return nil, fileErrors.ErrMultipartInitFailed.Wrap(err)
@@ -162,12 +160,15 @@ func (svc *uploaderApplicationService) CompleteMultipartUpload(ctx context.Conte
 		return nil, err
 	}
 
-	// 持久化文件记录与更新状态
+	// 持久化文件记录、更新状态并发布领域事件（同一事务）
 	if err = svc.txManager.Transaction(spanCtx, func(txCtx context.Context) error {
 		if err := svc.fileRepository.Save(txCtx, f); err != nil {
 			return err
 		}
-		return svc.uploaderRepository.MultipartUploadStatus(txCtx, uploadId, 2)
+		if err := svc.uploaderRepository.MultipartUploadStatus(txCtx, uploadId, 2); err != nil {
+			return err
+		}
+		return svc.publishFileUploaded(txCtx, f)
 	}); err != nil {
 		_ = svc.storage.AbortMultipartUpload(spanCtx, f.Path, uploadId)
 		_ = svc.uploaderRepository.MultipartUploadStatus(spanCtx, uploadId, 3)
@@ -177,16 +178,6 @@ func (svc *uploaderApplicationService) CompleteMultipartUpload(ctx context.Conte
 
 	f.Path = svc.storage.FullURL(spanCtx, f.Path)
 
-	evt := fileEvent.NewFileUploadedEvent(f.ID, f.Name.String(), f.Path, f.Size)
-	if err = svc.eventPublisher.Publish(spanCtx, domainEvent.DomainEvent[any]{
-		Type:          evt.Type,
-		AggregateID:   evt.AggregateID,
-		AggregateName: evt.AggregateName,
-		Payload:       evt.Payload,
-	}); err != nil {
-		logger.Warn(spanCtx, "发布文件上传事件失败", logger.ErrorField(err))
-	}
-
 	fileRes := &response.FileResponse{}
 	fileRes.FromDomainModel(f)
 	return fileRes, nil
@@ -207,3 +198,41 @@ func (svc *uploaderApplicationService) MultipartUploadStatus(ctx context.Context
 		Path:  svc.storage.FullURL(spanCtx, relPath),
 	}, nil
 }
+
+// publishFileUploaded 发布文件上传领域事件
+// 需在事务上下文中调用：启用事务发件箱时事件与文件记录一同提交或回滚，
+// 写入发件箱失败即事务已无法提交，随之中止上传；直接投递MQ时事件在事务提交后发送，发送失败仅记录日志
+func (svc *uploaderApplicationService) publishFileUploaded(ctx context.Context, f *fileModel.File) error {
+	evt := fileEvent.NewFileUploadedEvent(f.ID, f.Name.String(), svc.storage.FullURL(ctx, f.Path), f.Size)
+	if err := svc.eventPublisher.Publish(ctx, domainEvent.DomainEvent[any]{
+		ID:            evt.ID,
+		Type:          evt.Type,
+		Version:       evt.Version,
+		OccurredAt:    evt.OccurredAt,
+		AggregateID:   evt.AggregateID,
+		AggregateName: evt.AggregateName,
+		Payload:       evt.Payload,
+	}); err != nil {
+		logger.Error(ctx, "发布文件上传事件失败", logger.ErrorField(err))
+		return err
+	}
+	return nil
+}
+
+// scheduleMultipartUploadExpired 调度分片上传过期事件
+func (svc *uploaderApplicationService) scheduleMultipartUploadExpired(ctx context.Context, mu *fileModel.MultipartUpload) error {
+	evt := fileEvent.NewMultipartUploadExpiredEvent(mu.ID, mu.UploadID, mu.Path)
+	if err := svc.eventPublisher.PublishEventAfter(ctx, domainEvent.DomainEvent[any]{
+		ID:            evt.ID,
+		Type:          evt.Type,
+		Version:       evt.Version,
+		OccurredAt:    evt.OccurredAt,
+		AggregateID:   evt.AggregateID,
+		AggregateName: evt.AggregateName,
+		Payload:       evt.Payload,
+	}, fileModel.MultipartUploadExpiration); err != nil {
+		logger.Error(ctx, "调度分片上传过期事件失败", logger.ErrorField(err))
+		return err
+	}
+	return nil
+}
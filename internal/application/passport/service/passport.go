@@ -14,8 +14,11 @@ import (
 	"github.com/dysodeng/app/internal/domain/passport/valueobject"
 	permissionRepository "github.com/dysodeng/app/internal/domain/permission/repository"
 	sharedErrors "github.com/dysodeng/app/internal/domain/shared/errors"
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+	sharedPort "github.com/dysodeng/app/internal/domain/shared/port"
 	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
 	userErrors "github.com/dysodeng/app/internal/domain/user/errors"
+	userEvent "github.com/dysodeng/app/internal/domain/user/event"
 	userModel "github.com/dysodeng/app/internal/domain/user/model"
 	userRepository "github.com/dysodeng/app/internal/domain/user/repository"
 	"github.com/dysodeng/app/internal/domain/user/service"
@@ -24,6 +27,7 @@ import (
 	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
 	"github.com/dysodeng/app/internal/infrastructure/shared/redis"
 	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
 	"github.com/dysodeng/app/internal/infrastructure/shared/token"
 	"github.com/dysodeng/app/internal/infrastructure/shared/wx"
 )
@@ -40,18 +44,24 @@ type passportApplicationService struct {
 	userRepository    userRepository.UserRepository
 	userDomainService service.UserDomainService
 	adminRepository   permissionRepository.AdminRepository
+	eventPublisher    sharedPort.EventPublisher
+	txManager         sharedPort.TransactionManager
 }
 
 func NewPassportApplicationService(
 	userRepository userRepository.UserRepository,
 	userDomainService service.UserDomainService,
 	adminRepository permissionRepository.AdminRepository,
+	eventPublisher sharedPort.EventPublisher,
+	txManager sharedPort.TransactionManager,
 ) PassportApplicationService {
 	return &passportApplicationService{
 		baseTraceSpanName: "application.passport.service.PassportApplicationService",
 		userRepository:    userRepository,
 		userDomainService: userDomainService,
 		adminRepository:   adminRepository,
+		eventPublisher:    eventPublisher,
+		txManager:         txManager,
 	}
 }
 
@@ -106,7 +116,7 @@ func (svc *passportApplicationService) Login(ctx context.Context, cmd *command.L
 		return nil, passportErrors.ErrLoginUserTypeInvalid
 	}
 
-	tokenClaims, err := token.GenerateToken(cmd.UserType, data, attach)
+	tokenClaims, err := token.GenerateToken(cmd.UserType, withTenantClaim(spanCtx, data), attach)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +145,13 @@ func (svc *passportApplicationService) RefreshToken(ctx context.Context, refresh
 		return nil, passportErrors.ErrBizTokenCannotUsedForRefreshToken
 	}
 
+	// 刷新token只能在签发时的租户下使用
+	claimedTenant := helper.IfaceConvertString(claims[tenant.ClaimKey])
+	if current, ok := tenant.FromContext(spanCtx); ok && current != claimedTenant {
+		return nil, passportErrors.ErrTokenInvalid
+	}
+	spanCtx = tenant.WithContext(spanCtx, claimedTenant)
+
 	var data map[string]interface{}
 	var attach map[string]interface{}
 
@@ -171,7 +188,7 @@ func (svc *passportApplicationService) RefreshToken(ctx context.Context, refresh
 		return nil, passportErrors.ErrLoginUserTypeInvalid
 	}
 
-	tokenClaims, err := token.GenerateToken(userType, data, attach)
+	tokenClaims, err := token.GenerateToken(userType, withTenantClaim(spanCtx, data), attach)
 	if err != nil {
 		logger.Error(spanCtx, "token生成失败", logger.ErrorField(err))
 		return nil, err
@@ -187,6 +204,17 @@ func (svc *passportApplicationService) RefreshToken(ctx context.Context, refresh
 	}, nil
 }
 
+// withTenantClaim 将上下文中的租户写入token声明
+// 无租户时写入空值表示默认租户，启用多租户时缺少租户声明的token会被拒绝
+func withTenantClaim(ctx context.Context, data map[string]interface{}) map[string]interface{} {
+	id, _ := tenant.FromContext(ctx)
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	data[tenant.ClaimKey] = id
+	return data
+}
+
 func (svc *passportApplicationService) VerifyToken(ctx context.Context, cmd *command.VerifyTokenCommand) (map[string]interface{}, error) {
 	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".VerifyToken")
 	defer span.End()
@@ -308,7 +336,12 @@ func (svc *passportApplicationService) userLogin(ctx context.Context, cmd *comma
 				return nil, err
 			}
 
-			err = svc.userRepository.Save(ctx, userInfo)
+			err = svc.txManager.Transaction(ctx, func(txCtx context.Context) error {
+				if err := svc.userRepository.Save(txCtx, userInfo); err != nil {
+					return err
+				}
+				return svc.publishUserRegistered(txCtx, userInfo, valueobject.PlatformWxMinioProgram)
+			})
 			if err != nil {
 				return nil, userErrors.ErrUserRegisterFailed.Wrap(err)
 			}
@@ -426,3 +459,22 @@ func (svc *passportApplicationService) amsLogin(ctx context.Context, cmd *comman
 		Permissions: []string{},
 	}, nil
 }
+
+// publishUserRegistered 发布用户注册领域事件
+// 需在事务上下文中调用，事件与用户记录一同提交或回滚
+func (svc *passportApplicationService) publishUserRegistered(ctx context.Context, user *userModel.User, platform valueobject.PlatformType) error {
+	evt := userEvent.NewUserRegisteredEvent(user.ID, user.Nickname, platform.String())
+	if err := svc.eventPublisher.Publish(ctx, domainEvent.DomainEvent[any]{
+		ID:            evt.ID,
+		Type:          evt.Type,
+		Version:       evt.Version,
+		OccurredAt:    evt.OccurredAt,
+		AggregateID:   evt.AggregateID,
+		AggregateName: evt.AggregateName,
+		Payload:       evt.Payload,
+	}); err != nil {
+		logger.Error(ctx, "发布用户注册事件失败", logger.ErrorField(err))
+		return err
+	}
+	return nil
+}
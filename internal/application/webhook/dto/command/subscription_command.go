@@ -0,0 +1,20 @@
+package command
+
+import "github.com/google/uuid"
+
+// CreateSubscriptionCommand 创建Webhook订阅
+type CreateSubscriptionCommand struct {
+	Name       string
+	URL        string
+	EventTypes []string
+	Secret     string // 签名密钥，为空时自动生成
+}
+
+// UpdateSubscriptionCommand 修改Webhook订阅
+type UpdateSubscriptionCommand struct {
+	ID         uuid.UUID
+	Name       string
+	URL        string
+	EventTypes []string
+	Secret     string // 签名密钥，为空时不修改
+}
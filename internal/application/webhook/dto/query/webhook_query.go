@@ -0,0 +1,21 @@
+package query
+
+import "github.com/google/uuid"
+
+// SubscriptionListQuery Webhook订阅列表查询
+type SubscriptionListQuery struct {
+	Keyword   string
+	EventType string
+	Status    *uint8
+	Page      int
+	PageSize  int
+}
+
+// DeliveryListQuery Webhook投递记录列表查询
+type DeliveryListQuery struct {
+	SubscriptionID uuid.UUID
+	EventType      string
+	Status         uint8
+	Page           int
+	PageSize       int
+}
@@ -0,0 +1,79 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/domain/webhook/model"
+	"github.com/dysodeng/app/internal/domain/webhook/valueobject"
+)
+
+// SubscriptionResponse Webhook订阅响应
+type SubscriptionResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Secret              string     `json:"secret"` // 签名密钥，仅创建与重新生成时返回明文
+	Status              uint8      `json:"status"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// FromDomainModel 从领域模型转换，签名密钥脱敏
+func (r *SubscriptionResponse) FromDomainModel(s *model.Subscription) {
+	r.ID = s.ID
+	r.Name = s.Name
+	r.URL = s.URL
+	r.EventTypes = s.EventTypes
+	r.Secret = valueobject.MaskSecret(s.Secret)
+	r.Status = s.Status.Uint()
+	r.ConsecutiveFailures = s.ConsecutiveFailures
+	r.DisabledAt = s.DisabledAt
+	r.CreatedAt = s.CreatedAt
+	r.UpdatedAt = s.UpdatedAt
+}
+
+// RevealSecret 返回签名密钥明文，接收方据此校验签名
+func (r *SubscriptionResponse) RevealSecret(s *model.Subscription) {
+	r.Secret = s.Secret
+}
+
+// DeliveryResponse Webhook投递记录响应
+type DeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Payload        string     `json:"payload"`
+	Status         uint8      `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `json:"response_body"`
+	LastError      string     `json:"last_error"`
+	NextRetryAt    *time.Time `json:"next_retry_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// FromDomainModel 从领域模型转换
+func (r *DeliveryResponse) FromDomainModel(d *model.Delivery) {
+	r.ID = d.ID
+	r.SubscriptionID = d.SubscriptionID
+	r.EventID = d.EventID
+	r.EventType = d.EventType
+	r.Payload = d.Payload
+	r.Status = d.Status
+	r.Attempts = d.Attempts
+	r.ResponseStatus = d.ResponseStatus
+	r.ResponseBody = d.ResponseBody
+	r.LastError = d.LastError
+	r.NextRetryAt = d.NextRetryAt
+	r.DeliveredAt = d.DeliveredAt
+	r.CreatedAt = d.CreatedAt
+	r.UpdatedAt = d.UpdatedAt
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/dysodeng/app/internal/application/webhook/service"
+	webhookEvent "github.com/dysodeng/app/internal/domain/webhook/event"
+	"github.com/dysodeng/app/internal/infrastructure/event"
+)
+
+// DeliveryAttemptHandler Webhook投递尝试事件处理器
+type DeliveryAttemptHandler struct {
+	event.DomainEventHandler[webhookEvent.DeliveryAttempt]
+	deliveryService service.DeliveryApplicationService
+}
+
+// NewDeliveryAttemptHandler 创建Webhook投递尝试事件处理器
+func NewDeliveryAttemptHandler(deliveryService service.DeliveryApplicationService) *DeliveryAttemptHandler {
+	return &DeliveryAttemptHandler{deliveryService: deliveryService}
+}
+
+// Handle 事件处理
+func (h *DeliveryAttemptHandler) Handle(ctx context.Context, event any) error {
+	domainEvent, err := h.ParseDomainEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	payload := domainEvent.Payload()
+	return h.deliveryService.Attempt(ctx, payload.DeliveryID, payload.Attempt)
+}
+
+// InterestedEventTypes 返回感兴趣的事件列表
+func (h *DeliveryAttemptHandler) InterestedEventTypes() []string {
+	return []string{webhookEvent.DeliveryAttemptEventType}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/dysodeng/app/internal/application/webhook/service"
+)
+
+// WebhookDispatchHandler Webhook分发事件处理器
+// 将可订阅的领域事件分发给订阅了该事件的Webhook，实际投递由 DeliveryAttemptHandler 执行
+type WebhookDispatchHandler struct {
+	deliveryService service.DeliveryApplicationService
+}
+
+// NewWebhookDispatchHandler 创建Webhook分发事件处理器
+func NewWebhookDispatchHandler(deliveryService service.DeliveryApplicationService) *WebhookDispatchHandler {
+	return &WebhookDispatchHandler{deliveryService: deliveryService}
+}
+
+// Handle 事件处理
+func (h *WebhookDispatchHandler) Handle(ctx context.Context, event any) error {
+	e, ok := event.(interface {
+		EventID() string
+		EventType() string
+	})
+	if !ok {
+		return fmt.Errorf("unsupported event type: %T", event)
+	}
+
+	// 请求内容为完整的事件结构(id/type/version/timestamp/data/aggregate)
+	payload, err := sonic.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload failed: %w", err)
+	}
+	return h.deliveryService.Dispatch(ctx, e.EventID(), e.EventType(), payload)
+}
+
+// InterestedEventTypes 返回感兴趣的事件列表
+func (h *WebhookDispatchHandler) InterestedEventTypes() []string {
+	return service.SubscribableEventTypes
+}
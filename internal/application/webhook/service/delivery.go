@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+	sharedPort "github.com/dysodeng/app/internal/domain/shared/port"
+	webhookEvent "github.com/dysodeng/app/internal/domain/webhook/event"
+	"github.com/dysodeng/app/internal/domain/webhook/model"
+	webhookPort "github.com/dysodeng/app/internal/domain/webhook/port"
+	"github.com/dysodeng/app/internal/domain/webhook/repository"
+	"github.com/dysodeng/app/internal/domain/webhook/valueobject"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+// DeliveryApplicationService Webhook投递应用服务
+type DeliveryApplicationService interface {
+	// Dispatch 为订阅了该事件的启用订阅创建投递记录，并发布投递尝试事件
+	Dispatch(ctx context.Context, eventID, eventType string, payload []byte) error
+	// Attempt 执行一次投递，失败时按投递策略发布延时重试事件
+	Attempt(ctx context.Context, deliveryID uuid.UUID, attempt int) error
+}
+
+type deliveryApplicationService struct {
+	baseTraceSpanName      string
+	subscriptionRepository repository.SubscriptionRepository
+	deliveryRepository     repository.DeliveryRepository
+	sender                 webhookPort.WebhookSender
+	policy                 webhookPort.DeliveryPolicy
+	eventPublisher         sharedPort.EventPublisher
+	txManager              sharedPort.TransactionManager
+}
+
+func NewDeliveryApplicationService(
+	subscriptionRepository repository.SubscriptionRepository,
+	deliveryRepository repository.DeliveryRepository,
+	sender webhookPort.WebhookSender,
+	policy webhookPort.DeliveryPolicy,
+	eventPublisher sharedPort.EventPublisher,
+	txManager sharedPort.TransactionManager,
+) DeliveryApplicationService {
+	return &deliveryApplicationService{
+		baseTraceSpanName:      "application.webhook.service.DeliveryApplicationService",
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		sender:                 sender,
+		policy:                 policy,
+		eventPublisher:         eventPublisher,
+		txManager:              txManager,
+	}
+}
+
+func (svc *deliveryApplicationService) Dispatch(ctx context.Context, eventID, eventType string, payload []byte) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Dispatch")
+	defer span.End()
+
+	subscriptions, err := svc.subscriptionRepository.FindEnabledByEventType(spanCtx, eventType)
+	if err != nil {
+		logger.Error(spanCtx, "Webhook订阅查询失败", logger.ErrorField(err))
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	// 投递记录与投递尝试事件同一事务提交，每个订阅的投递相互独立地执行与重试
+	return svc.txManager.Transaction(spanCtx, func(txCtx context.Context) error {
+		for _, subscription := range subscriptions {
+			delivery := model.NewDelivery(subscription.ID, eventID, eventType, payload)
+			created, err := svc.deliveryRepository.Create(txCtx, delivery)
+			if err != nil {
+				logger.Error(txCtx, "Webhook投递记录创建失败", logger.ErrorField(err))
+				return err
+			}
+			if !created {
+				// 事件重复投递，该订阅已创建过投递记录
+				continue
+			}
+			if err = svc.publishAttempt(txCtx, delivery.ID, 1, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (svc *deliveryApplicationService) Attempt(ctx context.Context, deliveryID uuid.UUID, attempt int) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Attempt")
+	defer span.End()
+
+	delivery, err := svc.deliveryRepository.FindByID(spanCtx, deliveryID)
+	if err != nil {
+		logger.Error(spanCtx, "Webhook投递记录查询失败", logger.ErrorField(err))
+		return err
+	}
+	if delivery == nil || !delivery.IsPending() || delivery.Attempts >= attempt {
+		// 投递记录已删除、已结束或该次尝试已执行(事件重复投递)
+		return nil
+	}
+
+	subscription, err := svc.subscriptionRepository.FindByID(spanCtx, delivery.SubscriptionID)
+	if err != nil {
+		logger.Error(spanCtx, "Webhook订阅查询失败", logger.ErrorField(err))
+		return err
+	}
+	if subscription == nil || !subscription.IsEnabled() {
+		delivery.Abandon("订阅已停用或已删除")
+		return svc.deliveryRepository.Save(spanCtx, delivery)
+	}
+
+	timestamp := time.Now().Unix()
+	body := []byte(delivery.Payload)
+	result, sendErr := svc.sender.Send(spanCtx, subscription.URL, map[string]string{
+		valueobject.HeaderEvent:     delivery.EventType,
+		valueobject.HeaderEventID:   delivery.EventID,
+		valueobject.HeaderDelivery:  delivery.ID.String(),
+		valueobject.HeaderTimestamp: strconv.FormatInt(timestamp, 10),
+		valueobject.HeaderSignature: valueobject.Sign(subscription.Secret, timestamp, body),
+	}, body)
+	if result == nil {
+		result = &webhookPort.SendResult{}
+	}
+
+	if sendErr == nil {
+		delivery.Succeed(result.StatusCode, result.Body)
+		return svc.txManager.Transaction(spanCtx, func(txCtx context.Context) error {
+			if err := svc.deliveryRepository.Save(txCtx, delivery); err != nil {
+				return err
+			}
+			return svc.subscriptionRepository.RecordDeliverySuccess(txCtx, subscription.ID)
+		})
+	}
+
+	logger.Warn(spanCtx, "Webhook投递失败",
+		logger.AddField("delivery_id", delivery.ID.String()),
+		logger.AddField("subscription_id", subscription.ID.String()),
+		logger.AddField("attempt", attempt),
+		logger.ErrorField(sendErr),
+	)
+
+	return svc.txManager.Transaction(spanCtx, func(txCtx context.Context) error {
+		disabled, err := svc.subscriptionRepository.RecordDeliveryFailure(txCtx, subscription.ID, svc.policy.DisableThreshold())
+		if err != nil {
+			return err
+		}
+		if disabled {
+			logger.Warn(txCtx, "Webhook订阅连续投递失败，已自动停用",
+				logger.AddField("subscription_id", subscription.ID.String()),
+			)
+		}
+
+		var nextRetryAt *time.Time
+		var delay time.Duration
+		if !disabled && attempt < svc.policy.MaxAttempts() {
+			delay = svc.policy.RetryDelay(attempt)
+			retryAt := time.Now().Add(delay)
+			nextRetryAt = &retryAt
+		}
+		delivery.Fail(result.StatusCode, result.Body, sendErr, nextRetryAt)
+		if err = svc.deliveryRepository.Save(txCtx, delivery); err != nil {
+			return err
+		}
+		if nextRetryAt == nil {
+			return nil
+		}
+		return svc.publishAttempt(txCtx, delivery.ID, attempt+1, delay)
+	})
+}
+
+// publishAttempt 发布投递尝试事件，delay 大于0时延时发布
+func (svc *deliveryApplicationService) publishAttempt(ctx context.Context, deliveryID uuid.UUID, attempt int, delay time.Duration) error {
+	evt := webhookEvent.NewDeliveryAttemptEvent(deliveryID, attempt)
+	e := domainEvent.DomainEvent[any]{
+		ID:            evt.ID,
+		Type:          evt.Type,
+		Version:       evt.Version,
+		OccurredAt:    evt.OccurredAt,
+		AggregateID:   evt.AggregateID,
+		AggregateName: evt.AggregateName,
+		Payload:       evt.Payload,
+	}
+
+	var err error
+	if delay > 0 {
+		err = svc.eventPublisher.PublishEventAfter(ctx, e, delay)
+	} else {
+		err = svc.eventPublisher.Publish(ctx, e)
+	}
+	if err != nil {
+		logger.Error(ctx, "发布Webhook投递尝试事件失败", logger.ErrorField(err))
+	}
+	return err
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/application/webhook/dto/command"
+	"github.com/dysodeng/app/internal/application/webhook/dto/query"
+	"github.com/dysodeng/app/internal/application/webhook/dto/response"
+	auditModel "github.com/dysodeng/app/internal/domain/audit/model"
+	fileEvent "github.com/dysodeng/app/internal/domain/file/event"
+	"github.com/dysodeng/app/internal/domain/shared/port"
+	userEvent "github.com/dysodeng/app/internal/domain/user/event"
+	webhookErrors "github.com/dysodeng/app/internal/domain/webhook/errors"
+	"github.com/dysodeng/app/internal/domain/webhook/model"
+	"github.com/dysodeng/app/internal/domain/webhook/repository"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+// SubscribableEventTypes 允许外部系统通过Webhook订阅的事件类型
+var SubscribableEventTypes = []string{
+	fileEvent.FileUploadedEventType,
+	userEvent.UserRegisteredEventType,
+}
+
+// SubscriptionApplicationService Webhook订阅应用服务
+type SubscriptionApplicationService interface {
+	// EventTypes 可订阅的事件类型
+	EventTypes(ctx context.Context) []string
+	// List 订阅列表
+	List(ctx context.Context, qry *query.SubscriptionListQuery) ([]response.SubscriptionResponse, int64, error)
+	// Info 订阅详情
+	Info(ctx context.Context, id uuid.UUID) (*response.SubscriptionResponse, error)
+	// Create 创建订阅
+	Create(ctx context.Context, cmd *command.CreateSubscriptionCommand) (*response.SubscriptionResponse, error)
+	// Update 修改订阅
+	Update(ctx context.Context, cmd *command.UpdateSubscriptionCommand) (*response.SubscriptionResponse, error)
+	// RotateSecret 重新生成签名密钥
+	RotateSecret(ctx context.Context, id uuid.UUID) (*response.SubscriptionResponse, error)
+	// Delete 删除订阅及其投递记录
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Enable 启用订阅
+	Enable(ctx context.Context, id uuid.UUID) error
+	// Disable 停用订阅
+	Disable(ctx context.Context, id uuid.UUID) error
+	// Deliveries 投递记录列表
+	Deliveries(ctx context.Context, qry *query.DeliveryListQuery) ([]response.DeliveryResponse, int64, error)
+}
+
+type subscriptionApplicationService struct {
+	baseTraceSpanName      string
+	subscriptionRepository repository.SubscriptionRepository
+	deliveryRepository     repository.DeliveryRepository
+	auditRecorder          port.AuditRecorder
+}
+
+func NewSubscriptionApplicationService(
+	subscriptionRepository repository.SubscriptionRepository,
+	deliveryRepository repository.DeliveryRepository,
+	auditRecorder port.AuditRecorder,
+) SubscriptionApplicationService {
+	return &subscriptionApplicationService{
+		baseTraceSpanName:      "application.webhook.service.SubscriptionApplicationService",
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		auditRecorder:          auditRecorder,
+	}
+}
+
+func (svc *subscriptionApplicationService) EventTypes(_ context.Context) []string {
+	return SubscribableEventTypes
+}
+
+func (svc *subscriptionApplicationService) List(ctx context.Context, qry *query.SubscriptionListQuery) ([]response.SubscriptionResponse, int64, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".List")
+	defer span.End()
+
+	list, total, err := svc.subscriptionRepository.FindList(spanCtx, repository.SubscriptionQuery{
+		Keyword:   qry.Keyword,
+		EventType: qry.EventType,
+		Status:    qry.Status,
+		Page:      qry.Page,
+		PageSize:  qry.PageSize,
+	})
+	if err != nil {
+		logger.Error(spanCtx, "Webhook订阅列表查询失败", logger.ErrorField(err))
+		return nil, 0, webhookErrors.ErrWebhookQueryFailed.Wrap(err)
+	}
+
+	result := make([]response.SubscriptionResponse, len(list))
+	for i := range list {
+		result[i].FromDomainModel(&list[i])
+	}
+	return result, total, nil
+}
+
+func (svc *subscriptionApplicationService) Info(ctx context.Context, id uuid.UUID) (*response.SubscriptionResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Info")
+	defer span.End()
+
+	subscription, err := svc.find(spanCtx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var res response.SubscriptionResponse
+	res.FromDomainModel(subscription)
+	return &res, nil
+}
+
+func (svc *subscriptionApplicationService) Create(ctx context.Context, cmd *command.CreateSubscriptionCommand) (*response.SubscriptionResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Create")
+	defer span.End()
+
+	if err := svc.validateEventTypes(cmd.EventTypes); err != nil {
+		return nil, err
+	}
+	subscription, err := model.NewSubscription(cmd.Name, cmd.URL, cmd.EventTypes, cmd.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.subscriptionRepository.Save(spanCtx, subscription); err != nil {
+		logger.Error(spanCtx, "Webhook订阅保存失败", logger.ErrorField(err))
+		return nil, webhookErrors.ErrWebhookSubscriptionSaveFailed.Wrap(err)
+	}
+
+	var res response.SubscriptionResponse
+	res.FromDomainModel(subscription)
+	svc.audit(spanCtx, auditModel.ActionCreate, subscription.ID, nil, &res)
+	res.RevealSecret(subscription)
+	return &res, nil
+}
+
+func (svc *subscriptionApplicationService) Update(ctx context.Context, cmd *command.UpdateSubscriptionCommand) (*response.SubscriptionResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Update")
+	defer span.End()
+
+	if err := svc.validateEventTypes(cmd.EventTypes); err != nil {
+		return nil, err
+	}
+	subscription, err := svc.find(spanCtx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	var before response.SubscriptionResponse
+	before.FromDomainModel(subscription)
+
+	subscription.Name = cmd.Name
+	subscription.URL = cmd.URL
+	subscription.EventTypes = cmd.EventTypes
+	if cmd.Secret != "" {
+		subscription.Secret = cmd.Secret
+	}
+	if err = subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = svc.subscriptionRepository.Save(spanCtx, subscription); err != nil {
+		logger.Error(spanCtx, "Webhook订阅保存失败", logger.ErrorField(err))
+		return nil, webhookErrors.ErrWebhookSubscriptionSaveFailed.Wrap(err)
+	}
+
+	var res response.SubscriptionResponse
+	res.FromDomainModel(subscription)
+	svc.audit(spanCtx, auditModel.ActionUpdate, subscription.ID, &before, &res)
+	return &res, nil
+}
+
+func (svc *subscriptionApplicationService) RotateSecret(ctx context.Context, id uuid.UUID) (*response.SubscriptionResponse, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".RotateSecret")
+	defer span.End()
+
+	subscription, err := svc.find(spanCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	var before response.SubscriptionResponse
+	before.FromDomainModel(subscription)
+
+	subscription.RotateSecret()
+	if err = svc.subscriptionRepository.Save(spanCtx, subscription); err != nil {
+		logger.Error(spanCtx, "Webhook订阅保存失败", logger.ErrorField(err))
+		return nil, webhookErrors.ErrWebhookSubscriptionSaveFailed.Wrap(err)
+	}
+
+	var res response.SubscriptionResponse
+	res.FromDomainModel(subscription)
+	svc.audit(spanCtx, auditModel.ActionUpdate, subscription.ID, &before, &res)
+	res.RevealSecret(subscription)
+	return &res, nil
+}
+
+func (svc *subscriptionApplicationService) Delete(ctx context.Context, id uuid.UUID) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Delete")
+	defer span.End()
+
+	subscription, err := svc.find(spanCtx, id)
+	if err != nil {
+		return err
+	}
+	if err = svc.subscriptionRepository.Delete(spanCtx, id); err != nil {
+		logger.Error(spanCtx, "Webhook订阅删除失败", logger.ErrorField(err))
+		return webhookErrors.ErrWebhookDeleteFailed.Wrap(err)
+	}
+	var before response.SubscriptionResponse
+	before.FromDomainModel(subscription)
+	svc.audit(spanCtx, auditModel.ActionDelete, id, &before, nil)
+	return nil
+}
+
+func (svc *subscriptionApplicationService) Enable(ctx context.Context, id uuid.UUID) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Enable")
+	defer span.End()
+
+	subscription, err := svc.find(spanCtx, id)
+	if err != nil {
+		return err
+	}
+	before := map[string]any{"status": subscription.Status.Uint()}
+	subscription.Enable()
+	if err = svc.subscriptionRepository.Save(spanCtx, subscription); err != nil {
+		logger.Error(spanCtx, "Webhook订阅启用失败", logger.ErrorField(err))
+		return webhookErrors.ErrWebhookSubscriptionSaveFailed.Wrap(err)
+	}
+	svc.audit(spanCtx, auditModel.ActionEnable, id, before, map[string]any{"status": subscription.Status.Uint()})
+	return nil
+}
+
+func (svc *subscriptionApplicationService) Disable(ctx context.Context, id uuid.UUID) error {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Disable")
+	defer span.End()
+
+	subscription, err := svc.find(spanCtx, id)
+	if err != nil {
+		return err
+	}
+	before := map[string]any{"status": subscription.Status.Uint()}
+	subscription.Disable()
+	if err = svc.subscriptionRepository.Save(spanCtx, subscription); err != nil {
+		logger.Error(spanCtx, "Webhook订阅停用失败", logger.ErrorField(err))
+		return webhookErrors.ErrWebhookSubscriptionSaveFailed.Wrap(err)
+	}
+	svc.audit(spanCtx, auditModel.ActionDisable, id, before, map[string]any{"status": subscription.Status.Uint()})
+	return nil
+}
+
+func (svc *subscriptionApplicationService) Deliveries(ctx context.Context, qry *query.DeliveryListQuery) ([]response.DeliveryResponse, int64, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, svc.baseTraceSpanName+".Deliveries")
+	defer span.End()
+
+	list, total, err := svc.deliveryRepository.FindList(spanCtx, repository.DeliveryQuery{
+		SubscriptionID: qry.SubscriptionID,
+		EventType:      qry.EventType,
+		Status:         qry.Status,
+		Page:           qry.Page,
+		PageSize:       qry.PageSize,
+	})
+	if err != nil {
+		logger.Error(spanCtx, "Webhook投递记录查询失败", logger.ErrorField(err))
+		return nil, 0, webhookErrors.ErrWebhookQueryFailed.Wrap(err)
+	}
+
+	result := make([]response.DeliveryResponse, len(list))
+	for i := range list {
+		result[i].FromDomainModel(&list[i])
+	}
+	return result, total, nil
+}
+
+func (svc *subscriptionApplicationService) find(ctx context.Context, id uuid.UUID) (*model.Subscription, error) {
+	subscription, err := svc.subscriptionRepository.FindByID(ctx, id)
+	if err != nil {
+		logger.Error(ctx, "Webhook订阅查询失败", logger.ErrorField(err))
+		return nil, webhookErrors.ErrWebhookQueryFailed.Wrap(err)
+	}
+	if subscription == nil {
+		return nil, webhookErrors.ErrWebhookSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// audit 记录订阅变更审计，失败仅记录日志不影响业务
+func (svc *subscriptionApplicationService) audit(ctx context.Context, action string, id uuid.UUID, before, after any) {
+	if err := svc.auditRecorder.Record(ctx, action, "webhook_subscription", id.String(), before, after); err != nil {
+		logger.Error(ctx, "Webhook订阅审计记录失败", logger.ErrorField(err))
+	}
+}
+
+func (svc *subscriptionApplicationService) validateEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return webhookErrors.ErrWebhookEventTypesEmpty
+	}
+	for _, eventType := range eventTypes {
+		if !slices.Contains(SubscribableEventTypes, eventType) {
+			return webhookErrors.ErrWebhookEventTypeUnsupported
+		}
+	}
+	return nil
+}
@@ -9,11 +9,13 @@ import (
 	diEvent "github.com/dysodeng/app/internal/di/event"
 	"github.com/dysodeng/app/internal/infrastructure/config"
 	"github.com/dysodeng/app/internal/infrastructure/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/cache"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
 	eventServer "github.com/dysodeng/app/internal/infrastructure/server/event"
 	"github.com/dysodeng/app/internal/infrastructure/server/grpc"
 	"github.com/dysodeng/app/internal/infrastructure/server/health"
 	"github.com/dysodeng/app/internal/infrastructure/server/http"
+	"github.com/dysodeng/app/internal/infrastructure/server/outbox"
 	"github.com/dysodeng/app/internal/infrastructure/server/websocket"
 	"github.com/dysodeng/app/internal/infrastructure/shared/db"
 	"github.com/dysodeng/app/internal/infrastructure/shared/errors"
@@ -44,7 +46,9 @@ type App struct {
 	HealthServer         *health.Server
 	EventBus             event.Bus
 	EventConsumer        *event.ConsumerService
+	EventReplayer        *event.EventReplayer
 	EventServer          *eventServer.Server
+	OutboxServer         *outbox.Server
 }
 
 // NewApp 创建应用程序
@@ -66,7 +70,9 @@ func NewApp(
 	healthServer *health.Server,
 	eventBus event.Bus,
 	eventConsumer *event.ConsumerService,
+	eventReplayer *event.EventReplayer,
 	eventServer *eventServer.Server,
+	outboxServer *outbox.Server,
 ) *App {
 	return &App{
 		Config:               config,
@@ -86,13 +92,15 @@ func NewApp(
 		HealthServer:         healthServer,
 		EventBus:             eventBus,
 		EventConsumer:        eventConsumer,
+		EventReplayer:        eventReplayer,
 		EventServer:          eventServer,
+		OutboxServer:         outboxServer,
 	}
 }
 
 // Stop 停止应用相关服务
 func (app *App) Stop(ctx context.Context) error {
-	return errors.NewPipelineWithContext(ctx).Then(db.Close).Then(func() error {
+	return errors.NewPipelineWithContext(ctx).Then(db.Close).Then(cache.Close).Then(func() error {
 		return app.RedisClient.Close()
 	}).Then(func() error {
 		return app.MessageQueue.Close()
@@ -1,7 +1,12 @@
 package event
 
 import (
+	"fmt"
+	"strings"
+
+	auditHandler "github.com/dysodeng/app/internal/application/audit/event/handler"
 	"github.com/dysodeng/app/internal/application/file/event/handler"
+	webhookHandler "github.com/dysodeng/app/internal/application/webhook/event/handler"
 )
 
 // HandlerRegistry 事件处理器注册表
@@ -11,9 +16,15 @@ type HandlerRegistry struct {
 
 func NewHandlerRegistry(
 	fileUploadedHandler *handler.FileUploadedHandler,
+	multipartUploadExpiredHandler *handler.MultipartUploadExpiredHandler,
+	webhookDispatchHandler *webhookHandler.WebhookDispatchHandler,
+	webhookDeliveryAttemptHandler *webhookHandler.DeliveryAttemptHandler,
+	auditRecordedHandler *auditHandler.AuditRecordedHandler,
 ) *HandlerRegistry {
 	handlers := make([]any, 0)
-	handlers = append(handlers, fileUploadedHandler)
+	handlers = append(handlers, fileUploadedHandler, multipartUploadExpiredHandler)
+	handlers = append(handlers, webhookDispatchHandler, webhookDeliveryAttemptHandler)
+	handlers = append(handlers, auditRecordedHandler)
 	return &HandlerRegistry{
 		handlers: handlers,
 	}
@@ -22,3 +33,14 @@ func NewHandlerRegistry(
 func (h *HandlerRegistry) Handlers() []any {
 	return h.handlers
 }
+
+// Find 按名称查找事件处理器，名称为处理器类型全名(如 *handler.FileUploadedHandler)或类型名(如 FileUploadedHandler)
+func (h *HandlerRegistry) Find(name string) (any, bool) {
+	for _, handler := range h.handlers {
+		typeName := fmt.Sprintf("%T", handler)
+		if typeName == name || typeName[strings.LastIndex(typeName, ".")+1:] == name {
+			return handler, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,20 @@
+package event
+
+import (
+	auditEvent "github.com/dysodeng/app/internal/domain/audit/event"
+	fileEvent "github.com/dysodeng/app/internal/domain/file/event"
+	userEvent "github.com/dysodeng/app/internal/domain/user/event"
+	webhookEvent "github.com/dysodeng/app/internal/domain/webhook/event"
+	"github.com/dysodeng/app/internal/infrastructure/event"
+)
+
+// NewSchemaRegistry 事件结构版本注册表
+// 新增事件类型时在此注册当前版本，事件结构变更时递增版本并注册上一版本的升级函数
+func NewSchemaRegistry() *event.SchemaRegistry {
+	return event.NewSchemaRegistry().
+		Register(fileEvent.FileUploadedEventType, fileEvent.FileUploadedEventVersion).
+		Register(fileEvent.MultipartUploadExpiredEventType, fileEvent.MultipartUploadExpiredEventVersion).
+		Register(userEvent.UserRegisteredEventType, userEvent.UserRegisteredEventVersion).
+		Register(webhookEvent.DeliveryAttemptEventType, webhookEvent.DeliveryAttemptEventVersion).
+		Register(auditEvent.AuditRecordedEventType, auditEvent.AuditRecordedEventVersion)
+}
@@ -22,12 +22,22 @@ var InfrastructureSet = wire.NewSet(
 	provider.ProvideStorage,
 	provider.ProvideEventBus,
 	provider.ProvideEventConsumerService,
+	provider.ProvideOutboxRelay,
+	provider.ProvideEventInbox,
+	provider.ProvideEventDeadLetterQueue,
+	provider.ProvideEventStore,
+	provider.ProvideEventReplayer,
 
 	// 端口适配器
 	provider.ProvideFileStoragePort,
 	provider.ProvideFilePolicyPort,
 	provider.ProvideEventPublisherPort,
+	provider.ProvideAuditRecorderPort,
+	provider.ProvideDeadLetterQueuePort,
+	provider.ProvideCacheInspectorPort,
 	provider.ProvideTransactionManagerPort,
+	provider.ProvideWebhookSenderPort,
+	provider.ProvideWebhookDeliveryPolicyPort,
 )
 
 // WebSocketSet WebSocket聚合依赖
@@ -43,9 +53,11 @@ var ServerSet = wire.NewSet(
 	WebSocketSet,
 	http.NewHandlerRegistry,
 	event.NewHandlerRegistry,
+	event.NewSchemaRegistry,
 	grpc.NewServiceRegistry,
 	provider.ProvideHTTPServer,
 	provider.ProvideGRPCServer,
 	provider.ProvideHealthServer,
 	provider.ProvideEventServer,
+	provider.ProvideOutboxServer,
 )
@@ -13,4 +13,8 @@ var ModulesSet = wire.NewSet(
 	modules.SharedModuleSet,
 	modules.PassportModuleSet,
 	modules.FileModuleSet,
+	modules.EventModuleSet,
+	modules.WebhookModuleSet,
+	modules.CacheModuleSet,
+	modules.AuditModuleSet,
 )
@@ -0,0 +1,25 @@
+package modules
+
+import (
+	"github.com/google/wire"
+
+	"github.com/dysodeng/app/internal/application/audit/event/handler"
+	auditApplicationService "github.com/dysodeng/app/internal/application/audit/service"
+	auditRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/audit"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/audit"
+)
+
+// AuditModuleSet 审计日志模块依赖注入聚合
+var AuditModuleSet = wire.NewSet(
+	// 仓储层
+	auditRepository.NewAuditLogRepository,
+
+	// 应用层
+	auditApplicationService.NewAuditLogApplicationService,
+
+	// 事件处理层
+	handler.NewAuditRecordedHandler,
+
+	// http接口层
+	audit.NewAuditLogHandler,
+)
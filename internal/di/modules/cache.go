@@ -0,0 +1,17 @@
+package modules
+
+import (
+	"github.com/google/wire"
+
+	cacheApplicationService "github.com/dysodeng/app/internal/application/cache/service"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/cache"
+)
+
+// CacheModuleSet 缓存诊断模块依赖注入聚合
+var CacheModuleSet = wire.NewSet(
+	// 应用层
+	cacheApplicationService.NewCacheApplicationService,
+
+	// http接口层
+	cache.NewCacheHandler,
+)
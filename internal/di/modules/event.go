@@ -0,0 +1,17 @@
+package modules
+
+import (
+	"github.com/google/wire"
+
+	eventApplicationService "github.com/dysodeng/app/internal/application/event/service"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/event"
+)
+
+// EventModuleSet 事件管理模块依赖注入聚合
+var EventModuleSet = wire.NewSet(
+	// 应用层
+	eventApplicationService.NewDeadLetterApplicationService,
+
+	// http接口层
+	event.NewDeadLetterHandler,
+)
@@ -6,6 +6,7 @@ import (
 	fileDecorator "github.com/dysodeng/app/internal/application/file/decorator"
 	"github.com/dysodeng/app/internal/application/file/event/handler"
 	fileApplicationService "github.com/dysodeng/app/internal/application/file/service"
+	cacheRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/cache"
 	fileRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/file"
 	fileGRPCService "github.com/dysodeng/app/internal/interfaces/grpc/service"
 	"github.com/dysodeng/app/internal/interfaces/http/handler/file"
@@ -14,7 +15,7 @@ import (
 // FileModuleSet 文件模块依赖注入聚合
 var FileModuleSet = wire.NewSet(
 	// 仓储层
-	fileRepository.NewFileRepository,
+	cacheRepository.NewCachedFileRepository,
 	fileRepository.NewUploaderRepository,
 
 	// 领域层
@@ -27,10 +28,12 @@ var FileModuleSet = wire.NewSet(
 
 	// 事件处理层
 	handler.NewFileUploadedHandler,
+	handler.NewMultipartUploadExpiredHandler,
 
 	// grpc接口层
 	fileGRPCService.NewFileService,
 
 	// http接口层
 	file.NewUploaderHandler,
+	file.NewFileHandler,
 )
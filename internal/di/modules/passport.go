@@ -6,7 +6,6 @@ import (
 	"github.com/dysodeng/app/internal/application/passport/service"
 	userDomainService "github.com/dysodeng/app/internal/domain/user/service"
 	cacheRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/cache"
-	permissionRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/permission"
 	"github.com/dysodeng/app/internal/interfaces/http/handler/passport"
 )
 
@@ -14,7 +13,7 @@ import (
 var PassportModuleSet = wire.NewSet(
 	// 仓储层
 	cacheRepository.NewCachedUserRepository,
-	permissionRepository.NewAdminRepository,
+	cacheRepository.NewCachedAdminRepository,
 
 	// 领域层
 	userDomainService.NewUserDomainService,
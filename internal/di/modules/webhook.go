@@ -0,0 +1,28 @@
+package modules
+
+import (
+	"github.com/google/wire"
+
+	"github.com/dysodeng/app/internal/application/webhook/event/handler"
+	webhookApplicationService "github.com/dysodeng/app/internal/application/webhook/service"
+	webhookRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/webhook"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/webhook"
+)
+
+// WebhookModuleSet Webhook模块依赖注入聚合
+var WebhookModuleSet = wire.NewSet(
+	// 仓储层
+	webhookRepository.NewSubscriptionRepository,
+	webhookRepository.NewDeliveryRepository,
+
+	// 应用层
+	webhookApplicationService.NewSubscriptionApplicationService,
+	webhookApplicationService.NewDeliveryApplicationService,
+
+	// 事件处理层
+	handler.NewWebhookDispatchHandler,
+	handler.NewDeliveryAttemptHandler,
+
+	// http接口层
+	webhook.NewSubscriptionHandler,
+)
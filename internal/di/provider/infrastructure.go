@@ -78,6 +78,65 @@ func ProvideStorage(cfg *config.Config) (*storage.Storage, error) {
 }
 
 // ProvideEventBus 提供事件总线
-func ProvideEventBus(mq contract.MQ) event.Bus {
-	return event.NewMQEventBus(mq.Producer())
+func ProvideEventBus(cfg *config.Config, mq contract.MQ, tx transactions.TransactionManager, store *event.EventStore, logger *zap.Logger) event.Bus {
+	if cfg.Server.Event.Driver == "sync" {
+		return event.NewSyncEventBus(logger, event.WithSyncDispatchMode(event.SyncDispatchMode(cfg.Server.Event.SyncMode)))
+	}
+	opts := append(mqEventBusOptions(cfg, store), event.WithBusLogger(logger))
+	if cfg.Server.Event.Delay.Driver == "db" {
+		opts = append(opts, event.WithBusScheduler(event.NewDBEventScheduler(tx)))
+	}
+	return event.NewMQEventBus(mq.Producer(), opts...)
+}
+
+// ProvideEventStore 提供事件存储
+func ProvideEventStore(tx transactions.TransactionManager) *event.EventStore {
+	return event.NewEventStore(tx)
+}
+
+// ProvideEventReplayer 提供事件回放器
+func ProvideEventReplayer(store *event.EventStore, schemas *event.SchemaRegistry, logger *zap.Logger) *event.EventReplayer {
+	return event.NewEventReplayer(store, schemas, logger)
+}
+
+// mqEventBusOptions MQ事件总线公共选项
+func mqEventBusOptions(cfg *config.Config, store *event.EventStore) []event.MQEventBusOption {
+	opts := []event.MQEventBusOption{event.WithBusMessagingSystem(cfg.MessageQueue.Driver)}
+	if cfg.Server.Event.Store.Enabled {
+		opts = append(opts, event.WithBusEventStore(store))
+	}
+	return opts
+}
+
+// ProvideOutboxRelay 提供事务发件箱投递器
+func ProvideOutboxRelay(cfg *config.Config, tx transactions.TransactionManager, mq contract.MQ, store *event.EventStore, logger *zap.Logger) *event.OutboxRelay {
+	outboxCfg := cfg.Server.Event.Outbox
+	return event.NewOutboxRelay(
+		tx,
+		event.NewMQEventBus(mq.Producer(), append(mqEventBusOptions(cfg, store), event.WithBusOutboxRetry())...),
+		logger,
+		event.WithOutboxPollInterval(outboxCfg.PollInterval),
+		event.WithOutboxBatchSize(outboxCfg.BatchSize),
+		event.WithOutboxMaxAttempts(outboxCfg.MaxAttempts),
+		event.WithOutboxRetryInterval(outboxCfg.RetryInterval, outboxCfg.MaxRetryInterval),
+		event.WithOutboxRetention(outboxCfg.Retention),
+	)
+}
+
+// ProvideEventInbox 提供事件幂等消费收件箱
+func ProvideEventInbox(cfg *config.Config, tx transactions.TransactionManager, redisClient redis.Client) event.Inbox {
+	inboxCfg := cfg.Server.Event.Inbox
+	switch inboxCfg.Driver {
+	case "redis":
+		return event.NewRedisInbox(redisClient, inboxCfg.Retention)
+	case "db":
+		return event.NewDBInbox(tx)
+	default:
+		return nil
+	}
+}
+
+// ProvideEventDeadLetterQueue 提供事件死信队列
+func ProvideEventDeadLetterQueue(cfg *config.Config, tx transactions.TransactionManager, mq contract.MQ) *event.DeadLetterQueue {
+	return event.NewDeadLetterQueue(tx, event.NewMQEventBus(mq.Producer(), event.WithBusMessagingSystem(cfg.MessageQueue.Driver)))
 }
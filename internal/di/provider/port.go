@@ -3,8 +3,10 @@ package provider
 import (
 	domainFilePort "github.com/dysodeng/app/internal/domain/file/port"
 	domainSharedPort "github.com/dysodeng/app/internal/domain/shared/port"
+	domainWebhookPort "github.com/dysodeng/app/internal/domain/webhook/port"
 	"github.com/dysodeng/app/internal/infrastructure/adapter/file"
 	sharedAdapter "github.com/dysodeng/app/internal/infrastructure/adapter/shared"
+	webhookAdapter "github.com/dysodeng/app/internal/infrastructure/adapter/webhook"
 	"github.com/dysodeng/app/internal/infrastructure/config"
 	"github.com/dysodeng/app/internal/infrastructure/event"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
@@ -23,11 +25,44 @@ func ProvideFilePolicyPort(cfg *config.Config) domainFilePort.FilePolicy {
 }
 
 // ProvideEventPublisherPort 提供端口适配器：事件发布
-func ProvideEventPublisherPort(bus event.Bus) domainSharedPort.EventPublisher {
+func ProvideEventPublisherPort(cfg *config.Config, bus event.Bus, tx transactions.TransactionManager) domainSharedPort.EventPublisher {
+	if cfg.Server.Event.OutboxEnabled() {
+		// 启用事务发件箱时，事件随业务事务写入发件箱，由投递服务异步发送
+		return sharedAdapter.NewEventPublisherAdapter(event.NewOutboxEventBus(tx))
+	}
+	if cfg.Server.Event.Driver != "sync" {
+		// 直接投递MQ时，事务中发布的事件在提交后投递，投递失败不影响业务事务
+		return sharedAdapter.NewEventPublisherAdapter(bus, sharedAdapter.WithPublishAfterCommit())
+	}
 	return sharedAdapter.NewEventPublisherAdapter(bus)
 }
 
+// ProvideAuditRecorderPort 提供端口适配器：审计记录
+func ProvideAuditRecorderPort(publisher domainSharedPort.EventPublisher) domainSharedPort.AuditRecorder {
+	return sharedAdapter.NewAuditRecorderAdapter(publisher)
+}
+
+// ProvideDeadLetterQueuePort 提供端口适配器：事件死信队列
+func ProvideDeadLetterQueuePort(queue *event.DeadLetterQueue) domainSharedPort.DeadLetterQueue {
+	return sharedAdapter.NewDeadLetterQueueAdapter(queue)
+}
+
+// ProvideCacheInspectorPort 提供端口适配器：缓存诊断
+func ProvideCacheInspectorPort() domainSharedPort.CacheInspector {
+	return sharedAdapter.NewCacheInspectorAdapter()
+}
+
 // ProvideTransactionManagerPort 提供端口适配器：事务管理
 func ProvideTransactionManagerPort(tx transactions.TransactionManager) domainSharedPort.TransactionManager {
 	return sharedAdapter.NewTransactionManagerAdapter(tx)
 }
+
+// ProvideWebhookSenderPort 提供端口适配器：Webhook请求发送
+func ProvideWebhookSenderPort(cfg *config.Config) domainWebhookPort.WebhookSender {
+	return webhookAdapter.NewWebhookSenderAdapter(cfg.Server.Event.Webhook.Timeout)
+}
+
+// ProvideWebhookDeliveryPolicyPort 提供端口适配器：Webhook投递策略
+func ProvideWebhookDeliveryPolicyPort(cfg *config.Config) domainWebhookPort.DeliveryPolicy {
+	return webhookAdapter.NewDeliveryPolicyAdapter(cfg.Server.Event.Webhook)
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/dysodeng/app/internal/infrastructure/server/grpc"
 	"github.com/dysodeng/app/internal/infrastructure/server/health"
 	"github.com/dysodeng/app/internal/infrastructure/server/http"
+	"github.com/dysodeng/app/internal/infrastructure/server/outbox"
 	"github.com/dysodeng/app/internal/infrastructure/server/websocket"
+	sharedMQ "github.com/dysodeng/app/internal/infrastructure/shared/mq"
 	GRPC "github.com/dysodeng/app/internal/interfaces/grpc"
 	HTTP "github.com/dysodeng/app/internal/interfaces/http"
 	webSocket "github.com/dysodeng/app/internal/interfaces/websocket"
@@ -40,15 +42,53 @@ func ProvideHealthServer(cfg *config.Config) *health.Server {
 }
 
 // ProvideEventConsumerService 提供事件消费者服务
-func ProvideEventConsumerService(mq contract.MQ, logger *zap.Logger) *event.ConsumerService {
-	return event.NewEventConsumerService(mq.Consumer(), logger)
+func ProvideEventConsumerService(
+	cfg *config.Config,
+	mq contract.MQ,
+	inbox event.Inbox,
+	deadLetter *event.DeadLetterQueue,
+	schemas *event.SchemaRegistry,
+	logger *zap.Logger,
+) (*event.ConsumerService, error) {
+	eventCfg := cfg.Server.Event
+	opts := []event.ConsumerOption{
+		event.WithHandlerRetry(eventCfg.Retry.MaxAttempts, eventCfg.Retry.Interval, eventCfg.Retry.MaxInterval),
+		event.WithSchemaRegistry(schemas),
+		event.WithConsumerMessagingSystem(cfg.MessageQueue.Driver),
+		event.WithDefaultConcurrency(eventCfg.Concurrency.Default),
+	}
+	ordered := false
+	for _, typeCfg := range eventCfg.Concurrency.Types {
+		opts = append(opts, event.WithEventConcurrency(typeCfg.EventType, typeCfg.Concurrency, typeCfg.Ordered))
+		ordered = ordered || typeCfg.Ordered
+	}
+	if ordered {
+		orderedConsumer, err := sharedMQ.OrderedConsumer(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, event.WithOrderedConsumer(orderedConsumer))
+	}
+	if inbox != nil {
+		opts = append(opts, event.WithInbox(inbox, eventCfg.Inbox.Retention))
+	}
+	if eventCfg.DeadLetter.Enabled {
+		opts = append(opts, event.WithDeadLetter(deadLetter))
+	}
+	return event.NewEventConsumerService(mq.Consumer(), logger, opts...), nil
 }
 
 // ProvideEventServer 提供Event服务器
 func ProvideEventServer(
 	cfg *config.Config,
 	eventConsumer *event.ConsumerService,
+	bus event.Bus,
 	registry *diEvent.HandlerRegistry,
 ) *eventServer.Server {
-	return eventServer.NewEventServer(cfg, eventConsumer, registry)
+	return eventServer.NewEventServer(cfg, eventConsumer, bus, registry)
+}
+
+// ProvideOutboxServer 提供事务发件箱投递服务
+func ProvideOutboxServer(cfg *config.Config, relay *event.OutboxRelay) *outbox.Server {
+	return outbox.NewOutboxServer(cfg, relay)
 }
@@ -8,21 +8,32 @@ package di
 
 import (
 	"context"
+	handler3 "github.com/dysodeng/app/internal/application/audit/event/handler"
+	service7 "github.com/dysodeng/app/internal/application/audit/service"
+	service6 "github.com/dysodeng/app/internal/application/cache/service"
+	service4 "github.com/dysodeng/app/internal/application/event/service"
 	"github.com/dysodeng/app/internal/application/file/decorator"
 	"github.com/dysodeng/app/internal/application/file/event/handler"
 	service3 "github.com/dysodeng/app/internal/application/file/service"
 	service2 "github.com/dysodeng/app/internal/application/passport/service"
-	"github.com/dysodeng/app/internal/di/event"
+	handler2 "github.com/dysodeng/app/internal/application/webhook/event/handler"
+	service5 "github.com/dysodeng/app/internal/application/webhook/service"
+	event2 "github.com/dysodeng/app/internal/di/event"
 	"github.com/dysodeng/app/internal/di/provider"
 	"github.com/dysodeng/app/internal/domain/user/service"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/repository/audit"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/repository/cache"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/repository/file"
-	"github.com/dysodeng/app/internal/infrastructure/persistence/repository/permission"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/repository/webhook"
 	"github.com/dysodeng/app/internal/interfaces/grpc"
-	service4 "github.com/dysodeng/app/internal/interfaces/grpc/service"
+	service8 "github.com/dysodeng/app/internal/interfaces/grpc/service"
 	"github.com/dysodeng/app/internal/interfaces/http"
+	audit2 "github.com/dysodeng/app/internal/interfaces/http/handler/audit"
+	cache2 "github.com/dysodeng/app/internal/interfaces/http/handler/cache"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/event"
 	file2 "github.com/dysodeng/app/internal/interfaces/http/handler/file"
 	"github.com/dysodeng/app/internal/interfaces/http/handler/passport"
+	webhook2 "github.com/dysodeng/app/internal/interfaces/http/handler/webhook"
 	"github.com/dysodeng/app/internal/interfaces/websocket"
 )
 
@@ -58,37 +69,78 @@ func InitApp(ctx context.Context) (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	userRepository := cache.NewCachedUserRepository(transactionManager)
+	userRepository, err := cache.NewCachedUserRepository(transactionManager)
+	if err != nil {
+		return nil, err
+	}
 	userDomainService := service.NewUserDomainService(userRepository)
-	adminRepository := permission.NewAdminRepository(transactionManager)
-	passportApplicationService := service2.NewPassportApplicationService(userRepository, userDomainService, adminRepository)
+	adminRepository, err := cache.NewCachedAdminRepository(transactionManager)
+	if err != nil {
+		return nil, err
+	}
+	eventStore := provider.ProvideEventStore(transactionManager)
+	bus := provider.ProvideEventBus(config, mq, transactionManager, eventStore, logger)
+	eventPublisher := provider.ProvideEventPublisherPort(config, bus, transactionManager)
+	portTransactionManager := provider.ProvideTransactionManagerPort(transactionManager)
+	passportApplicationService := service2.NewPassportApplicationService(userRepository, userDomainService, adminRepository, eventPublisher, portTransactionManager)
 	passportHandler := passport.NewPassportHandler(passportApplicationService)
-	fileRepository := file.NewFileRepository(transactionManager)
+	fileRepository, err := cache.NewCachedFileRepository(transactionManager)
+	if err != nil {
+		return nil, err
+	}
 	uploaderRepository := file.NewUploaderRepository(transactionManager)
 	fileStorage := provider.ProvideFileStoragePort(storage)
 	filePolicy := provider.ProvideFilePolicyPort(config)
 	uploaderDomainService := decorator.NewUploaderDomainServiceWithTracing(fileRepository, uploaderRepository, fileStorage, filePolicy)
-	bus := provider.ProvideEventBus(mq)
-	eventPublisher := provider.ProvideEventPublisherPort(bus)
-	portTransactionManager := provider.ProvideTransactionManagerPort(transactionManager)
 	uploaderApplicationService := service3.NewUploaderApplicationService(uploaderDomainService, eventPublisher, portTransactionManager, fileRepository, uploaderRepository, fileStorage)
 	uploaderHandler := file2.NewUploaderHandler(uploaderApplicationService)
-	handlerRegistry := http.NewHandlerRegistry(passportHandler, uploaderHandler)
+	fileDomainService := decorator.NewFileDomainServiceWithTracing(fileRepository)
+	auditRecorder := provider.ProvideAuditRecorderPort(eventPublisher)
+	fileApplicationService := service3.NewFileApplicationService(fileDomainService, auditRecorder)
+	fileHandler := file2.NewFileHandler(fileApplicationService)
+	deadLetterQueue := provider.ProvideEventDeadLetterQueue(config, transactionManager, mq)
+	portDeadLetterQueue := provider.ProvideDeadLetterQueuePort(deadLetterQueue)
+	deadLetterApplicationService := service4.NewDeadLetterApplicationService(portDeadLetterQueue, auditRecorder)
+	deadLetterHandler := event.NewDeadLetterHandler(deadLetterApplicationService)
+	subscriptionRepository := webhook.NewSubscriptionRepository(transactionManager)
+	deliveryRepository := webhook.NewDeliveryRepository(transactionManager)
+	subscriptionApplicationService := service5.NewSubscriptionApplicationService(subscriptionRepository, deliveryRepository, auditRecorder)
+	subscriptionHandler := webhook2.NewSubscriptionHandler(subscriptionApplicationService)
+	cacheInspector := provider.ProvideCacheInspectorPort()
+	cacheApplicationService := service6.NewCacheApplicationService(cacheInspector, auditRecorder)
+	cacheHandler := cache2.NewCacheHandler(cacheApplicationService)
+	auditLogRepository := audit.NewAuditLogRepository(transactionManager)
+	auditLogApplicationService := service7.NewAuditLogApplicationService(auditLogRepository, auditRecorder)
+	auditLogHandler := audit2.NewAuditLogHandler(auditLogApplicationService)
+	handlerRegistry := http.NewHandlerRegistry(passportHandler, uploaderHandler, fileHandler, deadLetterHandler, subscriptionHandler, cacheHandler, auditLogHandler)
 	textMessageHandler := websocket.NewTextMessageHandler()
 	binaryMessageHandler := websocket.NewBinaryMessageHandler()
 	webSocket := websocket.NewWebSocket(textMessageHandler, binaryMessageHandler)
 	fileUploadedHandler := handler.NewFileUploadedHandler()
-	eventHandlerRegistry := event.NewHandlerRegistry(fileUploadedHandler)
-	fileDomainService := decorator.NewFileDomainServiceWithTracing(fileRepository)
-	fileApplicationService := service3.NewFileApplicationService(fileDomainService)
-	fileService := service4.NewFileService(fileApplicationService)
+	multipartUploadExpiredHandler := handler.NewMultipartUploadExpiredHandler(uploaderRepository, fileStorage)
+	webhookSender := provider.ProvideWebhookSenderPort(config)
+	deliveryPolicy := provider.ProvideWebhookDeliveryPolicyPort(config)
+	deliveryApplicationService := service5.NewDeliveryApplicationService(subscriptionRepository, deliveryRepository, webhookSender, deliveryPolicy, eventPublisher, portTransactionManager)
+	webhookDispatchHandler := handler2.NewWebhookDispatchHandler(deliveryApplicationService)
+	deliveryAttemptHandler := handler2.NewDeliveryAttemptHandler(deliveryApplicationService)
+	auditRecordedHandler := handler3.NewAuditRecordedHandler(auditLogRepository)
+	eventHandlerRegistry := event2.NewHandlerRegistry(fileUploadedHandler, multipartUploadExpiredHandler, webhookDispatchHandler, deliveryAttemptHandler, auditRecordedHandler)
+	fileService := service8.NewFileService(fileApplicationService)
 	serviceRegistry := grpc.NewServiceRegistry(fileService)
 	server := provider.ProvideHTTPServer(config, handlerRegistry)
 	grpcServer := provider.ProvideGRPCServer(ctx, config, serviceRegistry)
 	websocketServer := provider.ProvideWebSocketServer(config, webSocket)
 	healthServer := provider.ProvideHealthServer(config)
-	consumerService := provider.ProvideEventConsumerService(mq, logger)
-	eventServer := provider.ProvideEventServer(config, consumerService, eventHandlerRegistry)
-	app := NewApp(config, monitor, logger, transactionManager, client, mq, storage, handlerRegistry, webSocket, eventHandlerRegistry, serviceRegistry, server, grpcServer, websocketServer, healthServer, bus, consumerService, eventServer)
+	inbox := provider.ProvideEventInbox(config, transactionManager, client)
+	schemaRegistry := event2.NewSchemaRegistry()
+	consumerService, err := provider.ProvideEventConsumerService(config, mq, inbox, deadLetterQueue, schemaRegistry, logger)
+	if err != nil {
+		return nil, err
+	}
+	eventReplayer := provider.ProvideEventReplayer(eventStore, schemaRegistry, logger)
+	eventServer := provider.ProvideEventServer(config, consumerService, bus, eventHandlerRegistry)
+	outboxRelay := provider.ProvideOutboxRelay(config, transactionManager, mq, eventStore, logger)
+	outboxServer := provider.ProvideOutboxServer(config, outboxRelay)
+	app := NewApp(config, monitor, logger, transactionManager, client, mq, storage, handlerRegistry, webSocket, eventHandlerRegistry, serviceRegistry, server, grpcServer, websocketServer, healthServer, bus, consumerService, eventReplayer, eventServer, outboxServer)
 	return app, nil
 }
@@ -0,0 +1,35 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dysodeng/app/internal/domain/audit/model"
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+)
+
+// AuditRecordedEventType 管理操作审计事件，由事件处理器异步写入审计日志
+const AuditRecordedEventType = "audit.recorded"
+
+// AuditRecordedEventVersion 管理操作审计事件结构版本
+const AuditRecordedEventVersion = 1
+
+type AuditRecorded struct {
+	ActorType  string              `json:"actor_type"`
+	ActorID    string              `json:"actor_id"`
+	Action     string              `json:"action"`
+	TargetType string              `json:"target_type"`
+	TargetID   string              `json:"target_id"`
+	Before     json.RawMessage     `json:"before,omitempty"`
+	After      json.RawMessage     `json:"after,omitempty"`
+	Changes    []model.FieldChange `json:"changes,omitempty"`
+	IP         string              `json:"ip"`
+	UserAgent  string              `json:"user_agent"`
+	TraceID    string              `json:"trace_id"`
+	OccurredAt time.Time           `json:"occurred_at"`
+}
+
+func NewAuditRecordedEvent(payload AuditRecorded) domainEvent.DomainEvent[AuditRecorded] {
+	return domainEvent.NewDomainEvent(AuditRecordedEventType, payload.TargetID, payload.TargetType, payload).
+		WithVersion(AuditRecordedEventVersion)
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 常用审计操作
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionEnable  = "enable"
+	ActionDisable = "disable"
+	ActionReplay  = "replay"
+	ActionDiscard = "discard"
+	ActionPurge   = "purge"
+	ActionExport  = "export"
+)
+
+// AuditLog 审计日志(只追加，不修改不删除)
+type AuditLog struct {
+	ID         uuid.UUID
+	EventID    string // 来源事件ID，重复投递时据此去重
+	ActorType  string // 操作人类型
+	ActorID    string // 操作人ID
+	Action     string // 操作
+	TargetType string // 操作对象类型
+	TargetID   string // 操作对象ID
+	Before     string // 变更前快照(JSON)
+	After      string // 变更后快照(JSON)
+	Changes    []FieldChange
+	IP         string
+	UserAgent  string
+	TraceID    string
+	OccurredAt time.Time
+}
+
+// FieldChange 字段变更
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// Diff 对比变更前后快照的顶层字段，返回有变化的字段，按字段名排序
+func Diff(before, after map[string]any) []FieldChange {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for k := range before {
+		fields[k] = struct{}{}
+	}
+	for k := range after {
+		fields[k] = struct{}{}
+	}
+
+	changes := make([]FieldChange, 0)
+	for field := range fields {
+		b, a := before[field], after[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dysodeng/app/internal/domain/audit/model"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
+)
+
+// AuditLogQuery 审计日志查询参数
+type AuditLogQuery struct {
+	ActorType  string     // 操作人类型，可选
+	ActorID    string     // 操作人ID，可选
+	Action     string     // 操作，可选
+	TargetType string     // 操作对象类型，可选
+	TargetID   string     // 操作对象ID，可选
+	StartTime  *time.Time // 开始时间，可选
+	EndTime    *time.Time // 结束时间，可选
+	sharedVO.PageRequest
+}
+
+// AuditLogRepository 审计日志仓储接口，只追加不修改
+type AuditLogRepository interface {
+	// Append 追加审计日志，相同事件ID重复写入时忽略
+	Append(ctx context.Context, log *model.AuditLog) error
+	// FindList 查询审计日志列表
+	FindList(ctx context.Context, query AuditLogQuery) ([]model.AuditLog, sharedVO.PageInfo, error)
+}
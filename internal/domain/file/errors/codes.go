@@ -11,6 +11,7 @@ const (
 	CodeFileIDEmpty          = "FILE_ID_EMPTY"
 	CodeFilePathEmpty        = "FILE_PATH_EMPTY"
 	CodeFileQueryFailed      = "FILE_QUERY_FAILED"
+	CodeFileListQueryInvalid = "FILE_LIST_QUERY_INVALID"
 	CodeFileNameExists       = "FILE_NAME_EXISTS"
 	CodeFileDeleteFailed     = "FILE_DELETE_FAILED"
 	CodeFileUploadFailed     = "FILE_UPLOAD_FAILED"
@@ -38,6 +39,9 @@ var (
 	ErrFileQueryFailed  = domainErrors.NewFileError(CodeFileQueryFailed, "文件查询失败", nil)
 	ErrFileNameExists   = domainErrors.NewFileError(CodeFileNameExists, "已存在同名文件", nil)
 	ErrFileDeleteFailed = domainErrors.NewFileError(CodeFileDeleteFailed, "文件删除失败", nil)
+
+	// ErrFileListQueryInvalid 排序字段或分页游标无效
+	ErrFileListQueryInvalid = domainErrors.NewFileError(CodeFileListQueryInvalid, "排序字段或分页游标无效", nil)
 )
 
 // 文件上传相关错误
@@ -9,6 +9,9 @@ import (
 // FileUploadedEventType 文件上传事件
 const FileUploadedEventType = "file.uploaded"
 
+// FileUploadedEventVersion 文件上传事件结构版本，变更 FileUploaded 字段时递增并注册升级函数
+const FileUploadedEventVersion = 1
+
 type FileUploaded struct {
 	FileID   uuid.UUID `json:"file_id"`
 	FileName string    `json:"file_name"`
@@ -23,5 +26,6 @@ func NewFileUploadedEvent(fileID uuid.UUID, fileName, filePath string, fileSize
 		FilePath: filePath,
 		FileSize: fileSize,
 	}
-	return domainEvent.NewDomainEvent(FileUploadedEventType, fileID.String(), fileName, payload)
+	return domainEvent.NewDomainEvent(FileUploadedEventType, fileID.String(), fileName, payload).
+		WithVersion(FileUploadedEventVersion)
 }
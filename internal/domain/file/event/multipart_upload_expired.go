@@ -0,0 +1,29 @@
+package event
+
+import (
+	"github.com/google/uuid"
+
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+)
+
+// MultipartUploadExpiredEventType 分片上传过期事件
+const MultipartUploadExpiredEventType = "file.multipart_upload_expired"
+
+// MultipartUploadExpiredEventVersion 分片上传过期事件结构版本
+const MultipartUploadExpiredEventVersion = 1
+
+type MultipartUploadExpired struct {
+	MultipartUploadID uuid.UUID `json:"multipart_upload_id"`
+	UploadID          string    `json:"upload_id"`
+	Path              string    `json:"path"`
+}
+
+func NewMultipartUploadExpiredEvent(id uuid.UUID, uploadId, path string) domainEvent.DomainEvent[MultipartUploadExpired] {
+	payload := MultipartUploadExpired{
+		MultipartUploadID: id,
+		UploadID:          uploadId,
+		Path:              path,
+	}
+	return domainEvent.NewDomainEvent(MultipartUploadExpiredEventType, id.String(), "multipart_upload", payload).
+		WithVersion(MultipartUploadExpiredEventVersion)
+}
@@ -21,6 +21,7 @@ type File struct {
 	Ext       string                `json:"ext"`
 	MimeType  string                `json:"mime_type"`
 	Status    uint8                 `json:"status"`
+	Version   uint64                `json:"version"` // 乐观锁版本号，保存时校验
 	CreatedAt time.Time             `json:"created_at"`
 }
 
@@ -6,6 +6,16 @@ import (
 	"github.com/google/uuid"
 )
 
+// MultipartUploadExpiration 分片上传有效期，超过有效期未完成的上传将被取消
+const MultipartUploadExpiration = 24 * time.Hour
+
+// 分片上传状态
+const (
+	MultipartUploadStatusUploading uint8 = 1 // 进行中
+	MultipartUploadStatusCompleted uint8 = 2 // 已完成
+	MultipartUploadStatusAborted   uint8 = 3 // 已取消
+)
+
 // MultipartUpload 分片上传信息
 type MultipartUpload struct {
 	ID        uuid.UUID `json:"id"`
@@ -36,7 +46,7 @@ func NewMultipartUpload(fileName, path string, size uint64, mimeType, ext, uploa
 		MimeType: mimeType,
 		Ext:      ext,
 		UploadID: uploadId,
-		Status:   1,
+		Status:   MultipartUploadStatusUploading,
 		Parts:    make([]*Part, 0),
 	}
 }
@@ -52,10 +62,15 @@ func (m *MultipartUpload) AddPart(partNumber int, etag string, size int64) {
 
 // Complete 完成上传
 func (m *MultipartUpload) Complete() {
-	m.Status = 2
+	m.Status = MultipartUploadStatusCompleted
 }
 
 // Abort 取消上传
 func (m *MultipartUpload) Abort() {
-	m.Status = 3
+	m.Status = MultipartUploadStatusAborted
+}
+
+// IsUploading 是否上传中
+func (m *MultipartUpload) IsUploading() bool {
+	return m.Status == MultipartUploadStatusUploading
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/dysodeng/app/internal/domain/file/model"
 	"github.com/dysodeng/app/internal/domain/file/valueobject"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
 )
 
 // FileQuery 文件查询参数
@@ -16,17 +17,14 @@ type FileQuery struct {
 	Keyword   string                // 关键词搜索，可选
 	StartTime *time.Time            // 开始时间，可选
 	EndTime   *time.Time            // 结束时间，可选
-	OrderBy   string                // 排序字段
-	OrderType string                // 排序方式：asc/desc
-	Page      int                   // 页码
-	PageSize  int                   // 每页数量
 	FileIDs   []uint64
+	sharedVO.PageRequest
 }
 
 // FileRepository 文件仓储接口
 type FileRepository interface {
-	// FindList 查询文件列表
-	FindList(ctx context.Context, query FileQuery) ([]model.File, int64, error)
+	// FindList 查询文件列表，支持页码与游标分页
+	FindList(ctx context.Context, query FileQuery) ([]model.File, sharedVO.PageInfo, error)
 	// FindByID 根据ID获取文件信息
 	FindByID(ctx context.Context, id uuid.UUID) (*model.File, error)
 	// FindListByIds 根据文件id列表获取文件列表
@@ -8,7 +8,7 @@ import (
 	"github.com/dysodeng/app/internal/domain/file/errors"
 	"github.com/dysodeng/app/internal/domain/file/model"
 	"github.com/dysodeng/app/internal/domain/file/repository"
-	"github.com/dysodeng/app/internal/domain/file/valueobject"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
 )
 
 // FileDomainService 文件管理领域服务
@@ -16,7 +16,8 @@ type FileDomainService interface {
 	// CheckFileNameAvailable 检查文件名是否可用(查重名)
 	CheckFileNameAvailable(ctx context.Context, name string, excludeId uuid.UUID) error
 	Info(ctx context.Context, id uuid.UUID) (*model.File, error)
-	List(ctx context.Context, mediaType valueobject.MediaType, keyword, orderBy, orderType string, page, pageSize int) ([]model.File, int64, error)
+	// List 文件列表，支持页码与游标分页，按需统计总数
+	List(ctx context.Context, query repository.FileQuery) ([]model.File, sharedVO.PageInfo, error)
 	Delete(ctx context.Context, id uuid.UUID, ids []uuid.UUID) error
 }
 
@@ -59,21 +60,15 @@ func (svc *fileDomainService) Info(ctx context.Context, id uuid.UUID) (*model.Fi
 	return file, nil
 }
 
-func (svc *fileDomainService) List(ctx context.Context, mediaType valueobject.MediaType, keyword, orderBy, orderType string, page, pageSize int) ([]model.File, int64, error) {
-	// 构建查询参数
-	query := repository.FileQuery{
-		MediaType: mediaType,
-		Keyword:   keyword,
-		OrderBy:   orderBy,
-		OrderType: orderType,
-		Page:      page,
-		PageSize:  pageSize,
-	}
-	list, total, err := svc.fileRepository.FindList(ctx, query)
+func (svc *fileDomainService) List(ctx context.Context, query repository.FileQuery) ([]model.File, sharedVO.PageInfo, error) {
+	list, pageInfo, err := svc.fileRepository.FindList(ctx, query)
 	if err != nil {
-		return nil, 0, errors.ErrFileQueryFailed.Wrap(err)
+		if sharedVO.IsInvalidPageRequest(err) {
+			return nil, pageInfo, errors.ErrFileListQueryInvalid.Wrap(err)
+		}
+		return nil, pageInfo, errors.ErrFileQueryFailed.Wrap(err)
 	}
-	return list, total, nil
+	return list, pageInfo, nil
 }
 
 func (svc *fileDomainService) Delete(ctx context.Context, id uuid.UUID, ids []uuid.UUID) error {
@@ -18,6 +18,7 @@ import (
 	filePort "github.com/dysodeng/app/internal/domain/file/port"
 	"github.com/dysodeng/app/internal/domain/file/repository"
 	"github.com/dysodeng/app/internal/infrastructure/shared/helper"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
 )
 
 // UploaderDomainService 文件上传领域服务
@@ -55,8 +56,8 @@ func NewUploaderDomainService(
 	}
 }
 
-// generateFilePath 生成上传文件路径
-func (svc *uploaderDomainService) generateFilePath(ext string) (string, error) {
+// generateFilePath 生成上传文件路径，多租户时位于租户目录下
+func (svc *uploaderDomainService) generateFilePath(ctx context.Context, ext string) (string, error) {
 	if strings.ContainsRune(ext, '/') { // 防止路径注入
 		ext = ".invalid"
 	}
@@ -82,12 +83,22 @@ func (svc *uploaderDomainService) generateFilePath(ext string) (string, error) {
 	)
 
 	return path.Join(
+		tenant.StoragePrefix(ctx),
 		"resources",
 		dateDir,
 		fileName,
 	), nil
 }
 
+// inTenantDir 路径是否位于当前租户目录内，无租户时不限制
+func inTenantDir(ctx context.Context, p string) bool {
+	prefix := tenant.StoragePrefix(ctx)
+	if prefix == "" {
+		return true
+	}
+	return strings.HasPrefix(path.Clean("/"+p), "/"+prefix+"/")
+}
+
 // checkFileAllow 检查文件上传限制
 func (svc *uploaderDomainService) checkFileAllow(ext, mimeType string, size int64) error {
 	ext = strings.TrimLeft(ext, ".")
@@ -128,7 +139,7 @@ func (svc *uploaderDomainService) UploadFile(ctx context.Context, file *multipar
 	}
 
 	// 生成最终路径（相对路径）
-	filePath, _ := svc.generateFilePath(ext)
+	filePath, _ := svc.generateFilePath(ctx, ext)
 
 	// 上传
 	if err = svc.storage.Upload(ctx, filePath, src, mimeType); err != nil {
@@ -159,7 +170,7 @@ func (svc *uploaderDomainService) InitMultipartUpload(ctx context.Context, filen
 		return "", "", errors.ErrFileNameExists
 	}
 
-	filePath, _ := svc.generateFilePath(ext)
+	filePath, _ := svc.generateFilePath(ctx, ext)
 
 	uploadId, err := svc.storage.InitMultipartUpload(ctx, filePath, mimeType)
 	if err != nil {
@@ -176,7 +187,13 @@ func (svc *uploaderDomainService) UploadPart(ctx context.Context, path, uploadId
 	}
 	defer func() { _ = src.Close() }()
 
-	etag, err := svc.storage.UploadPart(ctx, svc.storage.RelativePath(ctx, path), uploadId, partNumber, src)
+	// 分片路径由客户端回传，限制在当前租户目录内
+	relPath := svc.storage.RelativePath(ctx, path)
+	if !inTenantDir(ctx, relPath) {
+		return nil, errors.ErrMultipartUploadFailed
+	}
+
+	etag, err := svc.storage.UploadPart(ctx, relPath, uploadId, partNumber, src)
 	if err != nil {
 		return nil, errors.ErrMultipartUploadFailed.Wrap(err)
 	}
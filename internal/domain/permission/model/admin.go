@@ -14,6 +14,7 @@ type Admin struct {
 	Remark       string
 	IsSuper      sharedModel.BinaryStatus
 	Status       sharedModel.BinaryStatus
+	Version      uint64 // 乐观锁版本号，保存时校验
 }
 
 func NewAdmin(
@@ -21,6 +21,8 @@ const (
 	CodeCommonUnauthorized = "COMMON_UNAUTHORIZED"
 	// CodeCommonInternalError 系统内部错误
 	CodeCommonInternalError = "COMMON_INTERNAL_ERROR"
+	// CodeCommonConflict 数据已被并发修改
+	CodeCommonConflict = "COMMON_CONFLICT"
 )
 
 // 预定义通用错误
@@ -33,4 +35,5 @@ var (
 	ErrCommonPermissionDenied = NewCommonError(CodeCommonPermissionDenied, "权限不足", nil)
 	ErrCommonUnauthorized     = NewCommonError(CodeCommonUnauthorized, "未授权", nil)
 	ErrCommonInternalError    = NewCommonError(CodeCommonInternalError, "系统内部错误", nil)
+	ErrCommonConflict         = NewCommonError(CodeCommonConflict, "数据已被修改，请刷新后重试", nil)
 )
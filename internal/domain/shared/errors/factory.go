@@ -8,6 +8,7 @@ const (
 	DomainFile       = "file"
 	DomainPassport   = "passport"
 	DomainPermission = "permission"
+	DomainWebhook    = "webhook"
 )
 
 // NewCommonError 创建通用领域错误
@@ -38,3 +39,8 @@ func NewPassportError(code, message string, err error) *DomainError {
 func NewPermissionError(code, message string, err error) *DomainError {
 	return NewDomainError(DomainPermission, code, message, err)
 }
+
+// NewWebhookError 创建Webhook领域错误
+func NewWebhookError(code, message string, err error) *DomainError {
+	return NewDomainError(DomainWebhook, code, message, err)
+}
@@ -1,23 +1,47 @@
 package event
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // DomainEvent 领域事件
 type DomainEvent[T any] struct {
+	ID            string    `json:"id"`
 	Type          string    `json:"type"`
+	Version       int       `json:"version"`
 	OccurredAt    time.Time `json:"timestamp"`
 	Payload       T         `json:"data"`
 	AggregateID   string    `json:"aggregate_id,omitempty"`
 	AggregateName string    `json:"aggregate_name,omitempty"`
+	TenantID      string    `json:"tenant_id,omitempty"` // 所属租户，为空时发布时取上下文中的租户
 }
 
 // NewDomainEvent 创建领域事件
 func NewDomainEvent[T any](eventType string, aggregateID string, aggregateName string, data T) DomainEvent[T] {
 	return DomainEvent[T]{
+		ID:            NewEventID(),
 		Type:          eventType,
+		Version:       1,
 		OccurredAt:    time.Now(),
 		Payload:       data,
 		AggregateID:   aggregateID,
 		AggregateName: aggregateName,
 	}
 }
+
+// WithVersion 设置事件结构版本
+func (e DomainEvent[T]) WithVersion(version int) DomainEvent[T] {
+	e.Version = version
+	return e
+}
+
+// NewEventID 生成事件唯一ID
+func NewEventID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return id.String()
+}
@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// AuditRecorder 管理操作审计记录端口
+// 操作人、IP、UA与追踪ID从上下文获取，审计日志经事件总线异步写入
+type AuditRecorder interface {
+	// Record 记录一次管理操作，before/after 为变更前后快照，新增时 before 为nil，删除时 after 为nil
+	Record(ctx context.Context, action, targetType, targetID string, before, after any) error
+}
@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheNamespaceNotFound 缓存命名空间不存在
+var ErrCacheNamespaceNotFound = errors.New("cache namespace not found")
+
+// CacheTagVersion 缓存标签版本
+type CacheTagVersion struct {
+	Tag     string
+	Version string
+}
+
+// CacheKeyInfo 缓存key诊断信息，仅包含元数据，不返回缓存值(可能包含密码哈希、手机号等敏感数据)
+type CacheKeyInfo struct {
+	Namespace    string
+	Key          string
+	Tags         []CacheTagVersion
+	Exists       bool
+	Negative     bool // 空值缓存
+	Fresh        bool // 逻辑有效期内
+	ExpireAt     *time.Time
+	LoadDuration time.Duration
+	Size         int // 缓存数据字节数
+}
+
+// CacheInspector 缓存诊断与清理端口
+type CacheInspector interface {
+	// Namespaces 缓存命名空间列表
+	Namespaces(ctx context.Context) []string
+	// Inspect 查看缓存key及其标签版本
+	Inspect(ctx context.Context, namespace, base string, tags ...string) (*CacheKeyInfo, error)
+	// InvalidateTags 标签失效
+	InvalidateTags(ctx context.Context, namespace string, tags ...string) error
+	// Purge 清空命名空间下的全部缓存数据
+	Purge(ctx context.Context, namespace string) error
+}
@@ -0,0 +1,49 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrDeadLetterNotFound 死信不存在
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrDeadLetterNotPending 死信已被处理
+	ErrDeadLetterNotPending = errors.New("dead letter is not pending")
+)
+
+// DeadLetter 事件处理死信
+type DeadLetter struct {
+	ID        uint64
+	EventID   string
+	EventType string
+	Handler   string
+	Payload   string
+	Error     string
+	Attempts  int
+	Status    uint8 // 1-待处理 2-已重放 3-已丢弃
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DeadLetterFilter 死信查询条件
+type DeadLetterFilter struct {
+	Status    uint8
+	EventType string
+	Handler   string
+	Page      int
+	PageSize  int
+}
+
+// DeadLetterQueue 事件死信队列端口
+type DeadLetterQueue interface {
+	// List 分页查询死信
+	List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetter, int64, error)
+	// Find 查询死信，不存在时返回 ErrDeadLetterNotFound
+	Find(ctx context.Context, id uint64) (*DeadLetter, error)
+	// Replay 重放待处理死信，已处理时返回 ErrDeadLetterNotPending
+	Replay(ctx context.Context, id uint64) error
+	// Discard 丢弃待处理死信，已处理时返回 ErrDeadLetterNotPending
+	Discard(ctx context.Context, id uint64) error
+}
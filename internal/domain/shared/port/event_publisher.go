@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
 )
@@ -9,4 +10,8 @@ import (
 // EventPublisher 事件发布端口
 type EventPublisher interface {
 	Publish(ctx context.Context, e domainEvent.DomainEvent[any]) error
+	// PublishEventAt 在指定时间发布事件
+	PublishEventAt(ctx context.Context, e domainEvent.DomainEvent[any], at time.Time) error
+	// PublishEventAfter 延迟指定时长后发布事件
+	PublishEventAfter(ctx context.Context, e domainEvent.DomainEvent[any], delay time.Duration) error
 }
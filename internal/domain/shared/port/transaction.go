@@ -1,8 +1,47 @@
 package port
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"time"
+)
 
 // TransactionManager 事务管理端口
 type TransactionManager interface {
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// Transaction 开启事务，已在事务中时通过保存点开启嵌套事务
+	Transaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TransactionOption) error
+}
+
+// TransactionOptions 事务选项，仅对最外层事务生效
+type TransactionOptions struct {
+	Isolation    sql.IsolationLevel // 隔离级别
+	ReadOnly     bool               // 只读事务
+	MaxRetries   int                // 序列化失败/死锁时整体重试次数，默认不重试
+	RetryBackoff time.Duration      // 重试退避基准时长
+}
+
+// TransactionOption 事务选项
+type TransactionOption func(options *TransactionOptions)
+
+// WithTxIsolation 设置隔离级别
+func WithTxIsolation(level sql.IsolationLevel) TransactionOption {
+	return func(options *TransactionOptions) {
+		options.Isolation = level
+	}
+}
+
+// WithTxReadOnly 只读事务
+func WithTxReadOnly() TransactionOption {
+	return func(options *TransactionOptions) {
+		options.ReadOnly = true
+	}
+}
+
+// WithTxRetry 序列化失败/死锁时重试整个事务函数
+// 仅在事务函数无事务外副作用(发送HTTP请求、发布消息等)时开启
+func WithTxRetry(maxRetries int, backoff time.Duration) TransactionOption {
+	return func(options *TransactionOptions) {
+		options.MaxRetries = maxRetries
+		options.RetryBackoff = backoff
+	}
 }
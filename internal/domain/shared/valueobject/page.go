@@ -0,0 +1,38 @@
+package valueobject
+
+import "errors"
+
+const (
+	DefaultPageSize = 20  // 未指定每页数量时的默认值
+	MaxPageSize     = 500 // 每页数量上限
+)
+
+var (
+	// ErrInvalidSortField 排序字段不在白名单内
+	ErrInvalidSortField = errors.New("invalid sort field")
+	// ErrInvalidCursor 游标无法解析或与当前排序不匹配
+	ErrInvalidCursor = errors.New("invalid page cursor")
+)
+
+// PageRequest 分页请求
+// Cursor 非空时按游标(keyset)分页，忽略 Page；否则按页码分页
+type PageRequest struct {
+	Cursor    string // 上一页返回的不透明游标
+	Page      int    // 页码，从1开始
+	PageSize  int    // 每页数量，<=0 时取 DefaultPageSize，超过 MaxPageSize 时取 MaxPageSize，不支持一次返回全部数据
+	OrderBy   string // 排序字段，须在仓储白名单内
+	OrderType string // 排序方式：asc/desc
+	WithTotal bool   // 是否统计总数，大表慎用
+}
+
+// PageInfo 分页结果
+type PageInfo struct {
+	Total      int64  // 总数，未统计时为-1
+	NextCursor string // 下一页游标，无更多数据时为空
+	HasMore    bool   // 是否还有下一页
+}
+
+// IsInvalidPageRequest 是否为分页参数错误(排序字段或游标无效)
+func IsInvalidPageRequest(err error) bool {
+	return errors.Is(err, ErrInvalidSortField) || errors.Is(err, ErrInvalidCursor)
+}
@@ -0,0 +1,29 @@
+package event
+
+import (
+	"github.com/google/uuid"
+
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+)
+
+// UserRegisteredEventType 用户注册事件
+const UserRegisteredEventType = "user.registered"
+
+// UserRegisteredEventVersion 用户注册事件结构版本
+const UserRegisteredEventVersion = 1
+
+type UserRegistered struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Nickname string    `json:"nickname"`
+	Platform string    `json:"platform"`
+}
+
+func NewUserRegisteredEvent(userID uuid.UUID, nickname, platform string) domainEvent.DomainEvent[UserRegistered] {
+	payload := UserRegistered{
+		UserID:   userID,
+		Nickname: nickname,
+		Platform: platform,
+	}
+	return domainEvent.NewDomainEvent(UserRegisteredEventType, userID.String(), "user", payload).
+		WithVersion(UserRegisteredEventVersion)
+}
@@ -20,6 +20,7 @@ type User struct {
 	Nickname            string
 	Avatar              valueobject.Avatar
 	Status              sharedModel.BinaryStatus
+	Version             uint64 // 乐观锁版本号，保存时校验
 	CreatedAt           time.Time
 }
 
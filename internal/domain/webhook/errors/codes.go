@@ -0,0 +1,33 @@
+package errors
+
+import (
+	domainErrors "github.com/dysodeng/app/internal/domain/shared/errors"
+)
+
+// Webhook领域错误码
+const (
+	CodeWebhookSubscriptionNotFound   = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+	CodeWebhookNameEmpty              = "WEBHOOK_NAME_EMPTY"
+	CodeWebhookURLInvalid             = "WEBHOOK_URL_INVALID"
+	CodeWebhookURLForbidden           = "WEBHOOK_URL_FORBIDDEN"
+	CodeWebhookEventTypesEmpty        = "WEBHOOK_EVENT_TYPES_EMPTY"
+	CodeWebhookEventTypeUnsupported   = "WEBHOOK_EVENT_TYPE_UNSUPPORTED"
+	CodeWebhookSecretTooShort         = "WEBHOOK_SECRET_TOO_SHORT"
+	CodeWebhookQueryFailed            = "WEBHOOK_QUERY_FAILED"
+	CodeWebhookSubscriptionSaveFailed = "WEBHOOK_SUBSCRIPTION_SAVE_FAILED"
+	CodeWebhookDeleteFailed           = "WEBHOOK_DELETE_FAILED"
+)
+
+// 预定义Webhook领域错误
+var (
+	ErrWebhookSubscriptionNotFound   = domainErrors.NewWebhookError(CodeWebhookSubscriptionNotFound, "Webhook订阅不存在", nil)
+	ErrWebhookNameEmpty              = domainErrors.NewWebhookError(CodeWebhookNameEmpty, "Webhook名称不能为空", nil)
+	ErrWebhookURLInvalid             = domainErrors.NewWebhookError(CodeWebhookURLInvalid, "Webhook地址无效，仅支持http/https", nil)
+	ErrWebhookURLForbidden           = domainErrors.NewWebhookError(CodeWebhookURLForbidden, "Webhook地址不允许指向内网或本机", nil)
+	ErrWebhookEventTypesEmpty        = domainErrors.NewWebhookError(CodeWebhookEventTypesEmpty, "订阅事件类型不能为空", nil)
+	ErrWebhookEventTypeUnsupported   = domainErrors.NewWebhookError(CodeWebhookEventTypeUnsupported, "不支持订阅的事件类型", nil)
+	ErrWebhookSecretTooShort         = domainErrors.NewWebhookError(CodeWebhookSecretTooShort, "签名密钥长度不能少于16位", nil)
+	ErrWebhookQueryFailed            = domainErrors.NewWebhookError(CodeWebhookQueryFailed, "Webhook查询失败", nil)
+	ErrWebhookSubscriptionSaveFailed = domainErrors.NewWebhookError(CodeWebhookSubscriptionSaveFailed, "Webhook订阅保存失败", nil)
+	ErrWebhookDeleteFailed           = domainErrors.NewWebhookError(CodeWebhookDeleteFailed, "Webhook订阅删除失败", nil)
+)
@@ -0,0 +1,27 @@
+package event
+
+import (
+	"github.com/google/uuid"
+
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+)
+
+// DeliveryAttemptEventType Webhook投递尝试事件，首次投递与失败重试均由该事件驱动
+const DeliveryAttemptEventType = "webhook.delivery_attempt"
+
+// DeliveryAttemptEventVersion Webhook投递尝试事件结构版本
+const DeliveryAttemptEventVersion = 1
+
+type DeliveryAttempt struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+	Attempt    int       `json:"attempt"` // 本次为第几次尝试，用于重复事件去重
+}
+
+func NewDeliveryAttemptEvent(deliveryID uuid.UUID, attempt int) domainEvent.DomainEvent[DeliveryAttempt] {
+	payload := DeliveryAttempt{
+		DeliveryID: deliveryID,
+		Attempt:    attempt,
+	}
+	return domainEvent.NewDomainEvent(DeliveryAttemptEventType, deliveryID.String(), "webhook_delivery", payload).
+		WithVersion(DeliveryAttemptEventVersion)
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 投递状态
+const (
+	DeliveryStatusPending   uint8 = 1 // 投递中(等待重试)
+	DeliveryStatusSucceeded uint8 = 2 // 投递成功
+	DeliveryStatusFailed    uint8 = 3 // 投递失败
+)
+
+// maxResponseBodyLength 投递日志记录的响应内容最大长度
+const maxResponseBodyLength = 1000
+
+// Delivery Webhook投递记录领域模型
+type Delivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        string
+	EventType      string
+	Payload        string
+	Status         uint8
+	Attempts       int
+	ResponseStatus int
+	ResponseBody   string
+	LastError      string
+	NextRetryAt    *time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewDelivery 创建投递记录
+func NewDelivery(subscriptionID uuid.UUID, eventID, eventType string, payload []byte) *Delivery {
+	return &Delivery{
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        string(payload),
+		Status:         DeliveryStatusPending,
+	}
+}
+
+// IsPending 是否等待投递
+func (d *Delivery) IsPending() bool {
+	return d.Status == DeliveryStatusPending
+}
+
+// Succeed 记录投递成功
+func (d *Delivery) Succeed(statusCode int, body []byte) {
+	now := time.Now()
+	d.Attempts++
+	d.Status = DeliveryStatusSucceeded
+	d.ResponseStatus = statusCode
+	d.ResponseBody = truncate(string(body))
+	d.LastError = ""
+	d.NextRetryAt = nil
+	d.DeliveredAt = &now
+}
+
+// Fail 记录投递失败，nextRetryAt 为空时不再重试
+func (d *Delivery) Fail(statusCode int, body []byte, err error, nextRetryAt *time.Time) {
+	d.Attempts++
+	d.ResponseStatus = statusCode
+	d.ResponseBody = truncate(string(body))
+	if err != nil {
+		d.LastError = truncate(err.Error())
+	}
+	d.NextRetryAt = nextRetryAt
+	if nextRetryAt == nil {
+		d.Status = DeliveryStatusFailed
+	}
+}
+
+// Abandon 放弃投递(订阅已停用或已删除)
+func (d *Delivery) Abandon(reason string) {
+	d.Status = DeliveryStatusFailed
+	d.LastError = reason
+	d.NextRetryAt = nil
+}
+
+func truncate(s string) string {
+	r := []rune(s)
+	if len(r) > maxResponseBodyLength {
+		return string(r[:maxResponseBodyLength])
+	}
+	return s
+}
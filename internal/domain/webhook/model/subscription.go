@@ -0,0 +1,95 @@
+package model
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/domain/webhook/errors"
+	"github.com/dysodeng/app/internal/domain/webhook/valueobject"
+	sharedModel "github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// Subscription Webhook订阅领域模型
+type Subscription struct {
+	ID                  uuid.UUID
+	Name                string
+	URL                 string
+	EventTypes          []string
+	Secret              string
+	Status              sharedModel.BinaryStatus
+	ConsecutiveFailures int        // 连续投递失败次数，投递成功后清零
+	DisabledAt          *time.Time // 最近一次停用时间
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// NewSubscription 创建Webhook订阅，未指定密钥时自动生成
+func NewSubscription(name, rawURL string, eventTypes []string, secret string) (*Subscription, error) {
+	if secret == "" {
+		secret = valueobject.GenerateSecret()
+	}
+	s := &Subscription{
+		Name:       strings.TrimSpace(name),
+		URL:        strings.TrimSpace(rawURL),
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Status:     sharedModel.BinaryStatusTrue,
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate 校验订阅信息
+func (s *Subscription) Validate() error {
+	if s.Name == "" {
+		return errors.ErrWebhookNameEmpty
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.ErrWebhookURLInvalid
+	}
+	if !valueobject.IsAllowedHost(u.Hostname()) {
+		return errors.ErrWebhookURLForbidden
+	}
+	if len(s.EventTypes) == 0 {
+		return errors.ErrWebhookEventTypesEmpty
+	}
+	if len(s.Secret) < valueobject.MinSecretLength {
+		return errors.ErrWebhookSecretTooShort
+	}
+	return nil
+}
+
+// RotateSecret 重新生成签名密钥
+func (s *Subscription) RotateSecret() {
+	s.Secret = valueobject.GenerateSecret()
+}
+
+// Subscribes 是否订阅了指定事件类型
+func (s *Subscription) Subscribes(eventType string) bool {
+	return slices.Contains(s.EventTypes, eventType)
+}
+
+// IsEnabled 是否启用
+func (s *Subscription) IsEnabled() bool {
+	return s.Status.Bool()
+}
+
+// Enable 启用订阅，并清零连续失败次数
+func (s *Subscription) Enable() {
+	s.Status = sharedModel.BinaryStatusTrue
+	s.ConsecutiveFailures = 0
+}
+
+// Disable 停用订阅
+func (s *Subscription) Disable() {
+	now := time.Now()
+	s.Status = sharedModel.BinaryStatusFalse
+	s.DisabledAt = &now
+}
@@ -0,0 +1,13 @@
+package port
+
+import "time"
+
+// DeliveryPolicy Webhook投递策略端口
+type DeliveryPolicy interface {
+	// MaxAttempts 单次投递最大尝试次数(含首次)
+	MaxAttempts() int
+	// RetryDelay 第 attempts 次失败后的重试等待时长
+	RetryDelay(attempts int) time.Duration
+	// DisableThreshold 连续失败次数达到阈值时自动停用订阅，0为不停用
+	DisableThreshold() int
+}
@@ -0,0 +1,15 @@
+package port
+
+import "context"
+
+// SendResult 请求结果
+type SendResult struct {
+	StatusCode int
+	Body       []byte
+}
+
+// WebhookSender Webhook请求发送端口
+type WebhookSender interface {
+	// Send 发送Webhook请求，非2xx响应返回错误，同时返回已收到的响应
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (*SendResult, error)
+}
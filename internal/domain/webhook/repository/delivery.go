@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/domain/webhook/model"
+)
+
+// DeliveryQuery 投递记录查询参数
+type DeliveryQuery struct {
+	SubscriptionID uuid.UUID
+	EventType      string // 事件类型，可选
+	Status         uint8  // 投递状态，可选
+	Page           int
+	PageSize       int
+}
+
+// DeliveryRepository Webhook投递记录仓储接口
+type DeliveryRepository interface {
+	// FindList 查询投递记录列表
+	FindList(ctx context.Context, query DeliveryQuery) ([]model.Delivery, int64, error)
+	// FindByID 根据ID获取投递记录
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Delivery, error)
+	// Create 创建投递记录，同一订阅的同一事件已存在投递记录时返回 false
+	Create(ctx context.Context, delivery *model.Delivery) (bool, error)
+	// Save 保存投递结果
+	Save(ctx context.Context, delivery *model.Delivery) error
+}
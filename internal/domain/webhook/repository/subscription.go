@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/domain/webhook/model"
+)
+
+// SubscriptionQuery 订阅查询参数
+type SubscriptionQuery struct {
+	Keyword   string // 名称关键词，可选
+	EventType string // 订阅事件类型，可选
+	Status    *uint8 // 状态，可选
+	Page      int
+	PageSize  int
+}
+
+// SubscriptionRepository Webhook订阅仓储接口
+type SubscriptionRepository interface {
+	// FindList 查询订阅列表
+	FindList(ctx context.Context, query SubscriptionQuery) ([]model.Subscription, int64, error)
+	// FindByID 根据ID获取订阅
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Subscription, error)
+	// FindEnabledByEventType 获取订阅了指定事件类型的已启用订阅
+	FindEnabledByEventType(ctx context.Context, eventType string) ([]model.Subscription, error)
+	// Save 保存订阅
+	Save(ctx context.Context, subscription *model.Subscription) error
+	// Delete 删除订阅及其投递记录
+	Delete(ctx context.Context, id uuid.UUID) error
+	// RecordDeliverySuccess 投递成功，清零连续失败次数
+	RecordDeliverySuccess(ctx context.Context, id uuid.UUID) error
+	// RecordDeliveryFailure 投递失败，累加连续失败次数，达到阈值时停用订阅并返回 true
+	RecordDeliveryFailure(ctx context.Context, id uuid.UUID, disableThreshold int) (bool, error)
+}
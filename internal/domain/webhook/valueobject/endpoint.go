@@ -0,0 +1,50 @@
+package valueobject
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// reservedPrefixes 非公网地址段(共享地址、本网络、基准测试)，不在 netip 内置判断范围内
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // 运营商级NAT，部分云厂商元数据服务在此网段
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// forbiddenHosts 禁止投递的主机名
+var forbiddenHosts = []string{"localhost", "metadata", "metadata.google.internal"}
+
+// IsPublicAddr 是否为公网地址
+// Webhook仅投递到公网地址，拒绝回环、私有、链路本地(含云元数据服务169.254.169.254)等地址，防止请求内网服务(SSRF)
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAllowedHost 是否允许投递到指定主机
+// 主机为IP时须为公网地址；主机名在投递建立连接时按解析后的地址再次校验，防止DNS重绑定绕过
+func IsAllowedHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return IsPublicAddr(addr)
+	}
+	if host == "" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	for _, forbidden := range forbiddenHosts {
+		if host == forbidden {
+			return false
+		}
+	}
+	return true
+}
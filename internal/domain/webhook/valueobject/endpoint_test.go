@@ -0,0 +1,41 @@
+package valueobject
+
+import "testing"
+
+func TestIsAllowedHost(t *testing.T) {
+	cases := map[string]bool{
+		"example.com":              true,
+		"93.184.216.34":            true,
+		"2606:2800:220:1::":        true,
+		"localhost":                false,
+		"api.localhost":            false,
+		"LOCALHOST.":               false,
+		"metadata.google.internal": false,
+		"127.0.0.1":                false,
+		"10.0.0.8":                 false,
+		"172.16.3.4":               false,
+		"192.168.1.1":              false,
+		"169.254.169.254":          false,
+		"100.100.100.200":          false,
+		"0.0.0.0":                  false,
+		"::1":                      false,
+		"fd00:ec2::254":            false,
+		"fe80::1":                  false,
+		"::ffff:127.0.0.1":         false,
+		"":                         false,
+	}
+	for host, want := range cases {
+		if got := IsAllowedHost(host); got != want {
+			t.Errorf("IsAllowedHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	if got := MaskSecret("0123456789abcdef"); got != "****cdef" {
+		t.Fatalf("MaskSecret = %s", got)
+	}
+	if got := MaskSecret("abc"); got != "****" {
+		t.Fatalf("MaskSecret short = %s", got)
+	}
+}
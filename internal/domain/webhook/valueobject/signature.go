@@ -0,0 +1,54 @@
+package valueobject
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// 签名相关请求头
+const (
+	HeaderEvent     = "X-Webhook-Event"     // 事件类型
+	HeaderEventID   = "X-Webhook-Event-Id"  // 事件ID
+	HeaderDelivery  = "X-Webhook-Delivery"  // 投递记录ID
+	HeaderTimestamp = "X-Webhook-Timestamp" // 签名时间戳(秒)
+	HeaderSignature = "X-Webhook-Signature" // 签名 sha256=<hex>
+)
+
+// MinSecretLength 签名密钥最小长度
+const MinSecretLength = 16
+
+// signaturePrefix 签名算法前缀
+const signaturePrefix = "sha256="
+
+// Sign 计算请求签名
+// 签名内容为 "<timestamp>.<body>"，使用订阅密钥做 HMAC-SHA256，接收方按相同规则校验并检查时间戳防重放
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature 校验请求签名
+func VerifySignature(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// GenerateSecret 生成随机签名密钥
+func GenerateSecret() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// MaskSecret 隐藏签名密钥，仅保留末4位用于辨识
+func MaskSecret(secret string) string {
+	if len(secret) <= 4 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
@@ -0,0 +1,31 @@
+package valueobject
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"1","type":"file.uploaded"}`)
+	signature := Sign("0123456789abcdef", 1760000000, body)
+
+	if signature != Sign("0123456789abcdef", 1760000000, body) {
+		t.Fatal("signature should be deterministic")
+	}
+	if !VerifySignature("0123456789abcdef", 1760000000, body, signature) {
+		t.Fatal("signature should be verified")
+	}
+	if VerifySignature("0123456789abcdeX", 1760000000, body, signature) {
+		t.Fatal("signature with different secret should not be verified")
+	}
+	if VerifySignature("0123456789abcdef", 1760000001, body, signature) {
+		t.Fatal("signature with different timestamp should not be verified")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret := GenerateSecret()
+	if len(secret) < MinSecretLength {
+		t.Fatalf("secret length %d less than %d", len(secret), MinSecretLength)
+	}
+	if secret == GenerateSecret() {
+		t.Fatal("secret should be random")
+	}
+}
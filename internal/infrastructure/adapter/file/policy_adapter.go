@@ -13,19 +13,29 @@ func NewFilePolicyAdapter() domainPort.FilePolicy {
 	return &PolicyAdapter{}
 }
 
+// Allow 返回媒体类型允许的文件类型与容量，优先使用当前配置中的上传策略，支持热更新
 func (a *PolicyAdapter) Allow(mediaType valueobject.MediaType) ([]string, int64) {
+	var key string
+	var allow infraConfig.FileAllow
 	switch mediaType {
 	case valueobject.MediaTypeImage:
-		return infraConfig.AmsFileAllow.Image.AllowMimeType, infraConfig.AmsFileAllow.Image.AllowCapacitySize.ToInt()
+		key, allow = "image", infraConfig.AmsFileAllow.Image
 	case valueobject.MediaTypeAudio:
-		return infraConfig.AmsFileAllow.Audio.AllowMimeType, infraConfig.AmsFileAllow.Audio.AllowCapacitySize.ToInt()
+		key, allow = "audio", infraConfig.AmsFileAllow.Audio
 	case valueobject.MediaTypeVideo:
-		return infraConfig.AmsFileAllow.Video.AllowMimeType, infraConfig.AmsFileAllow.Video.AllowCapacitySize.ToInt()
+		key, allow = "video", infraConfig.AmsFileAllow.Video
 	case valueobject.MediaTypeDocument:
-		return infraConfig.AmsFileAllow.Document.AllowMimeType, infraConfig.AmsFileAllow.Document.AllowCapacitySize.ToInt()
+		key, allow = "document", infraConfig.AmsFileAllow.Document
 	case valueobject.MediaTypeCompressed:
-		return infraConfig.AmsFileAllow.Compressed.AllowMimeType, infraConfig.AmsFileAllow.Compressed.AllowCapacitySize.ToInt()
+		key, allow = "compressed", infraConfig.AmsFileAllow.Compressed
 	default:
 		return nil, 0
 	}
+
+	if cfg := infraConfig.Current(); cfg != nil {
+		if policy, ok := cfg.Storage.UploadPolicy[key]; ok {
+			allow = policy
+		}
+	}
+	return allow.AllowMimeType, allow.AllowCapacitySize.ToInt()
 }
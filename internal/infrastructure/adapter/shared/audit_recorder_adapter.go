@@ -0,0 +1,124 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	auditEvent "github.com/dysodeng/app/internal/domain/audit/event"
+	auditModel "github.com/dysodeng/app/internal/domain/audit/model"
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+	domainPort "github.com/dysodeng/app/internal/domain/shared/port"
+	"github.com/dysodeng/app/internal/infrastructure/shared/principal"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+const (
+	// redactedValue 敏感字段脱敏后的值
+	redactedValue = "******"
+	// maxUserAgentLength 客户端标识最大长度，与审计日志表字段长度一致
+	maxUserAgentLength = 500
+)
+
+// sensitiveFields 快照中需脱敏的字段名片段
+var sensitiveFields = []string{"password", "secret", "token"}
+
+// AuditRecorderAdapter 审计记录适配器，构造审计事件并经事件总线发布
+type AuditRecorderAdapter struct {
+	publisher domainPort.EventPublisher
+}
+
+func NewAuditRecorderAdapter(publisher domainPort.EventPublisher) domainPort.AuditRecorder {
+	return &AuditRecorderAdapter{publisher: publisher}
+}
+
+func (a *AuditRecorderAdapter) Record(ctx context.Context, action, targetType, targetID string, before, after any) error {
+	beforeRaw, beforeMap, err := snapshot(before)
+	if err != nil {
+		return err
+	}
+	afterRaw, afterMap, err := snapshot(after)
+	if err != nil {
+		return err
+	}
+
+	p, _ := principal.FromContext(ctx)
+	evt := auditEvent.NewAuditRecordedEvent(auditEvent.AuditRecorded{
+		ActorType:  p.Type,
+		ActorID:    p.ID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     beforeRaw,
+		After:      afterRaw,
+		Changes:    auditModel.Diff(beforeMap, afterMap),
+		IP:         p.IP,
+		UserAgent:  truncate(p.UserAgent, maxUserAgentLength),
+		TraceID:    trace.ParseContextTraceId(ctx),
+		OccurredAt: time.Now(),
+	})
+
+	return a.publisher.Publish(ctx, domainEvent.DomainEvent[any]{
+		ID:            evt.ID,
+		Type:          evt.Type,
+		Version:       evt.Version,
+		OccurredAt:    evt.OccurredAt,
+		AggregateID:   evt.AggregateID,
+		AggregateName: evt.AggregateName,
+		Payload:       evt.Payload,
+	})
+}
+
+// snapshot 序列化快照并脱敏，返回脱敏后的JSON与顶层字段
+// 非对象快照原样保留，不参与字段对比
+func snapshot(v any) (json.RawMessage, map[string]any, error) {
+	if v == nil {
+		return nil, nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err = decoder.Decode(&fields); err != nil {
+		return raw, nil, nil
+	}
+	for field := range fields {
+		if isSensitive(field) {
+			fields[field] = redactedValue
+		}
+	}
+
+	raw, err = json.Marshal(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, fields, nil
+}
+
+func isSensitive(field string) bool {
+	field = strings.ToLower(field)
+	for _, s := range sensitiveFields {
+		if strings.Contains(field, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate 按字符截断字符串
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"encoding/json"
+	"testing"
+
+	auditModel "github.com/dysodeng/app/internal/domain/audit/model"
+)
+
+func TestSnapshotRedactsAndDiffs(t *testing.T) {
+	type subscription struct {
+		Name   string `json:"name"`
+		Secret string `json:"secret"`
+		Status uint8  `json:"status"`
+	}
+
+	beforeRaw, before, err := snapshot(subscription{Name: "a", Secret: "s1", Status: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, after, err := snapshot(subscription{Name: "b", Secret: "s2", Status: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err = json.Unmarshal(beforeRaw, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["secret"] != redactedValue {
+		t.Fatalf("secret not redacted: %s", beforeRaw)
+	}
+
+	changes := auditModel.Diff(before, after)
+	if len(changes) != 1 || changes[0].Field != "name" || changes[0].Before != "a" || changes[0].After != "b" {
+		t.Fatalf("changes = %+v, want only name", changes)
+	}
+
+	if raw, fields, err := snapshot(nil); err != nil || raw != nil || fields != nil {
+		t.Fatalf("nil snapshot = %s, %v, %v", raw, fields, err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("abc", 5); got != "abc" {
+		t.Fatalf("truncate short = %q", got)
+	}
+	if got := truncate("审计日志客户端", 4); got != "审计日志" {
+		t.Fatalf("truncate runes = %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package shared
+
+import (
+	"context"
+
+	domainPort "github.com/dysodeng/app/internal/domain/shared/port"
+	persistCache "github.com/dysodeng/app/internal/infrastructure/persistence/cache"
+)
+
+// CacheInspectorAdapter 缓存诊断端口适配器
+type CacheInspectorAdapter struct{}
+
+func NewCacheInspectorAdapter() domainPort.CacheInspector {
+	return &CacheInspectorAdapter{}
+}
+
+func (a *CacheInspectorAdapter) Namespaces(_ context.Context) []string {
+	return persistCache.Namespaces()
+}
+
+func (a *CacheInspectorAdapter) Inspect(ctx context.Context, namespace, base string, tags ...string) (*domainPort.CacheKeyInfo, error) {
+	inspector, ok := persistCache.Lookup(namespace)
+	if !ok {
+		return nil, domainPort.ErrCacheNamespaceNotFound
+	}
+	info, err := inspector.Inspect(ctx, base, tags...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domainPort.CacheKeyInfo{
+		Namespace:    info.Namespace,
+		Key:          info.Key,
+		Tags:         make([]domainPort.CacheTagVersion, len(info.Tags)),
+		Exists:       info.Exists,
+		Negative:     info.Negative,
+		Fresh:        info.Fresh,
+		ExpireAt:     info.ExpireAt,
+		LoadDuration: info.LoadDuration,
+		Size:         info.Size,
+	}
+	for i, tv := range info.Tags {
+		res.Tags[i] = domainPort.CacheTagVersion{Tag: tv.Tag, Version: tv.Version}
+	}
+	return res, nil
+}
+
+func (a *CacheInspectorAdapter) InvalidateTags(ctx context.Context, namespace string, tags ...string) error {
+	inspector, ok := persistCache.Lookup(namespace)
+	if !ok {
+		return domainPort.ErrCacheNamespaceNotFound
+	}
+	return inspector.InvalidateTags(ctx, tags...)
+}
+
+func (a *CacheInspectorAdapter) Purge(ctx context.Context, namespace string) error {
+	inspector, ok := persistCache.Lookup(namespace)
+	if !ok {
+		return domainPort.ErrCacheNamespaceNotFound
+	}
+	return inspector.Purge(ctx)
+}
@@ -0,0 +1,82 @@
+package shared
+
+import (
+	"context"
+	"errors"
+
+	domainPort "github.com/dysodeng/app/internal/domain/shared/port"
+	infraEvent "github.com/dysodeng/app/internal/infrastructure/event"
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+)
+
+// DeadLetterQueueAdapter 事件死信队列适配器
+type DeadLetterQueueAdapter struct {
+	queue *infraEvent.DeadLetterQueue
+}
+
+func NewDeadLetterQueueAdapter(queue *infraEvent.DeadLetterQueue) domainPort.DeadLetterQueue {
+	return &DeadLetterQueueAdapter{queue: queue}
+}
+
+func (a *DeadLetterQueueAdapter) List(ctx context.Context, filter domainPort.DeadLetterFilter) ([]domainPort.DeadLetter, int64, error) {
+	records, total, err := a.queue.List(ctx, infraEvent.DeadLetterQuery{
+		Status:    filter.Status,
+		EventType: filter.EventType,
+		Handler:   filter.Handler,
+		Page:      filter.Page,
+		PageSize:  filter.PageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]domainPort.DeadLetter, len(records))
+	for i := range records {
+		list[i] = toDeadLetter(&records[i])
+	}
+	return list, total, nil
+}
+
+func (a *DeadLetterQueueAdapter) Find(ctx context.Context, id uint64) (*domainPort.DeadLetter, error) {
+	record, err := a.queue.Find(ctx, id)
+	if err != nil {
+		return nil, translateDeadLetterError(err)
+	}
+	deadLetter := toDeadLetter(record)
+	return &deadLetter, nil
+}
+
+func (a *DeadLetterQueueAdapter) Replay(ctx context.Context, id uint64) error {
+	return translateDeadLetterError(a.queue.Replay(ctx, id))
+}
+
+func (a *DeadLetterQueueAdapter) Discard(ctx context.Context, id uint64) error {
+	return translateDeadLetterError(a.queue.Discard(ctx, id))
+}
+
+func toDeadLetter(record *eventEntity.DeadLetter) domainPort.DeadLetter {
+	return domainPort.DeadLetter{
+		ID:        record.ID,
+		EventID:   record.EventID,
+		EventType: record.EventType,
+		Handler:   record.Handler,
+		Payload:   record.Payload,
+		Error:     record.Error,
+		Attempts:  record.Attempts,
+		Status:    record.Status,
+		CreatedAt: record.CreatedAt.Time,
+		UpdatedAt: record.UpdatedAt.Time,
+	}
+}
+
+// translateDeadLetterError 转换为端口定义的错误
+func translateDeadLetterError(err error) error {
+	switch {
+	case errors.Is(err, infraEvent.ErrDeadLetterNotFound):
+		return domainPort.ErrDeadLetterNotFound
+	case errors.Is(err, infraEvent.ErrDeadLetterNotPending):
+		return domainPort.ErrDeadLetterNotPending
+	default:
+		return err
+	}
+}
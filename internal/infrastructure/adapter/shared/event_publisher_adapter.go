@@ -2,22 +2,82 @@ package shared
 
 import (
 	"context"
+	"time"
 
 	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
 	domainPort "github.com/dysodeng/app/internal/domain/shared/port"
 	infraEvent "github.com/dysodeng/app/internal/infrastructure/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
 )
 
+// EventPublisherAdapterOption 事件发布器适配器选项
+type EventPublisherAdapterOption func(a *EventPublisherAdapter)
+
+// WithPublishAfterCommit 在事务中发布时延迟到事务提交后发布，发布失败仅记录日志
+// 用于不参与业务事务的事件总线(直接投递MQ)，避免事务回滚后投递出幽灵事件，或投递失败导致业务回滚
+func WithPublishAfterCommit() EventPublisherAdapterOption {
+	return func(a *EventPublisherAdapter) {
+		a.afterCommit = true
+	}
+}
+
 // EventPublisherAdapter 事件发布器适配器
 type EventPublisherAdapter struct {
-	bus infraEvent.Bus
+	bus         infraEvent.Bus
+	afterCommit bool
 }
 
-func NewEventPublisherAdapter(bus infraEvent.Bus) domainPort.EventPublisher {
-	return &EventPublisherAdapter{bus: bus}
+func NewEventPublisherAdapter(bus infraEvent.Bus, opts ...EventPublisherAdapterOption) domainPort.EventPublisher {
+	a := &EventPublisherAdapter{bus: bus}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 func (a *EventPublisherAdapter) Publish(ctx context.Context, e domainEvent.DomainEvent[any]) error {
-	// 转换为基础设施领域事件
-	return a.bus.PublishEvent(ctx, infraEvent.NewDomainEvent(e.Type, e.AggregateID, e.AggregateName, e.Payload))
+	evt := a.toInfraEvent(ctx, e)
+	if a.afterCommit && transactions.AfterCommit(ctx, func(ctx context.Context) {
+		if err := a.bus.PublishEvent(ctx, evt); err != nil {
+			logger.Error(
+				ctx,
+				"事务提交后发布事件失败",
+				logger.AddField("eventID", evt.ID),
+				logger.AddField("eventType", evt.Type),
+				logger.ErrorField(err),
+			)
+		}
+	}) {
+		return nil
+	}
+	return a.bus.PublishEvent(ctx, evt)
+}
+
+func (a *EventPublisherAdapter) PublishEventAt(ctx context.Context, e domainEvent.DomainEvent[any], at time.Time) error {
+	return a.bus.PublishEventAt(ctx, a.toInfraEvent(ctx, e), at)
+}
+
+func (a *EventPublisherAdapter) PublishEventAfter(ctx context.Context, e domainEvent.DomainEvent[any], delay time.Duration) error {
+	return a.bus.PublishEventAfter(ctx, a.toInfraEvent(ctx, e), delay)
+}
+
+// toInfraEvent 转换为基础设施领域事件，保留领域事件ID、版本、发生时间与所属租户
+func (a *EventPublisherAdapter) toInfraEvent(ctx context.Context, e domainEvent.DomainEvent[any]) infraEvent.BaseDomainEvent[any] {
+	evt := infraEvent.NewDomainEvent(e.Type, e.AggregateID, e.AggregateName, e.Payload).(infraEvent.BaseDomainEvent[any])
+	if e.ID != "" {
+		evt.ID = e.ID
+	}
+	if e.Version > 0 {
+		evt.Version = e.Version
+	}
+	if !e.OccurredAt.IsZero() {
+		evt.Timestamp = e.OccurredAt
+	}
+	evt.Tenant = e.TenantID
+	if evt.Tenant == "" {
+		evt.Tenant, _ = tenant.FromContext(ctx)
+	}
+	return evt
 }
@@ -0,0 +1,43 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+	"github.com/dysodeng/app/internal/infrastructure/event/eventtest"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+)
+
+func TestPublishAfterCommit(t *testing.T) {
+	bus := eventtest.NewBus(t)
+	publisher := NewEventPublisherAdapter(bus, WithPublishAfterCommit())
+	tx := transactions.NewGormTransactionManager(dbtest.Open(t))
+	evt := domainEvent.DomainEvent[any]{Type: "file.uploaded", AggregateID: "1", AggregateName: "file"}
+
+	err := tx.Transaction(context.Background(), func(txCtx context.Context) error {
+		if err := publisher.Publish(txCtx, evt); err != nil {
+			return err
+		}
+		eventtest.AssertNotPublished(t, bus, "file.uploaded")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventtest.AssertPublishedCount(t, bus, "file.uploaded", 1)
+
+	_ = tx.Transaction(context.Background(), func(txCtx context.Context) error {
+		_ = publisher.Publish(txCtx, evt)
+		return errors.New("rollback")
+	})
+	eventtest.AssertPublishedCount(t, bus, "file.uploaded", 1)
+
+	// 不在事务中时立即发布
+	if err = publisher.Publish(context.Background(), evt); err != nil {
+		t.Fatal(err)
+	}
+	eventtest.AssertPublishedCount(t, bus, "file.uploaded", 2)
+}
@@ -16,6 +16,21 @@ func NewTransactionManagerAdapter(tx infraTx.TransactionManager) domainPort.Tran
 	return &TransactionManagerAdapter{tx: tx}
 }
 
-func (a *TransactionManagerAdapter) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return a.tx.Transaction(ctx, fn)
+func (a *TransactionManagerAdapter) Transaction(ctx context.Context, fn func(ctx context.Context) error, opts ...domainPort.TransactionOption) error {
+	if len(opts) == 0 {
+		return a.tx.Transaction(ctx, fn)
+	}
+
+	options := &domainPort.TransactionOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	txOpts := []infraTx.TransactionOption{
+		infraTx.WithIsolation(options.Isolation),
+		infraTx.WithRetry(options.MaxRetries, options.RetryBackoff),
+	}
+	if options.ReadOnly {
+		txOpts = append(txOpts, infraTx.WithReadOnly())
+	}
+	return a.tx.Transaction(ctx, fn, txOpts...)
 }
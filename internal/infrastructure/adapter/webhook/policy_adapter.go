@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"time"
+
+	domainPort "github.com/dysodeng/app/internal/domain/webhook/port"
+	infraConfig "github.com/dysodeng/app/internal/infrastructure/config"
+)
+
+// PolicyAdapter Webhook投递策略端口适配器
+type PolicyAdapter struct {
+	cfg infraConfig.EventWebhookConfig
+}
+
+func NewDeliveryPolicyAdapter(cfg infraConfig.EventWebhookConfig) domainPort.DeliveryPolicy {
+	return &PolicyAdapter{cfg: cfg}
+}
+
+func (a *PolicyAdapter) MaxAttempts() int {
+	if a.cfg.MaxAttempts <= 0 {
+		return 1
+	}
+	return a.cfg.MaxAttempts
+}
+
+// RetryDelay 指数退避：retry_interval * 2^(attempts-1)，不超过 max_retry_interval
+func (a *PolicyAdapter) RetryDelay(attempts int) time.Duration {
+	delay := a.cfg.RetryInterval
+	if delay <= 0 {
+		delay = time.Second
+	}
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if a.cfg.MaxRetryInterval > 0 && delay >= a.cfg.MaxRetryInterval {
+			return a.cfg.MaxRetryInterval
+		}
+	}
+	if a.cfg.MaxRetryInterval > 0 && delay > a.cfg.MaxRetryInterval {
+		return a.cfg.MaxRetryInterval
+	}
+	return delay
+}
+
+func (a *PolicyAdapter) DisableThreshold() int {
+	return a.cfg.DisableThreshold
+}
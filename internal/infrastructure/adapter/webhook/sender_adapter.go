@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	domainPort "github.com/dysodeng/app/internal/domain/webhook/port"
+	"github.com/dysodeng/app/internal/domain/webhook/valueobject"
+	"github.com/dysodeng/app/internal/infrastructure/shared/request"
+)
+
+// SenderAdapter Webhook请求发送端口适配器
+type SenderAdapter struct {
+	timeout time.Duration
+	client  *http.Client
+}
+
+func NewWebhookSenderAdapter(timeout time.Duration) domainPort.WebhookSender {
+	return &SenderAdapter{timeout: timeout, client: newGuardedClient(valueobject.IsPublicAddr)}
+}
+
+// newGuardedClient 创建校验目标地址的HTTP客户端
+// 在建立连接时校验域名解析后的实际地址(含重定向)，防止DNS重绑定绕过订阅地址校验；不使用环境变量代理
+func newGuardedClient(allow func(addr netip.Addr) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !allow(addr) {
+				return fmt.Errorf("webhook address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
+
+func (a *SenderAdapter) Send(ctx context.Context, url string, headers map[string]string, body []byte) (*domainPort.SendResult, error) {
+	opts := []request.Option{
+		request.WithContext(ctx),
+		request.WithClient(a.client),
+		request.WithHeader("Content-Type", "application/json"),
+	}
+	if a.timeout > 0 {
+		opts = append(opts, request.WithTimeout(a.timeout))
+	}
+	for key, value := range headers {
+		opts = append(opts, request.WithHeader(key, value))
+	}
+
+	resBody, statusCode, err := request.Request(url, http.MethodPost, bytes.NewReader(body), opts...)
+	result := &domainPort.SendResult{StatusCode: statusCode, Body: resBody}
+	// request 仅将200/201视为成功，Webhook接收方返回任意2xx均视为投递成功
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return result, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("unexpected status code %d", statusCode)
+	} else if statusCode > 0 {
+		err = fmt.Errorf("unexpected status code %d: %w", statusCode, err)
+	}
+	return result, err
+}
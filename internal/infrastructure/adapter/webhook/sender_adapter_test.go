@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSenderRejectsPrivateAddress(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// 域名解析到回环地址(DNS重绑定)在建立连接时拒绝
+	sender := NewWebhookSenderAdapter(time.Second)
+	port := server.URL[strings.LastIndex(server.URL, ":"):]
+	for _, url := range []string{server.URL, "http://localhost" + port} {
+		if _, err := sender.Send(context.Background(), url, nil, []byte(`{}`)); err == nil || !strings.Contains(err.Error(), "not allowed") {
+			t.Fatalf("send %s err = %v", url, err)
+		}
+	}
+	if calls != 0 {
+		t.Fatalf("server received %d requests", calls)
+	}
+
+	// 校验通过的地址正常投递
+	allowed := &SenderAdapter{timeout: time.Second, client: newGuardedClient(func(netip.Addr) bool { return true })}
+	result, err := allowed.Send(context.Background(), server.URL, nil, []byte(`{}`))
+	if err != nil || result.StatusCode != http.StatusOK || calls != 1 {
+		t.Fatalf("result = %+v, err = %v, calls = %d", result, err, calls)
+	}
+}
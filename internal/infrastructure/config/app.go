@@ -16,6 +16,17 @@ type AppConfig struct {
 	Environment string `mapstructure:"environment"`
 	Debug       bool   `mapstructure:"debug"`
 	Domain      string `mapstructure:"domain"`
+	LogLevel    string `mapstructure:"log_level"` // 日志级别 debug、info、warn、error，为空时调试模式为debug否则为info，支持热更新
+	Tenant      Tenant `mapstructure:"tenant"`
+}
+
+// Tenant 多租户配置
+// 按 token 声明、请求头、子域名的优先级解析租户；未开启时为单租户模式，数据不做租户隔离
+type Tenant struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	Header   string `mapstructure:"header"`   // 租户请求头
+	Domain   string `mapstructure:"domain"`   // 子域名解析的基础域名，如 example.com 时 t1.example.com 解析为 t1，为空不按子域名解析
+	Required bool   `mapstructure:"required"` // 未解析到租户时拒绝请求
 }
 
 // Security 安全配置
@@ -23,6 +34,19 @@ type Security struct {
 	JWT struct {
 		Secret string `mapstructure:"secret"`
 	} `mapstructure:"jwt"`
+	Encryption Encryption `mapstructure:"encryption"`
+}
+
+// Encryption 敏感字段加密(AES-GCM 信封加密)
+// 每个值使用随机数据密钥加密，数据密钥由主密钥加密后与密文一同存储
+type Encryption struct {
+	Enabled bool `mapstructure:"enabled"`
+	// PrimaryKey 用于加密的主密钥ID
+	PrimaryKey string `mapstructure:"primary_key"`
+	// Keys 主密钥列表，格式 id:base64密钥，逗号分隔；轮换时新增密钥并切换 PrimaryKey，执行 pii:backfill 后再移除旧密钥
+	Keys string `mapstructure:"keys"`
+	// IndexKey 盲索引HMAC密钥(base64)，更换后需执行 pii:backfill 重建索引
+	IndexKey string `mapstructure:"index_key"`
 }
 
 func appBindEnv(v *viper.Viper) {
@@ -30,9 +54,15 @@ func appBindEnv(v *viper.Viper) {
 	_ = v.BindEnv("environment", "APP_ENV")
 	_ = v.BindEnv("debug", "APP_DEBUG")
 	_ = v.BindEnv("domain", "APP_DOMAIN")
+	_ = v.BindEnv("log_level", "APP_LOG_LEVEL")
 	v.SetDefault("environment", Dev)
+	v.SetDefault("tenant.header", "X-Tenant-ID")
 }
 
 func securityBindEnv(v *viper.Viper) {
 	_ = v.BindEnv("jwt.secret", "SECURITY_JWT_SECRET")
+	_ = v.BindEnv("encryption.enabled", "SECURITY_ENCRYPTION_ENABLED")
+	_ = v.BindEnv("encryption.primary_key", "SECURITY_ENCRYPTION_PRIMARY_KEY")
+	_ = v.BindEnv("encryption.keys", "SECURITY_ENCRYPTION_KEYS")
+	_ = v.BindEnv("encryption.index_key", "SECURITY_ENCRYPTION_INDEX_KEY")
 }
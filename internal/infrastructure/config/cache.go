@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -8,9 +10,72 @@ import (
 type Cache struct {
 	Driver     string `mapstructure:"driver"`
 	Serializer string `mapstructure:"serializer"`
+	// NegativeTTL 空值缓存TTL，防止不存在的数据反复穿透到数据库，<=0 关闭
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+	Stampede    CacheStampede `mapstructure:"stampede"`
+	Layered     CacheLayered  `mapstructure:"layered"`
+	// Compression 压缩，对所有命名空间生效
+	Compression CacheCompression `mapstructure:"compression"`
+	// Encryption 加密，仅对指定命名空间生效
+	Encryption CacheEncryption `mapstructure:"encryption"`
+}
+
+// CacheCompression 缓存数据压缩
+type CacheCompression struct {
+	// Algorithm 压缩算法 none、zstd 或 snappy
+	Algorithm string `mapstructure:"algorithm"`
+	// Threshold 序列化后不小于该字节数时才压缩
+	Threshold int `mapstructure:"threshold"`
+}
+
+// CacheEncryption 缓存数据加密(AES-GCM)
+type CacheEncryption struct {
+	Enabled bool `mapstructure:"enabled"`
+	// PrimaryKey 用于加密的密钥ID
+	PrimaryKey string `mapstructure:"primary_key"`
+	// Keys 密钥列表，格式 id:base64密钥，逗号分隔；轮换时新增密钥并切换 PrimaryKey，旧密钥保留至缓存过期
+	Keys string `mapstructure:"keys"`
+	// Namespaces 需要加密的缓存命名空间
+	Namespaces []string `mapstructure:"namespaces"`
+}
+
+// CacheLayered 两级缓存(driver=layered)
+type CacheLayered struct {
+	// MaxEntries L1 进程内缓存最大条目数
+	MaxEntries int `mapstructure:"max_entries"`
+	// L1TTL L1 最长驻留时间，兜底跨节点失效消息丢失
+	L1TTL time.Duration `mapstructure:"l1_ttl"`
+}
+
+// CacheStampede 缓存击穿/雪崩防护
+type CacheStampede struct {
+	// Beta XFetch 概率提前过期系数，<=0 关闭，越大越倾向于提前刷新
+	Beta float64 `mapstructure:"beta"`
+	// StaleTTL 过期后仍可返回旧值的时间窗口(stale-while-revalidate)，<=0 关闭
+	StaleTTL time.Duration `mapstructure:"stale_ttl"`
+	// Lock 是否在加载数据时使用分布式锁(仅redis驱动生效)
+	Lock bool `mapstructure:"lock"`
+	// LockTTL 分布式锁过期时间
+	LockTTL time.Duration `mapstructure:"lock_ttl"`
+	// LockWait 未抢到锁时等待其他节点回写缓存的最长时间
+	LockWait time.Duration `mapstructure:"lock_wait"`
 }
 
 func cacheBindEnv(d *viper.Viper) {
 	d.SetDefault("driver", "memory")
 	d.SetDefault("serializer", "json")
+	d.SetDefault("negative_ttl", 30*time.Second)
+	d.SetDefault("stampede.beta", 1.0)
+	d.SetDefault("stampede.stale_ttl", 0)
+	d.SetDefault("stampede.lock", false)
+	d.SetDefault("stampede.lock_ttl", 10*time.Second)
+	d.SetDefault("stampede.lock_wait", 3*time.Second)
+	d.SetDefault("layered.max_entries", 10000)
+	d.SetDefault("layered.l1_ttl", time.Minute)
+	d.SetDefault("compression.algorithm", "none")
+	d.SetDefault("compression.threshold", 1024)
+	d.SetDefault("encryption.enabled", false)
+	_ = d.BindEnv("encryption.enabled", "CACHE_ENCRYPTION_ENABLED")
+	_ = d.BindEnv("encryption.primary_key", "CACHE_ENCRYPTION_PRIMARY_KEY")
+	_ = d.BindEnv("encryption.keys", "CACHE_ENCRYPTION_KEYS")
 }
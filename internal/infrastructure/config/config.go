@@ -11,6 +11,7 @@ const (
 	TempPath        = VarPath + "/tmp"
 )
 
+// GlobalConfig 启动时加载的配置，重载后不再变化；支持热更新的配置项需通过 Current 或 Subscribe 读取
 var GlobalConfig *Config
 
 // Config 应用配置
@@ -33,6 +34,23 @@ func LoadConfig(configPath string) (*Config, error) {
 	// 加载.env
 	_ = godotenv.Load()
 
+	config, err := readConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = config.Validate(); err != nil {
+		return nil, err
+	}
+
+	GlobalConfig = config
+	loadedPath = configPath
+	current.Store(config)
+
+	return config, nil
+}
+
+// readConfig 读取配置文件
+func readConfig(configPath string) (*Config, error) {
 	v := viper.New()
 
 	v.SetConfigFile(configPath)
@@ -42,6 +60,21 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, err
 	}
 
+	src, namespace, err := remoteSource(v)
+	if err != nil {
+		return nil, err
+	}
+	if src.Enabled {
+		if v, err = mergeRemote(configPath, src, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	return decode(v)
+}
+
+// decode 解析配置，各配置节绑定环境变量与默认值
+func decode(v *viper.Viper) (*Config, error) {
 	var appConfig AppConfig
 	app := v.Sub("app")
 	appBindEnv(app)
@@ -101,7 +134,7 @@ func LoadConfig(configPath string) (*Config, error) {
 	var storageConfig Storage
 	storage := v.Sub("storage")
 	storageBindEnv(storage)
-	if err := storage.Unmarshal(&storageConfig); err != nil {
+	if err := storage.Unmarshal(&storageConfig, viper.DecodeHook(byteSizeDecodeHook())); err != nil {
 		return nil, err
 	}
 
@@ -133,7 +166,5 @@ func LoadConfig(configPath string) (*Config, error) {
 		ThirdParty:   thirdPartyConfig,
 	}
 
-	GlobalConfig = &config
-
 	return &config, nil
 }
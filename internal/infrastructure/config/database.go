@@ -18,6 +18,25 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// Replicas 只读从库，事务外的读请求轮询路由到健康的从库
+	Replicas []DatabaseReplica `mapstructure:"replicas"`
+	// ReplicaHealth 从库健康检查
+	ReplicaHealth ReplicaHealth `mapstructure:"replica_health"`
+}
+
+// DatabaseReplica 只读从库，账号密码为空时沿用主库配置
+type DatabaseReplica struct {
+	Name     string `mapstructure:"name"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// ReplicaHealth 从库健康检查，检查失败的从库移出读轮询，恢复后自动加入
+type ReplicaHealth struct {
+	Interval time.Duration `mapstructure:"interval"`
+	Timeout  time.Duration `mapstructure:"timeout"`
 }
 
 type Migration struct {
@@ -33,4 +52,6 @@ func databaseBindEnv(v *viper.Viper) {
 	_ = v.BindEnv("tracer_enable", "MAIN_DB_TRACER_ENABLE")
 	v.SetDefault("host", "127.0.0.1")
 	v.SetDefault("port", "3306")
+	v.SetDefault("replica_health.interval", 10*time.Second)
+	v.SetDefault("replica_health.timeout", 3*time.Second)
 }
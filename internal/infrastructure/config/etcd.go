@@ -1,11 +1,14 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Etcd struct {
-	GRPC etcdItem `mapstructure:"grpc"`
+	GRPC   etcdItem         `mapstructure:"grpc"`
+	Config EtcdConfigSource `mapstructure:"config"`
 }
 
 type etcdItem struct {
@@ -15,10 +18,28 @@ type etcdItem struct {
 	TLS      bool   `mapstructure:"tls"`
 }
 
+// EtcdConfigSource etcd 远程配置源，优先级低于本地配置文件与环境变量
+// 连接配置只从本地配置文件与环境变量读取，键按 <prefix>/<app.name>/<app.environment>/ 划分命名空间
+type EtcdConfigSource struct {
+	etcdItem    `mapstructure:",squash"`
+	Enabled     bool          `mapstructure:"enabled"`
+	Prefix      string        `mapstructure:"prefix"`
+	DialTimeout time.Duration `mapstructure:"dial_timeout"`
+}
+
 func etcdBindEnv(d *viper.Viper) {
 	_ = d.BindEnv("grpc.addr", "GRPC_ETCD_ADDR")
 	_ = d.BindEnv("grpc.username", "GRPC_ETCD_USERNAME")
 	_ = d.BindEnv("grpc.password", "GRPC_ETCD_PASSWORD")
 	_ = d.BindEnv("grpc.tls", "GRPC_ETCD_TLS")
 	d.SetDefault("grpc.addr", "127.0.0.1:2379")
+	_ = d.BindEnv("config.enabled", "CONFIG_ETCD_ENABLED")
+	_ = d.BindEnv("config.addr", "CONFIG_ETCD_ADDR")
+	_ = d.BindEnv("config.username", "CONFIG_ETCD_USERNAME")
+	_ = d.BindEnv("config.password", "CONFIG_ETCD_PASSWORD")
+	_ = d.BindEnv("config.tls", "CONFIG_ETCD_TLS")
+	_ = d.BindEnv("config.prefix", "CONFIG_ETCD_PREFIX")
+	d.SetDefault("config.addr", "127.0.0.1:2379")
+	d.SetDefault("config.prefix", "/config")
+	d.SetDefault("config.dial_timeout", 5*time.Second)
 }
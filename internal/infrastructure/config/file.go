@@ -1,6 +1,12 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
 
 type ByteSize int64
 
@@ -23,6 +29,36 @@ func (b ByteSize) ToInt() int64 {
 	return int64(b)
 }
 
+// UnmarshalText 解析容量配置，如 5MB、512KB，无单位时为字节
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	value := strings.ToUpper(strings.TrimSpace(string(text)))
+	unit := B
+	for _, u := range []struct {
+		suffix string
+		size   ByteSize
+	}{{"TB", TB}, {"GB", GB}, {"MB", MB}, {"KB", KB}, {"B", B}} {
+		if v, ok := strings.CutSuffix(value, u.suffix); ok {
+			value, unit = strings.TrimSpace(v), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid byte size %q", text)
+	}
+	*b = ByteSize(n * float64(unit))
+	return nil
+}
+
+// byteSizeDecodeHook 在viper默认解析之外支持 ByteSize 文本配置
+func byteSizeDecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.TextUnmarshallerHookFunc(),
+	)
+}
+
 const (
 	B ByteSize = 1 << (10 * iota)
 	KB
@@ -33,9 +69,9 @@ const (
 
 type FileAllow struct {
 	// 允许上传的文件类型
-	AllowMimeType []string
+	AllowMimeType []string `mapstructure:"mime_types"`
 	// 允许上传的文件容量大小(单位：字节)
-	AllowCapacitySize ByteSize
+	AllowCapacitySize ByteSize `mapstructure:"max_size"`
 }
 
 // UserFileAllow 终端用户上传限制
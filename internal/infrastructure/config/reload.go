@@ -0,0 +1,181 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+var (
+	current    atomic.Pointer[Config]
+	loadedPath string
+	// remoteRevision 最近一次读取远程配置时的 etcd 修订号，监听从其后开始，不遗漏读取与监听之间的变更
+	remoteRevision atomic.Int64
+
+	// reloadMu 串行化重载，保证订阅者按配置生效顺序收到通知
+	reloadMu sync.Mutex
+
+	subscribersMu sync.RWMutex
+	subscribers   []*subscriber
+)
+
+type subscriber struct {
+	section string
+	fn      func(old, new *Config)
+}
+
+// Current 当前生效的配置，重载后原子替换
+func Current() *Config {
+	return current.Load()
+}
+
+// Subscribe 订阅配置节变更，section 为配置键路径，如 app.log_level、server.http.cors，为空时订阅全部变更
+// 重载后该配置节有变化时按订阅顺序同步回调，返回取消订阅函数
+func Subscribe(section string, fn func(old, new *Config)) (unsubscribe func()) {
+	sub := &subscriber{section: section, fn: fn}
+	subscribersMu.Lock()
+	subscribers = append(subscribers, sub)
+	subscribersMu.Unlock()
+
+	return func() {
+		subscribersMu.Lock()
+		defer subscribersMu.Unlock()
+		for i, s := range subscribers {
+			if s == sub {
+				subscribers = append(subscribers[:i:i], subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Reload 重新读取配置文件，校验通过后原子替换当前配置并通知订阅者
+// 返回发生变化的顶层配置节，读取或校验失败时保留原配置
+func Reload() ([]string, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	next, err := readConfig(loadedPath)
+	if err != nil {
+		return nil, fmt.Errorf("config reload: %w", err)
+	}
+	return apply(next)
+}
+
+// apply 校验并替换当前配置，调用方需持有 reloadMu
+func apply(next *Config) ([]string, error) {
+	if err := next.Validate(); err != nil {
+		return nil, fmt.Errorf("config reload rejected: %w", err)
+	}
+
+	prev := current.Swap(next)
+	if prev == nil {
+		return nil, nil
+	}
+	changed := changedSections(prev, next)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	subscribersMu.RLock()
+	subs := append([]*subscriber(nil), subscribers...)
+	subscribersMu.RUnlock()
+	for _, sub := range subs {
+		if sectionChanged(prev, next, sub.section) {
+			sub.fn(prev, next)
+		}
+	}
+	return changed, nil
+}
+
+// Watch 监听配置文件、远程配置变更与 SIGHUP 信号并重载配置，ctx 取消后不再重载
+// onReload 在每次重载后回调，changed 为空表示配置无变化；远程配置源的连接配置变更需重启生效
+func Watch(ctx context.Context, onReload func(changed []string, err error)) {
+	if src, namespace, err := LoadRemoteSource(loadedPath); err != nil {
+		onReload(nil, err)
+	} else if src.Enabled {
+		go watchRemote(ctx, src, namespace, remoteRevision.Load(), func() {
+			if ctx.Err() == nil {
+				onReload(Reload())
+			}
+		}, func(err error) {
+			onReload(nil, err)
+		})
+	}
+
+	v := viper.New()
+	v.SetConfigFile(loadedPath)
+	v.OnConfigChange(func(fsnotify.Event) {
+		if ctx.Err() != nil {
+			return
+		}
+		onReload(Reload())
+	})
+	v.WatchConfig()
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				onReload(Reload())
+			}
+		}
+	}()
+}
+
+// changedSections 比较两份配置，返回发生变化的顶层配置节
+func changedSections(prev, next *Config) []string {
+	var changed []string
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		section := t.Field(i).Tag.Get("mapstructure")
+		if sectionChanged(prev, next, section) {
+			changed = append(changed, section)
+		}
+	}
+	return changed
+}
+
+// sectionChanged 按 mapstructure 键路径比较配置节，路径不存在时视为整体比较
+func sectionChanged(prev, next *Config, section string) bool {
+	a, b := reflect.ValueOf(*prev), reflect.ValueOf(*next)
+	if section != "" {
+		for _, key := range strings.Split(section, ".") {
+			a, b = fieldByKey(a, key), fieldByKey(b, key)
+			if !a.IsValid() || !b.IsValid() {
+				break
+			}
+		}
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return !reflect.DeepEqual(*prev, *next)
+	}
+	return !reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func fieldByKey(v reflect.Value, key string) reflect.Value {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("mapstructure") == key {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	base, err := os.ReadFile("../../../configs/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, string(base))
+
+	initial, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var levels, all []string
+	unsubscribe := Subscribe("app.log_level", func(old, new *Config) {
+		levels = append(levels, old.App.LogLevel+"->"+new.App.LogLevel)
+	})
+	defer unsubscribe()
+	defer Subscribe("", func(_, new *Config) { all = append(all, new.App.LogLevel) })()
+
+	// 无变化时不通知
+	if changed, err := Reload(); err != nil || len(changed) != 0 {
+		t.Fatalf("unchanged reload got %v, %v", changed, err)
+	}
+
+	writeConfig(t, path, strings.Replace(string(base), `log_level: ""`, `log_level: "warn"`, 1))
+	changed, err := Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(changed, []string{"app"}) || !slices.Equal(levels, []string{"->warn"}) || len(all) != 1 {
+		t.Fatalf("changed=%v levels=%v all=%v", changed, levels, all)
+	}
+	if Current().App.LogLevel != "warn" || GlobalConfig != initial {
+		t.Fatal("current config should be swapped while GlobalConfig keeps the startup snapshot")
+	}
+
+	// 其他配置节变化不通知 app.log_level 订阅者
+	writeConfig(t, path, strings.Replace(strings.Replace(string(base), `log_level: ""`, `log_level: "warn"`, 1), `- "*"`, `- "https://example.com"`, 1))
+	if changed, err = Reload(); err != nil || !slices.Equal(changed, []string{"server"}) || len(levels) != 1 || len(all) != 2 {
+		t.Fatalf("changed=%v err=%v levels=%v all=%v", changed, err, levels, all)
+	}
+
+	// 校验失败时保留原配置
+	writeConfig(t, path, strings.Replace(string(base), `log_level: ""`, `log_level: "verbose"`, 1))
+	if _, err = Reload(); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if Current().App.LogLevel != "warn" || len(levels) != 1 {
+		t.Fatal("rejected reload should keep the previous config")
+	}
+}
+
+func TestByteSizeUnmarshalText(t *testing.T) {
+	cases := map[string]ByteSize{"5MB": 5 * MB, "512 kb": 512 * KB, "1.5GB": GB + GB/2, "1024": 1024}
+	for text, want := range cases {
+		var b ByteSize
+		if err := b.UnmarshalText([]byte(text)); err != nil || b != want {
+			t.Errorf("%s: got %d, %v", text, b, err)
+		}
+	}
+	var b ByteSize
+	if err := b.UnmarshalText([]byte("5XB")); err == nil {
+		t.Error("expected error for invalid unit")
+	}
+}
@@ -0,0 +1,304 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// remoteDebounce 远程配置变更合并窗口，批量写入只触发一次重载
+	remoteDebounce = 500 * time.Millisecond
+	// maxTxnOps 单个事务的最大操作数，与 etcd 默认 --max-txn-ops 一致
+	maxTxnOps = 128
+)
+
+// RemoteNamespace 远程配置键的命名空间，以 / 结尾
+func RemoteNamespace(prefix, appName, environment string) string {
+	return path.Join("/", prefix, appName, environment) + "/"
+}
+
+// LoadRemoteSource 从本地配置文件与环境变量读取远程配置源设置与命名空间
+func LoadRemoteSource(configPath string) (EtcdConfigSource, string, error) {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.AutomaticEnv()
+	if err := v.ReadInConfig(); err != nil {
+		return EtcdConfigSource{}, "", err
+	}
+	return remoteSource(v)
+}
+
+func remoteSource(v *viper.Viper) (EtcdConfigSource, string, error) {
+	var appConfig AppConfig
+	app := v.Sub("app")
+	appBindEnv(app)
+	if err := app.Unmarshal(&appConfig); err != nil {
+		return EtcdConfigSource{}, "", err
+	}
+
+	var etcdConfig Etcd
+	etcd := v.Sub("etcd")
+	etcdBindEnv(etcd)
+	if err := etcd.Unmarshal(&etcdConfig); err != nil {
+		return EtcdConfigSource{}, "", err
+	}
+
+	src := etcdConfig.Config
+	return src, RemoteNamespace(src.Prefix, appConfig.Name, appConfig.Environment), nil
+}
+
+// NewEtcdClient 创建远程配置源的 etcd 客户端
+func NewEtcdClient(src EtcdConfigSource) (*clientv3.Client, error) {
+	cfg := clientv3.Config{
+		Endpoints:   strings.Split(src.Addr, ","),
+		Username:    src.Username,
+		Password:    src.Password,
+		DialTimeout: src.DialTimeout,
+	}
+	if src.TLS {
+		cfg.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return clientv3.New(cfg)
+}
+
+// mergeRemote 读取远程配置并以本地配置文件覆盖，环境变量在解析各配置节时绑定，优先级最高
+func mergeRemote(configPath string, src EtcdConfigSource, namespace string) (*viper.Viper, error) {
+	cli, err := NewEtcdClient(src)
+	if err != nil {
+		return nil, fmt.Errorf("remote config: %w", err)
+	}
+	defer func() { _ = cli.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), src.DialTimeout)
+	defer cancel()
+	flat, revision, err := remoteKeys(ctx, cli, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("remote config: %w", err)
+	}
+	remoteRevision.Store(revision)
+	remote, err := unflatten(flat)
+	if err != nil {
+		return nil, fmt.Errorf("remote config: %w", err)
+	}
+
+	v := viper.New()
+	v.AutomaticEnv()
+	if err = v.MergeConfigMap(remote); err != nil {
+		return nil, err
+	}
+	v.SetConfigFile(configPath)
+	if err = v.MergeInConfig(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// watchRemote 监听远程配置命名空间，变更合并后回调
+// revision 为加载配置时读取的修订号，监听从下一修订开始，为0时从建立监听时开始
+func watchRemote(ctx context.Context, src EtcdConfigSource, namespace string, revision int64, onChange func(), onError func(error)) {
+	cli, err := NewEtcdClient(src)
+	if err != nil {
+		onError(fmt.Errorf("remote config watch: %w", err))
+		return
+	}
+	defer func() { _ = cli.Close() }()
+
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	opts := []clientv3.OpOption{clientv3.WithPrefix()}
+	if revision > 0 {
+		opts = append(opts, clientv3.WithRev(revision+1))
+	}
+	watchCh := cli.Watch(clientv3.WithRequireLeader(ctx), namespace, opts...)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case resp, ok := <-watchCh:
+			if !ok {
+				return
+			}
+			if err = resp.Err(); err != nil {
+				onError(fmt.Errorf("remote config watch: %w", err))
+				continue
+			}
+			if timer == nil {
+				timer = time.AfterFunc(remoteDebounce, onChange)
+			} else {
+				timer.Reset(remoteDebounce)
+			}
+		}
+	}
+}
+
+// RemoteKeys 读取命名空间下的全部配置键，返回 键路径 => YAML值
+func RemoteKeys(ctx context.Context, cli *clientv3.Client, namespace string) (map[string]string, error) {
+	keys, _, err := remoteKeys(ctx, cli, namespace)
+	return keys, err
+}
+
+// remoteKeys 读取命名空间下的全部配置键，并返回读取时的修订号
+func remoteKeys(ctx context.Context, cli *clientv3.Client, namespace string) (map[string]string, int64, error) {
+	resp, err := cli.Get(ctx, namespace, clientv3.WithPrefix())
+	if err != nil {
+		return nil, 0, err
+	}
+	keys := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		keys[strings.TrimPrefix(string(kv.Key), namespace)] = string(kv.Value)
+	}
+	return keys, resp.Header.Revision, nil
+}
+
+// FlattenYAML 将YAML配置展开为 键路径 => YAML值，键路径以 / 分隔，空值忽略
+func FlattenYAML(data []byte) (map[string]string, error) {
+	var root map[string]any
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]string)
+	return keys, flatten("", root, keys)
+}
+
+func flatten(prefix string, value any, keys map[string]string) error {
+	switch val := value.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		for k, item := range val {
+			key := k
+			if prefix != "" {
+				key = prefix + "/" + k
+			}
+			if err := flatten(key, item, keys); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		b, err := yaml.Marshal(val)
+		if err != nil {
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+		keys[prefix] = strings.TrimSuffix(string(b), "\n")
+		return nil
+	}
+}
+
+// unflatten 将 键路径 => YAML值 还原为嵌套配置
+func unflatten(keys map[string]string) (map[string]any, error) {
+	root := make(map[string]any)
+	for key, raw := range keys {
+		var value any
+		if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		parts := strings.Split(key, "/")
+		node := root
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+	return root, nil
+}
+
+// IsSecretKey 是否为密码、密钥类配置键
+func IsSecretKey(key string) bool {
+	name := path.Base(key)
+	return strings.Contains(name, "password") || strings.Contains(name, "secret") || name == "keys" || strings.HasSuffix(name, "_key")
+}
+
+// OmitSecrets 移除密码、密钥类配置键，返回移除的数量
+func OmitSecrets(keys map[string]string) int {
+	omitted := 0
+	for key := range keys {
+		if IsSecretKey(key) {
+			delete(keys, key)
+			omitted++
+		}
+	}
+	return omitted
+}
+
+// 远程配置变更类型
+const (
+	RemoteAdd    = "add"
+	RemoteUpdate = "update"
+	RemoteDelete = "delete"
+)
+
+// RemoteChange 本地配置与远程配置的差异
+type RemoteChange struct {
+	Op     string
+	Key    string
+	Local  string
+	Remote string
+}
+
+// DiffRemote 比较本地与远程配置键，按键排序
+func DiffRemote(local, remote map[string]string) []RemoteChange {
+	var changes []RemoteChange
+	for key, value := range local {
+		current, ok := remote[key]
+		switch {
+		case !ok:
+			changes = append(changes, RemoteChange{Op: RemoteAdd, Key: key, Local: value})
+		case current != value:
+			changes = append(changes, RemoteChange{Op: RemoteUpdate, Key: key, Local: value, Remote: current})
+		}
+	}
+	for key, value := range remote {
+		if _, ok := local[key]; !ok {
+			changes = append(changes, RemoteChange{Op: RemoteDelete, Key: key, Remote: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// PushRemote 将差异写入远程配置，prune 为 false 时不删除仅远程存在的键
+// 按 etcd 事务操作数上限分批提交，返回写入的键数
+func PushRemote(ctx context.Context, cli *clientv3.Client, namespace string, changes []RemoteChange, prune bool) (int, error) {
+	var ops []clientv3.Op
+	for _, change := range changes {
+		switch change.Op {
+		case RemoteAdd, RemoteUpdate:
+			ops = append(ops, clientv3.OpPut(namespace+change.Key, change.Local))
+		case RemoteDelete:
+			if prune {
+				ops = append(ops, clientv3.OpDelete(namespace+change.Key))
+			}
+		}
+	}
+
+	written := 0
+	for len(ops) > 0 {
+		n := min(len(ops), maxTxnOps)
+		if _, err := cli.Txn(ctx).Then(ops[:n]...).Commit(); err != nil {
+			return written, err
+		}
+		written += n
+		ops = ops[n:]
+	}
+	return written, nil
+}
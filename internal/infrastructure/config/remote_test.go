@@ -0,0 +1,175 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+)
+
+func freeURL(t *testing.T) url.URL {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+	return url.URL{Scheme: "http", Host: l.Addr().String()}
+}
+
+// startEtcd 启动嵌入式 etcd，返回客户端地址
+func startEtcd(t *testing.T) string {
+	t.Helper()
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	clientURL, peerURL := freeURL(t), freeURL(t)
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = []url.URL{clientURL}, []url.URL{clientURL}
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = []url.URL{peerURL}, []url.URL{peerURL}
+	cfg.InitialCluster = fmt.Sprintf("%s=%s", cfg.Name, peerURL.String())
+
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(e.Close)
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("embedded etcd not ready")
+	}
+	return clientURL.Host
+}
+
+func TestRemoteConfig(t *testing.T) {
+	ctx := context.Background()
+	addr := startEtcd(t)
+	t.Setenv("CONFIG_ETCD_ADDR", addr)
+
+	base, err := os.ReadFile("../../../configs/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	// 推送完整配置到远程，再次比较无差异
+	full := filepath.Join(dir, "full.yaml")
+	writeConfig(t, full, string(base))
+	src, namespace, err := LoadRemoteSource(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namespace != "/config/app/development/" {
+		t.Fatalf("unexpected namespace %q", namespace)
+	}
+	cli, err := NewEtcdClient(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cli.Close() }()
+
+	local, err := FlattenYAML(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local["server/http/port"] != "8080" || local["app/tenant/header"] != "X-Tenant-ID" {
+		t.Fatalf("unexpected flattened keys: %v", local)
+	}
+	changes := DiffRemote(local, map[string]string{"obsolete/key": "1"})
+	if written, err := PushRemote(ctx, cli, namespace, changes, false); err != nil || written != len(local) {
+		t.Fatalf("push wrote %d of %d keys: %v", written, len(local), err)
+	}
+	remote, _ := RemoteKeys(ctx, cli, namespace)
+	if diff := DiffRemote(local, remote); len(diff) != 0 {
+		t.Fatalf("expected no difference after push, got %v", diff)
+	}
+
+	// 远程配置作为底层，本地配置文件与环境变量依次覆盖
+	_, _ = cli.Put(ctx, namespace+"app/log_level", "warn")
+	_, _ = cli.Put(ctx, namespace+"server/http/port", "9999")
+	slim := strings.Replace(string(base), "  log_level: \"\" # 日志级别 debug、info、warn、error，为空时按调试模式取默认值\n", "", 1)
+	slim = strings.Replace(slim, "etcd:\n  grpc:\n    addr: 127.0.0.1:2379\n  # 远程配置源，优先级低于本地配置文件与环境变量\n  config:\n    enabled: false", "etcd:\n  grpc:\n    addr: 127.0.0.1:2379\n  config:\n    enabled: true", 1)
+	path := filepath.Join(dir, "config.yaml")
+	writeConfig(t, path, slim)
+	t.Setenv("APP_DOMAIN", "https://env.example.com")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Etcd.Config.Enabled || cfg.App.LogLevel != "warn" || cfg.Server.HTTP.Port != 8080 || cfg.App.Domain != "https://env.example.com" {
+		t.Fatalf("unexpected layering: enabled=%v log_level=%q port=%d domain=%q",
+			cfg.Etcd.Config.Enabled, cfg.App.LogLevel, cfg.Server.HTTP.Port, cfg.App.Domain)
+	}
+
+	// 远程配置变更触发重载
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// 监听从加载配置时的修订号之后开始，无需等待监听建立
+	reloaded := make(chan []string, 10)
+	rejected := make(chan error, 10)
+	Watch(watchCtx, func(changed []string, err error) {
+		if err != nil {
+			rejected <- err
+		} else if len(changed) > 0 {
+			reloaded <- changed
+		}
+	})
+	_, _ = cli.Txn(ctx).Then(
+		clientv3.OpPut(namespace+"app/log_level", "error"),
+		clientv3.OpPut(namespace+"server/http/cors/allowed_origins", "- https://example.com"),
+	).Commit()
+
+	select {
+	case changed := <-reloaded:
+		if !slices.Equal(changed, []string{"app"}) || Current().App.LogLevel != "error" {
+			t.Fatalf("changed=%v log_level=%q", changed, Current().App.LogLevel)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("remote change did not trigger reload")
+	}
+
+	// 校验失败的远程配置被拒绝
+	_, _ = cli.Put(ctx, namespace+"app/log_level", "verbose")
+	select {
+	case <-rejected:
+	case changed := <-reloaded:
+		t.Fatalf("invalid remote config should be rejected, reloaded %v", changed)
+	case <-time.After(10 * time.Second):
+		t.Fatal("invalid remote change did not trigger reload")
+	}
+	if Current().App.LogLevel != "error" {
+		t.Fatalf("invalid remote config should be rejected, got %q", Current().App.LogLevel)
+	}
+}
+
+func TestOmitSecrets(t *testing.T) {
+	keys := map[string]string{
+		"security/jwt/secret":           `"s"`,
+		"security/encryption/keys":      `"k1:abc"`,
+		"security/encryption/index_key": `"idx"`,
+		"database/password":             `"p"`,
+		"redis/main/sentinel_password":  `"p"`,
+		"storage/oss/access_key_secret": `"s"`,
+		"storage/oss/access_key_id":     `"id"`,
+		"redis/main/key_prefix":         `"app"`,
+		"server/http/port":              "8080",
+	}
+	if omitted := OmitSecrets(keys); omitted != 6 {
+		t.Fatalf("omitted = %d, want 6: %v", omitted, keys)
+	}
+	for _, key := range []string{"storage/oss/access_key_id", "redis/main/key_prefix", "server/http/port"} {
+		if _, ok := keys[key]; !ok {
+			t.Fatalf("%s should be kept", key)
+		}
+	}
+}
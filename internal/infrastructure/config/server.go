@@ -1,6 +1,10 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 // Server 服务配置
 type Server struct {
@@ -13,9 +17,15 @@ type Server struct {
 
 // HTTPConfig HTTP服务配置
 type HTTPConfig struct {
-	Enabled bool   `mapstructure:"enabled"`
-	Host    string `mapstructure:"host"`
-	Port    int    `mapstructure:"port"`
+	Enabled bool       `mapstructure:"enabled"`
+	Host    string     `mapstructure:"host"`
+	Port    int        `mapstructure:"port"`
+	CORS    CORSConfig `mapstructure:"cors"`
+}
+
+// CORSConfig 跨域配置，支持热更新
+type CORSConfig struct {
+	AllowedOrigins []string `mapstructure:"allowed_origins"` // 允许的来源，如 https://example.com，* 为允许全部
 }
 
 // GRPCConfig gRPC配置
@@ -35,8 +45,88 @@ type WebSocketConfig struct {
 
 // EventConfig 事件消费者服务配置
 type EventConfig struct {
-	Enabled bool   `mapstructure:"enabled"`
-	Driver  string `mapstructure:"driver"`
+	Enabled     bool                   `mapstructure:"enabled"`
+	Driver      string                 `mapstructure:"driver"`    // 事件总线驱动 mq|sync
+	SyncMode    string                 `mapstructure:"sync_mode"` // sync驱动分发模式 immediate|after_commit
+	Outbox      EventOutboxConfig      `mapstructure:"outbox"`
+	Inbox       EventInboxConfig       `mapstructure:"inbox"`
+	Retry       EventRetryConfig       `mapstructure:"retry"`
+	DeadLetter  EventDeadLetterConfig  `mapstructure:"dead_letter"`
+	Delay       EventDelayConfig       `mapstructure:"delay"`
+	Concurrency EventConcurrencyConfig `mapstructure:"concurrency"`
+	Store       EventStoreConfig       `mapstructure:"store"`
+	Webhook     EventWebhookConfig     `mapstructure:"webhook"`
+}
+
+// OutboxEnabled 是否启用事务发件箱，进程内同步总线不经过MQ，不启用发件箱
+func (c EventConfig) OutboxEnabled() bool {
+	return c.Outbox.Enabled && c.Driver != "sync"
+}
+
+// RelayEnabled 是否启用发件箱投递服务(投递发件箱事件与数据库定时事件)
+func (c EventConfig) RelayEnabled() bool {
+	return c.OutboxEnabled() || (c.Driver != "sync" && c.Delay.Driver == "db")
+}
+
+// EventOutboxConfig 事务发件箱配置
+type EventOutboxConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	PollInterval     time.Duration `mapstructure:"poll_interval"`
+	BatchSize        int           `mapstructure:"batch_size"`
+	MaxAttempts      int           `mapstructure:"max_attempts"`
+	RetryInterval    time.Duration `mapstructure:"retry_interval"`
+	MaxRetryInterval time.Duration `mapstructure:"max_retry_interval"`
+	Retention        time.Duration `mapstructure:"retention"`
+}
+
+// EventInboxConfig 幂等消费收件箱配置
+type EventInboxConfig struct {
+	Driver    string        `mapstructure:"driver"` // 存储驱动 db|redis|none
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// EventRetryConfig 事件处理器重试配置
+type EventRetryConfig struct {
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	Interval    time.Duration `mapstructure:"interval"`
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+}
+
+// EventDeadLetterConfig 事件死信配置
+type EventDeadLetterConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// EventDelayConfig 延时事件配置
+type EventDelayConfig struct {
+	Driver string `mapstructure:"driver"` // 延时实现 mq(MQ延时消息)|db(数据库定时调度)
+}
+
+// EventConcurrencyConfig 事件消费并发配置
+type EventConcurrencyConfig struct {
+	Default int                          `mapstructure:"default"` // 默认并发数量，0为不限制
+	Types   []EventTypeConcurrencyConfig `mapstructure:"types"`
+}
+
+// EventTypeConcurrencyConfig 事件类型消费并发配置
+type EventTypeConcurrencyConfig struct {
+	EventType   string `mapstructure:"event_type"`
+	Concurrency int    `mapstructure:"concurrency"` // 并发数量，有序处理时为分区数量
+	Ordered     bool   `mapstructure:"ordered"`     // 是否按聚合根ID有序处理
+}
+
+// EventStoreConfig 事件存储配置
+type EventStoreConfig struct {
+	Enabled bool `mapstructure:"enabled"` // 是否记录经MQ发布的全部事件
+}
+
+// EventWebhookConfig Webhook投递配置
+type EventWebhookConfig struct {
+	Timeout          time.Duration `mapstructure:"timeout"`            // 请求超时时间
+	MaxAttempts      int           `mapstructure:"max_attempts"`       // 单次投递最大尝试次数(含首次)
+	RetryInterval    time.Duration `mapstructure:"retry_interval"`     // 首次重试间隔，之后按指数退避
+	MaxRetryInterval time.Duration `mapstructure:"max_retry_interval"` // 最大重试间隔
+	DisableThreshold int           `mapstructure:"disable_threshold"`  // 连续失败次数达到阈值时自动停用订阅，0为不停用
 }
 
 type HealthConfig struct {
@@ -46,7 +136,32 @@ type HealthConfig struct {
 
 func serverBindEnv(v *viper.Viper) {
 	_ = v.BindEnv("http.port", "SERVER_HTTP_PORT")
+	v.SetDefault("http.cors.allowed_origins", []string{"*"})
 	_ = v.BindEnv("grpc.port", "SERVER_GRPC_PORT")
 	_ = v.BindEnv("websocket.port", "SERVER_WEBSOCKET_PORT")
 	_ = v.BindEnv("health.port", "SERVER_HEALTH_PORT")
+	_ = v.BindEnv("event.driver", "SERVER_EVENT_DRIVER")
+	v.SetDefault("event.sync_mode", "after_commit")
+	_ = v.BindEnv("event.outbox.enabled", "SERVER_EVENT_OUTBOX_ENABLED")
+	v.SetDefault("event.outbox.poll_interval", "1s")
+	v.SetDefault("event.outbox.batch_size", 100)
+	v.SetDefault("event.outbox.max_attempts", 10)
+	v.SetDefault("event.outbox.retry_interval", "1s")
+	v.SetDefault("event.outbox.max_retry_interval", "5m")
+	v.SetDefault("event.outbox.retention", "24h")
+	_ = v.BindEnv("event.inbox.driver", "SERVER_EVENT_INBOX_DRIVER")
+	v.SetDefault("event.inbox.driver", "db")
+	v.SetDefault("event.inbox.retention", "168h")
+	v.SetDefault("event.retry.max_attempts", 3)
+	v.SetDefault("event.retry.interval", "1s")
+	v.SetDefault("event.retry.max_interval", "30s")
+	_ = v.BindEnv("event.dead_letter.enabled", "SERVER_EVENT_DEAD_LETTER_ENABLED")
+	_ = v.BindEnv("event.delay.driver", "SERVER_EVENT_DELAY_DRIVER")
+	v.SetDefault("event.delay.driver", "mq")
+	_ = v.BindEnv("event.store.enabled", "SERVER_EVENT_STORE_ENABLED")
+	v.SetDefault("event.webhook.timeout", "10s")
+	v.SetDefault("event.webhook.max_attempts", 6)
+	v.SetDefault("event.webhook.retry_interval", "30s")
+	v.SetDefault("event.webhook.max_retry_interval", "1h")
+	v.SetDefault("event.webhook.disable_threshold", 20)
 }
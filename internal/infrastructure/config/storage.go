@@ -11,6 +11,8 @@ type Storage struct {
 	HwObs     cloudStorage `mapstructure:"hw_obs"`
 	TxCos     cloudStorage `mapstructure:"tx_cos"`
 	S3        cloudStorage `mapstructure:"s3"`
+	// UploadPolicy 管理端上传策略，按媒体类型(image、audio、video、document、compressed)覆盖 AmsFileAllow，支持热更新
+	UploadPolicy map[string]FileAllow `mapstructure:"upload_policy"`
 }
 
 type local struct {
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+var (
+	validatorsMu sync.RWMutex
+	validators   []func(*Config) error
+)
+
+// RegisterValidator 注册配置校验，启动加载与重载时执行，未通过时拒绝该配置
+func RegisterValidator(fn func(*Config) error) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators = append(validators, fn)
+}
+
+// Validate 校验配置
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.App.LogLevel != "" && !slices.Contains([]string{"debug", "info", "warn", "error"}, c.App.LogLevel) {
+		errs = append(errs, fmt.Errorf("app.log_level: invalid level %q", c.App.LogLevel))
+	}
+	if c.App.Tenant.Enabled && c.App.Tenant.Header == "" {
+		errs = append(errs, errors.New("app.tenant.header: required when tenant is enabled"))
+	}
+
+	if c.Server.Event.Driver == "sync" && !c.Server.Event.Enabled {
+		// 进程内同步总线的处理器随事件服务订阅，未开启时发布的事件无人处理
+		errs = append(errs, errors.New("server.event.enabled: required when server.event.driver is sync"))
+	}
+
+	for name, port := range map[string]int{
+		"server.http.port":      c.Server.HTTP.Port,
+		"server.grpc.port":      c.Server.GRPC.Port,
+		"server.websocket.port": c.Server.WebSocket.Port,
+		"server.health.port":    c.Server.Health.Port,
+	} {
+		if port < 0 || port > 65535 {
+			errs = append(errs, fmt.Errorf("%s: invalid port %d", name, port))
+		}
+	}
+
+	for _, origin := range c.Server.HTTP.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+			errs = append(errs, fmt.Errorf("server.http.cors.allowed_origins: invalid origin %q", origin))
+		}
+	}
+
+	for mediaType, policy := range c.Storage.UploadPolicy {
+		if len(policy.AllowMimeType) == 0 || policy.AllowCapacitySize <= 0 {
+			errs = append(errs, fmt.Errorf("storage.upload_policy.%s: mime_types and max_size are required", mediaType))
+		}
+	}
+
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	for _, fn := range validators {
+		if err := fn(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -3,40 +3,183 @@ package event
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/dysodeng/mq/contract"
 	"github.com/dysodeng/mq/message"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
 )
 
+// MQEventBusOption 基于MQ的事件总线选项
+type MQEventBusOption func(b *MQEventBus)
+
+// WithBusMessagingSystem 设置消息系统标识，用于追踪span的 messaging.system 属性
+func WithBusMessagingSystem(system string) MQEventBusOption {
+	return func(b *MQEventBus) {
+		if system != "" {
+			b.system = system
+		}
+	}
+}
+
+// WithBusScheduler 设置定时事件调度器，设置后延时事件写入数据库调度而不使用MQ的延时消息
+func WithBusScheduler(scheduler *DBEventScheduler) MQEventBusOption {
+	return func(b *MQEventBus) {
+		b.scheduler = scheduler
+	}
+}
+
+// WithBusEventStore 设置事件存储，设置后事件发布到MQ前先追加到事件存储
+func WithBusEventStore(store *EventStore) MQEventBusOption {
+	return func(b *MQEventBus) {
+		b.store = store
+	}
+}
+
+// WithBusOutboxRetry 标记由事务发件箱投递，发送失败由发件箱重试重新发布
+func WithBusOutboxRetry() MQEventBusOption {
+	return func(b *MQEventBus) {
+		b.outboxRetry = true
+	}
+}
+
+// WithBusLogger 设置日志记录器
+func WithBusLogger(logger *zap.Logger) MQEventBusOption {
+	return func(b *MQEventBus) {
+		if logger != nil {
+			b.logger = logger
+		}
+	}
+}
+
 // MQEventBus 基于MQ的事件总线实现
 type MQEventBus struct {
-	producer contract.Producer
+	producer    contract.Producer
+	system      string
+	scheduler   *DBEventScheduler
+	store       *EventStore
+	outboxRetry bool
+	logger      *zap.Logger
 }
 
 // NewMQEventBus 创建基于MQ的事件总线
-func NewMQEventBus(producer contract.Producer) *MQEventBus {
-	return &MQEventBus{
+func NewMQEventBus(producer contract.Producer, opts ...MQEventBusOption) *MQEventBus {
+	b := &MQEventBus{
 		producer: producer,
+		system:   defaultMessagingSystem,
+		logger:   zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(b)
 	}
+	return b
 }
 
 // Publish 发布事件
+// 上下文中的追踪信息注入消息头，消费端据此延续调用链
 func (b *MQEventBus) Publish(ctx context.Context, eventType string, eventData []byte) error {
-	return b.producer.Send(ctx, message.New(eventType, eventData))
+	spanCtx, span := startPublishSpan(ctx, b.system, eventType)
+	defer span.End()
+
+	msg := message.New(eventType, eventData)
+	injectTraceHeaders(spanCtx, msg.Headers)
+	span.SetAttributes(semconv.MessagingMessageID(msg.ID))
+
+	if err := b.appendStore(spanCtx, span, eventType, eventData); err != nil {
+		return err
+	}
+	if err := b.producer.Send(spanCtx, msg); err != nil {
+		trace.Error(err, span)
+		b.logUnsent(eventType, eventData, err)
+		return err
+	}
+	return nil
+}
+
+// PublishDelay 发布延时事件
+func (b *MQEventBus) PublishDelay(ctx context.Context, eventType string, eventData []byte, delay time.Duration) error {
+	spanCtx, span := startPublishSpan(ctx, b.system, eventType)
+	defer span.End()
+
+	msg := message.New(eventType, eventData)
+	injectTraceHeaders(spanCtx, msg.Headers)
+	span.SetAttributes(semconv.MessagingMessageID(msg.ID))
+
+	if err := b.appendStore(spanCtx, span, eventType, eventData); err != nil {
+		return err
+	}
+	if err := b.producer.SendDelay(spanCtx, msg, delay); err != nil {
+		trace.Error(err, span)
+		b.logUnsent(eventType, eventData, err)
+		return err
+	}
+	return nil
+}
+
+// appendStore 追加事件到事件存储
+// 先于发送追加，追加失败时不发送，避免已发送的事件随调用方回滚后缺失存储记录；存储按事件ID去重。
+// 发件箱投递时存储记录与投递状态在同一事务中写入，追加后发送失败由发件箱重试重新发布；
+// 直接发布(未启用发件箱)时发送失败的事件仅存在于存储中，不会自动补发，记录错误日志供排查与回放
+func (b *MQEventBus) appendStore(ctx context.Context, span oteltrace.Span, eventType string, eventData []byte) error {
+	if b.store == nil {
+		return nil
+	}
+	if err := b.store.Append(ctx, eventType, eventData); err != nil {
+		trace.Error(err, span)
+		return err
+	}
+	return nil
+}
+
+// logUnsent 直接发布时记录已追加到事件存储但发送失败的事件
+func (b *MQEventBus) logUnsent(eventType string, eventData []byte, err error) {
+	if b.store == nil || b.outboxRetry {
+		return
+	}
+	var head struct {
+		ID string `json:"id"`
+	}
+	_ = sonic.Unmarshal(eventData, &head)
+	b.logger.Error("Stored event was not sent",
+		zap.String("eventID", head.ID),
+		zap.String("eventType", eventType),
+		zap.Error(err),
+	)
 }
 
 // PublishEvent 发布事件
 func (b *MQEventBus) PublishEvent(ctx context.Context, event any) error {
-	// 检查是否实现了EventType方法
-	if e, ok := event.(interface{ EventType() string }); ok {
-		data, err := sonic.Marshal(event)
-		if err != nil {
-			return fmt.Errorf("marshal event failed: %w", err)
-		}
-		return b.Publish(ctx, e.EventType(), data)
+	eventType, data, err := marshalEvent(event)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("unsupported event type: %T", event)
+	return b.Publish(ctx, eventType, data)
+}
+
+// PublishEventAt 在指定时间发布事件
+func (b *MQEventBus) PublishEventAt(ctx context.Context, event any, at time.Time) error {
+	return b.PublishEventAfter(ctx, event, time.Until(at))
+}
+
+// PublishEventAfter 延迟指定时长后发布事件
+func (b *MQEventBus) PublishEventAfter(ctx context.Context, event any, delay time.Duration) error {
+	if delay <= 0 {
+		return b.PublishEvent(ctx, event)
+	}
+	if b.scheduler != nil {
+		return b.scheduler.ScheduleEvent(ctx, event, time.Now().Add(delay))
+	}
+
+	eventType, data, err := marshalEvent(event)
+	if err != nil {
+		return err
+	}
+	return b.PublishDelay(ctx, eventType, data, delay)
 }
 
 // SubscribeHandler 订阅事件处理器
@@ -47,3 +190,17 @@ func (b *MQEventBus) SubscribeHandler(handler any) error {
 	}
 	return fmt.Errorf("unsupported handler type: %T", handler)
 }
+
+// marshalEvent 序列化事件，返回事件类型与事件数据
+func marshalEvent(event any) (string, []byte, error) {
+	// 检查是否实现了EventType方法
+	e, ok := event.(interface{ EventType() string })
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported event type: %T", event)
+	}
+	data, err := sonic.Marshal(event)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshal event failed: %w", err)
+	}
+	return e.EventType(), data, nil
+}
@@ -3,6 +3,7 @@ package event
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -11,49 +12,74 @@ import (
 	"github.com/dysodeng/mq/contract"
 	"github.com/dysodeng/mq/message"
 	"go.uber.org/zap"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/shared/retry"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
 )
 
+// RetryableHandler 自定义重试策略的事件处理器
+type RetryableHandler interface {
+	Handler
+	// RetryOptions 处理失败时的重试选项，覆盖消费者服务的默认重试策略
+	RetryOptions() []retry.Option
+}
+
 // handlerWrapper 处理器包装器
 type handlerWrapper struct {
-	handler Handler
-	logger  *zap.Logger
+	handler   Handler
+	logger    *zap.Logger
+	retryOpts []retry.Option
 }
 
 // createEvent 根据事件数据创建事件对象
-func (w *handlerWrapper) createEvent(eventType string, eventData json.RawMessage, timestamp time.Time, aggregateID, aggregateName string) any {
-	if aggregateID != "" && aggregateName != "" {
+func (w *handlerWrapper) createEvent(data *eventData) any {
+	baseEvent := BaseEvent[json.RawMessage]{
+		ID:        data.ID,
+		Type:      data.Type,
+		Version:   data.Version,
+		Timestamp: data.Timestamp,
+		Data:      data.Data,
+		Tenant:    data.TenantID,
+	}
+
+	if data.AggregateID != "" && data.AggregateName != "" {
 		// 创建领域事件
 		return BaseDomainEvent[json.RawMessage]{
-			BaseEvent: BaseEvent[json.RawMessage]{
-				Type:      eventType,
-				Timestamp: timestamp,
-				Data:      eventData,
-			},
-			AggID:   aggregateID,
-			AggName: aggregateName,
+			BaseEvent: baseEvent,
+			AggID:     data.AggregateID,
+			AggName:   data.AggregateName,
 		}
 	}
 
 	// 创建普通事件
-	return BaseEvent[json.RawMessage]{
-		Type:      eventType,
-		Timestamp: timestamp,
-		Data:      eventData,
-	}
+	return baseEvent
+}
+
+// name 处理器名称，用于幂等去重记录
+func (w *handlerWrapper) name() string {
+	return fmt.Sprintf("%T", w.handler)
 }
 
 // handle 处理事件
-func (w *handlerWrapper) handle(ctx context.Context, eventType string, eventData json.RawMessage, timestamp time.Time, aggregateID, aggregateName string) error {
+func (w *handlerWrapper) handle(ctx context.Context, data *eventData) error {
+	eventType := data.Type
+
 	// 记录开始处理事件
 	w.logger.Debug("Handler starting to process event",
+		zap.String("eventID", data.ID),
 		zap.String("eventType", eventType),
-		zap.String("handlerType", fmt.Sprintf("%T", w.handler)),
-		zap.String("aggregateID", aggregateID),
-		zap.String("aggregateName", aggregateName),
+		zap.String("handlerType", w.name()),
+		zap.String("aggregateID", data.AggregateID),
+		zap.String("aggregateName", data.AggregateName),
 	)
 
+	// 在事件所属租户上下文中处理
+	ctx = tenant.WithContext(ctx, data.TenantID)
+
 	// 创建事件对象
-	event := w.createEvent(eventType, eventData, timestamp, aggregateID, aggregateName)
+	event := w.createEvent(data)
 	if event == nil {
 		err := fmt.Errorf("failed to create event object for type: %s", eventType)
 		w.logger.Error("Event creation failed", zap.Error(err))
@@ -63,35 +89,149 @@ func (w *handlerWrapper) handle(ctx context.Context, eventType string, eventData
 	// 处理事件
 	if err := w.handler.Handle(ctx, event); err != nil {
 		w.logger.Error("Handler failed to process event",
+			zap.String("eventID", data.ID),
 			zap.String("eventType", eventType),
-			zap.String("handlerType", fmt.Sprintf("%T", w.handler)),
+			zap.String("handlerType", w.name()),
 			zap.Error(err),
 		)
 		return fmt.Errorf("handler %T failed to process event %s: %w", w.handler, eventType, err)
 	}
 
 	w.logger.Debug("Handler successfully processed event",
+		zap.String("eventID", data.ID),
 		zap.String("eventType", eventType),
-		zap.String("handlerType", fmt.Sprintf("%T", w.handler)),
+		zap.String("handlerType", w.name()),
 	)
 	return nil
 }
 
+// ConsumerOption 事件消费者服务选项
+type ConsumerOption func(s *ConsumerService)
+
+// WithInbox 设置幂等消费收件箱
+// retention 为去重记录保留时长，收件箱实现 InboxPruner 时定期清理过期记录
+func WithInbox(inbox Inbox, retention time.Duration) ConsumerOption {
+	return func(s *ConsumerService) {
+		s.inbox = inbox
+		s.inboxRetention = retention
+	}
+}
+
+// WithHandlerRetry 设置处理器默认重试策略
+// maxAttempts 为最大处理次数，重试间隔从 interval 开始指数退避，不超过 maxInterval
+func WithHandlerRetry(maxAttempts int, interval, maxInterval time.Duration) ConsumerOption {
+	return func(s *ConsumerService) {
+		if maxAttempts <= 0 {
+			return
+		}
+		s.retryOpts = []retry.Option{
+			retry.WithRetryNum(maxAttempts),
+			retry.WithExponentialBackoff(interval, maxInterval),
+		}
+	}
+}
+
+// WithDeadLetter 设置死信存储，处理器重试耗尽后事件写入死信而不再整体重新投递
+func WithDeadLetter(store DeadLetterStore) ConsumerOption {
+	return func(s *ConsumerService) {
+		s.deadLetter = store
+	}
+}
+
+// WithSchemaRegistry 设置事件结构版本注册表
+// 设置后订阅未注册结构的事件类型将失败，消费前旧版本事件数据升级到当前版本
+func WithSchemaRegistry(registry *SchemaRegistry) ConsumerOption {
+	return func(s *ConsumerService) {
+		s.schemas = registry
+	}
+}
+
+// WithConsumerMessagingSystem 设置消息系统标识，用于追踪span的 messaging.system 属性
+func WithConsumerMessagingSystem(system string) ConsumerOption {
+	return func(s *ConsumerService) {
+		if system != "" {
+			s.system = system
+		}
+	}
+}
+
+// WithDefaultConcurrency 设置事件类型默认并发处理数量，0为不限制(由MQ适配器的并发决定)
+func WithDefaultConcurrency(concurrency int) ConsumerOption {
+	return func(s *ConsumerService) {
+		s.defaultConcurrency = concurrency
+	}
+}
+
+// WithEventConcurrency 设置指定事件类型的并发处理数量
+// ordered 为true时按聚合根ID分区，同一聚合根的事件按顺序处理，concurrency 为分区数量
+func WithEventConcurrency(eventType string, concurrency int, ordered bool) ConsumerOption {
+	return func(s *ConsumerService) {
+		s.concurrency[eventType] = eventConcurrency{concurrency: concurrency, ordered: ordered}
+	}
+}
+
+// WithOrderedConsumer 设置有序事件类型的消费者，需按消息到达顺序逐条回调
+// 未设置时有序事件类型使用共享消费者订阅
+func WithOrderedConsumer(consumer contract.Consumer) ConsumerOption {
+	return func(s *ConsumerService) {
+		s.orderedConsumer = consumer
+	}
+}
+
+// eventConcurrency 事件类型并发配置
+type eventConcurrency struct {
+	concurrency int
+	ordered     bool
+}
+
+// upcastDeadLetterHandler 事件数据升级失败时死信记录的处理器名称
+const upcastDeadLetterHandler = "event.SchemaRegistry"
+
+// errConsumerStopping 消费者服务停止中，拒绝新消息由MQ重新投递
+var errConsumerStopping = fmt.Errorf("event consumer service is stopping")
+
 // ConsumerService 事件消费者服务
 type ConsumerService struct {
-	consumer contract.Consumer
-	handlers map[string][]*handlerWrapper // 存储处理器包装器
-	logger   *zap.Logger
-	mu       sync.RWMutex
+	consumer        contract.Consumer
+	orderedConsumer contract.Consumer            // 有序事件类型的消费者
+	handlers        map[string][]*handlerWrapper // 存储处理器包装器
+	logger          *zap.Logger
+	inbox           Inbox
+	inboxRetention  time.Duration
+	retryOpts       []retry.Option
+	deadLetter      DeadLetterStore
+	schemas         *SchemaRegistry
+	system          string
+	cancel          context.CancelFunc
+	mu              sync.RWMutex
+
+	defaultConcurrency int
+	concurrency        map[string]eventConcurrency
+	executors          map[string]*eventExecutor // 启动后只读
+
+	drainMu  sync.RWMutex
+	stopping bool
+	inflight sync.WaitGroup
 }
 
 // NewEventConsumerService 创建事件消费者服务
-func NewEventConsumerService(consumer contract.Consumer, logger *zap.Logger) *ConsumerService {
-	return &ConsumerService{
-		consumer: consumer,
-		handlers: make(map[string][]*handlerWrapper),
-		logger:   logger,
+func NewEventConsumerService(consumer contract.Consumer, logger *zap.Logger, opts ...ConsumerOption) *ConsumerService {
+	s := &ConsumerService{
+		consumer:    consumer,
+		handlers:    make(map[string][]*handlerWrapper),
+		logger:      logger,
+		system:      defaultMessagingSystem,
+		concurrency: make(map[string]eventConcurrency),
+		executors:   make(map[string]*eventExecutor),
+		retryOpts: []retry.Option{
+			retry.WithRetryNum(3),
+			retry.WithExponentialBackoff(time.Second, 30*time.Second),
+		},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // SubscribeHandler 订阅事件处理器
@@ -115,10 +255,23 @@ func (s *ConsumerService) SubscribeHandler(handler any) error {
 		return fmt.Errorf("handler %T must be interested in at least one event type", handler)
 	}
 
+	// 校验事件结构已注册
+	if s.schemas != nil {
+		for _, eventType := range interestedTypes {
+			if eventType != "" && !s.schemas.Known(eventType) {
+				return fmt.Errorf("handler %T subscribes to event type %s without a registered schema", handler, eventType)
+			}
+		}
+	}
+
 	// 创建处理器包装器
 	wrapper := &handlerWrapper{
-		handler: eventHandler,
-		logger:  s.logger,
+		handler:   eventHandler,
+		logger:    s.logger,
+		retryOpts: s.retryOpts,
+	}
+	if retryable, ok := handler.(RetryableHandler); ok {
+		wrapper.retryOpts = retryable.RetryOptions()
 	}
 
 	// 为每个感兴趣的事件类型注册包装器
@@ -171,9 +324,32 @@ func (s *ConsumerService) Start(ctx context.Context) error {
 		return nil
 	}
 
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	// 定期清理收件箱过期记录
+	if pruner, ok := s.inbox.(InboxPruner); ok && s.inboxRetention > 0 {
+		go s.pruneInbox(ctx, pruner)
+	}
+
+	// 为每个事件类型创建执行器
+	for _, eventType := range eventTypes {
+		cfg, ok := s.concurrency[eventType]
+		if !ok {
+			cfg = eventConcurrency{concurrency: s.defaultConcurrency}
+		}
+		if executor := newEventExecutor(cfg.concurrency, cfg.ordered); executor != nil {
+			s.executors[eventType] = executor
+			s.logger.Info("Event type concurrency configured",
+				zap.String("eventType", eventType),
+				zap.Int("concurrency", cfg.concurrency),
+				zap.Bool("ordered", cfg.ordered),
+			)
+		}
+	}
+
 	// 为每个事件类型单独订阅事件处理器
 	for _, eventType := range eventTypes {
-		if err := s.consumer.Subscribe(ctx, eventType, s.handleMessage); err != nil {
+		if err := s.consumerFor(eventType).Subscribe(ctx, eventType, s.handleMessage); err != nil {
 			s.logger.Error("Failed to subscribe to event type",
 				zap.String("eventType", eventType),
 				zap.Error(err),
@@ -191,9 +367,52 @@ func (s *ConsumerService) Start(ctx context.Context) error {
 }
 
 // Stop 停止事件消费服务
-func (s *ConsumerService) Stop() error {
+// 停止接收新消息并等待处理中的事件完成后再关闭消费者，超时以 ctx 为准
+func (s *ConsumerService) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping event consumer service")
 
+	s.drainMu.Lock()
+	s.stopping = true
+	s.drainMu.Unlock()
+
+	// 取消订阅，停止拉取新消息
+	s.mu.RLock()
+	for eventType := range s.handlers {
+		if err := s.consumerFor(eventType).Unsubscribe(eventType); err != nil {
+			s.logger.Warn("Failed to unsubscribe event type",
+				zap.String("eventType", eventType),
+				zap.Error(err),
+			)
+		}
+	}
+	s.mu.RUnlock()
+
+	// 等待处理中的事件完成，有序分区队列中的事件随消息回调一并等待
+	drained := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		s.logger.Info("Event consumer drained in-flight events")
+	case <-ctx.Done():
+		s.logger.Warn("Timed out waiting for in-flight events", zap.Error(ctx.Err()))
+	}
+
+	// 超时后中止处理中的事件，分区协程随执行器关闭退出，未处理的事件由MQ重新投递
+	if s.cancel != nil {
+		s.cancel()
+	}
+	for _, executor := range s.executors {
+		executor.close()
+	}
+
+	if s.orderedConsumer != nil && s.orderedConsumer != s.consumer {
+		if err := s.orderedConsumer.Close(); err != nil {
+			s.logger.Error("Failed to stop ordered consumer", zap.Error(err))
+		}
+	}
 	if err := s.consumer.Close(); err != nil {
 		s.logger.Error("Failed to stop consumer", zap.Error(err))
 		return fmt.Errorf("failed to stop consumer: %w", err)
@@ -203,10 +422,38 @@ func (s *ConsumerService) Stop() error {
 	return nil
 }
 
+// consumerFor 事件类型对应的消费者，有序事件类型使用有序消费者
+func (s *ConsumerService) consumerFor(eventType string) contract.Consumer {
+	if cfg, ok := s.concurrency[eventType]; ok && cfg.ordered && s.orderedConsumer != nil {
+		return s.orderedConsumer
+	}
+	return s.consumer
+}
+
+// acquire 登记处理中的消息，停止中返回false
+func (s *ConsumerService) acquire() bool {
+	s.drainMu.RLock()
+	defer s.drainMu.RUnlock()
+	if s.stopping {
+		return false
+	}
+	s.inflight.Add(1)
+	return true
+}
+
 // handleMessage 处理消息
 func (s *ConsumerService) handleMessage(ctx context.Context, msg *message.Message) error {
+	if !s.acquire() {
+		return errConsumerStopping
+	}
+	defer s.inflight.Done()
+
 	eventType := msg.Topic
 
+	// 从消息头恢复生产者的追踪上下文
+	ctx, span := startProcessSpan(ctx, s.system, eventType, msg.ID, msg.Headers)
+	defer span.End()
+
 	// 获取注册的处理器
 	handlers := s.getHandlers(eventType)
 	if len(handlers) == 0 {
@@ -221,11 +468,99 @@ func (s *ConsumerService) handleMessage(ctx context.Context, msg *message.Messag
 			zap.String("eventType", eventType),
 			zap.Error(err),
 		)
-		return fmt.Errorf("failed to parse event data: %w", err)
+		err = fmt.Errorf("failed to parse event data: %w", err)
+		trace.Error(err, span)
+		return err
 	}
 
-	// 处理事件
-	return s.processEvent(ctx, eventType, data, handlers)
+	// 兼容未携带事件ID的旧消息，使用消息ID作为事件ID
+	if data.ID == "" {
+		data.ID = msg.ID
+	}
+	if data.Type == "" {
+		data.Type = eventType
+	}
+
+	// 旧版本事件数据升级到当前版本
+	if s.schemas != nil {
+		upcasted, version, err := s.schemas.Upcast(data.Type, data.Version, data.Data)
+		if err != nil {
+			s.logger.Error("Failed to upcast event data",
+				zap.String("eventID", data.ID),
+				zap.String("eventType", data.Type),
+				zap.Int("version", data.Version),
+				zap.Error(err),
+			)
+			trace.Error(err, span)
+			return s.rejectUpcast(ctx, data, err)
+		}
+		data.Data, data.Version = upcasted, version
+	}
+
+	// 死信重放仅分发给失败的处理器，不依赖收件箱去重，避免已成功的处理器再次执行
+	if data.ReplayHandler != "" {
+		handlers = replayHandlers(handlers, data.ReplayHandler)
+		if len(handlers) == 0 {
+			s.logger.Warn("No handler matches replayed event",
+				zap.String("eventID", data.ID),
+				zap.String("eventType", eventType),
+				zap.String("handlerType", data.ReplayHandler),
+			)
+			return nil
+		}
+	}
+
+	// 处理事件，配置并发控制时交由执行器调度
+	// 有序模式下等待分区处理完成后再确认消息，处理失败返回错误由MQ重新投递
+	process := func(ctx context.Context) error {
+		return s.processEvent(ctx, eventType, data, handlers)
+	}
+	if executor, ok := s.executors[eventType]; ok {
+		err = executor.execute(ctx, partitionKey(data), process)
+	} else {
+		err = process(ctx)
+	}
+	if err != nil {
+		trace.Error(err, span)
+		return err
+	}
+	return nil
+}
+
+// rejectUpcast 升级失败重新投递也无法恢复，写入死信后确认消息，待升级注册表后重放
+// 未配置死信时返回错误，由MQ按自身策略处理
+func (s *ConsumerService) rejectUpcast(ctx context.Context, data *eventData, upcastErr error) error {
+	if s.deadLetter == nil {
+		return upcastErr
+	}
+	if err := s.pushDeadLetter(ctx, upcastDeadLetterHandler, data, 1, upcastErr); err != nil {
+		s.logger.Error("Failed to push event to dead letter",
+			zap.String("eventID", data.ID),
+			zap.String("eventType", data.Type),
+			zap.Error(err),
+		)
+		return upcastErr
+	}
+	return nil
+}
+
+// replayHandlers 筛选死信记录的处理器
+func replayHandlers(handlers []*handlerWrapper, name string) []*handlerWrapper {
+	var matched []*handlerWrapper
+	for _, wrapper := range handlers {
+		if wrapper.name() == name {
+			matched = append(matched, wrapper)
+		}
+	}
+	return matched
+}
+
+// partitionKey 有序处理的分区键，优先使用聚合根ID
+func partitionKey(data *eventData) string {
+	if data.AggregateID != "" {
+		return data.AggregateName + ":" + data.AggregateID
+	}
+	return data.ID
 }
 
 // getHandlers 获取指定事件类型的处理器
@@ -246,14 +581,20 @@ func (s *ConsumerService) parseEventData(payload []byte) (*eventData, error) {
 
 // eventData 事件数据结构
 type eventData struct {
+	ID            string          `json:"id"`
 	Type          string          `json:"type"`
+	Version       int             `json:"version"`
 	Timestamp     time.Time       `json:"timestamp"`
 	Data          json.RawMessage `json:"data"`
 	AggregateID   string          `json:"aggregate_id,omitempty"`
 	AggregateName string          `json:"aggregate_name,omitempty"`
+	TenantID      string          `json:"tenant_id,omitempty"`
+	// ReplayHandler 死信重放时仅由该处理器处理，为空时分发给全部处理器
+	ReplayHandler string `json:"replay_handler,omitempty"`
 }
 
 // processEvent 处理事件
+// 每个处理器独立重试，重试耗尽后写入死信，避免已成功的处理器随消息重新投递再次执行
 func (s *ConsumerService) processEvent(ctx context.Context, eventType string, data *eventData, handlers []*handlerWrapper) error {
 	var errs []error
 
@@ -263,11 +604,36 @@ func (s *ConsumerService) processEvent(ctx context.Context, eventType string, da
 			zap.Int("handlerIndex", i),
 		)
 
-		if err := wrapper.handle(ctx, eventType, data.Data, data.Timestamp, data.AggregateID, data.AggregateName); err != nil {
-			s.logger.Error("Handler failed to process event",
+		attempts := 0
+		err := retry.Do(ctx, func() error {
+			attempts++
+			return s.dispatch(ctx, wrapper, data)
+		}, wrapper.retryOpts...)
+		if err == nil {
+			continue
+		}
+		// 服务停止中止了重试，处理次数未耗尽，返回错误由MQ重新投递而不写入死信
+		if ctx.Err() != nil {
+			return fmt.Errorf("processing event %s aborted: %w", eventType, errors.Join(err, ctx.Err()))
+		}
+
+		s.logger.Error("Handler failed to process event",
+			zap.String("eventType", eventType),
+			zap.Int("handlerIndex", i),
+			zap.Int("attempts", attempts),
+			zap.Error(err),
+		)
+
+		if s.deadLetter == nil {
+			errs = append(errs, err)
+			continue
+		}
+		if dlqErr := s.pushDeadLetter(ctx, wrapper.name(), data, attempts, err); dlqErr != nil {
+			s.logger.Error("Failed to push event to dead letter",
+				zap.String("eventID", data.ID),
 				zap.String("eventType", eventType),
-				zap.Int("handlerIndex", i),
-				zap.Error(err),
+				zap.String("handlerType", wrapper.name()),
+				zap.Error(dlqErr),
 			)
 			errs = append(errs, err)
 		}
@@ -284,9 +650,74 @@ func (s *ConsumerService) processEvent(ctx context.Context, eventType string, da
 	return nil
 }
 
+// pushDeadLetter 将处理失败的事件写入死信
+func (s *ConsumerService) pushDeadLetter(ctx context.Context, handler string, data *eventData, attempts int, handleErr error) error {
+	payload, err := sonic.Marshal(data)
+	if err != nil {
+		return err
+	}
+	// 死信归属事件租户，重放与管理接口按租户隔离
+	return s.deadLetter.Push(tenant.WithContext(context.WithoutCancel(ctx), data.TenantID), &eventEntity.DeadLetter{
+		EventID:   data.ID,
+		EventType: data.Type,
+		Handler:   handler,
+		Payload:   string(payload),
+		Error:     handleErr.Error(),
+		Attempts:  attempts,
+	})
+}
+
+// dispatch 将事件分发给处理器，配置收件箱时保证同一事件对同一处理器至多执行一次
+func (s *ConsumerService) dispatch(ctx context.Context, wrapper *handlerWrapper, data *eventData) error {
+	if s.inbox == nil || data.ID == "" {
+		return wrapper.handle(ctx, data)
+	}
+
+	executed, err := s.inbox.Execute(ctx, data.ID, data.Type, wrapper.name(), func(ctx context.Context) error {
+		return wrapper.handle(ctx, data)
+	})
+	if err != nil {
+		return err
+	}
+	if !executed {
+		s.logger.Info("Skipping duplicate event for handler",
+			zap.String("eventID", data.ID),
+			zap.String("eventType", data.Type),
+			zap.String("handlerType", wrapper.name()),
+		)
+	}
+	return nil
+}
+
+// pruneInbox 定期清理收件箱过期记录
+func (s *ConsumerService) pruneInbox(ctx context.Context, pruner InboxPruner) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := pruner.Prune(ctx, time.Now().Add(-s.inboxRetention)); err != nil {
+				s.logger.Error("Failed to prune event inbox", zap.Error(err))
+			}
+		}
+	}
+}
+
 // PublishEvent 发布事件
 func (s *ConsumerService) PublishEvent(ctx context.Context, event any) error {
 	// ConsumerService不应该负责发布事件
 	// 这里只是为了实现TypedEventBus接口
 	return fmt.Errorf("ConsumerService does not support publishing events")
 }
+
+// PublishEventAt ConsumerService不负责发布事件
+func (s *ConsumerService) PublishEventAt(ctx context.Context, event any, at time.Time) error {
+	return fmt.Errorf("ConsumerService does not support publishing events")
+}
+
+// PublishEventAfter ConsumerService不负责发布事件
+func (s *ConsumerService) PublishEventAfter(ctx context.Context, event any, delay time.Duration) error {
+	return fmt.Errorf("ConsumerService does not support publishing events")
+}
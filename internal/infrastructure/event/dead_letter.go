@@ -0,0 +1,171 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+var (
+	// ErrDeadLetterNotFound 死信不存在
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrDeadLetterNotPending 死信已被处理
+	ErrDeadLetterNotPending = errors.New("dead letter is not pending")
+)
+
+// DeadLetterStore 死信存储
+type DeadLetterStore interface {
+	// Push 写入死信
+	Push(ctx context.Context, record *eventEntity.DeadLetter) error
+}
+
+// DeadLetterQuery 死信查询条件
+type DeadLetterQuery struct {
+	Status    uint8
+	EventType string
+	Handler   string
+	Page      int
+	PageSize  int
+}
+
+// DeadLetterQueue 基于数据库的事件死信队列
+// 处理器重试耗尽后的事件写入死信表，支持查询、重放与丢弃
+type DeadLetterQueue struct {
+	txManager transactions.TransactionManager
+	publisher RawPublisher
+}
+
+// NewDeadLetterQueue 创建事件死信队列
+func NewDeadLetterQueue(txManager transactions.TransactionManager, publisher RawPublisher) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		txManager: txManager,
+		publisher: publisher,
+	}
+}
+
+// Push 写入死信
+func (q *DeadLetterQueue) Push(ctx context.Context, record *eventEntity.DeadLetter) error {
+	record.Status = eventEntity.DeadLetterStatusPending
+	if err := q.txManager.GetTx(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("write event dead letter failed: %w", err)
+	}
+	return nil
+}
+
+// List 分页查询死信
+func (q *DeadLetterQueue) List(ctx context.Context, query DeadLetterQuery) ([]eventEntity.DeadLetter, int64, error) {
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 20
+	}
+
+	tx := q.txManager.GetTx(ctx).Model(&eventEntity.DeadLetter{})
+	if query.Status > 0 {
+		tx = tx.Where("status = ?", query.Status)
+	}
+	if query.EventType != "" {
+		tx = tx.Where("event_type = ?", query.EventType)
+	}
+	if query.Handler != "" {
+		tx = tx.Where("handler = ?", query.Handler)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []eventEntity.DeadLetter
+	if err := tx.Order("id DESC").
+		Offset((query.Page - 1) * query.PageSize).
+		Limit(query.PageSize).
+		Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+	return records, total, nil
+}
+
+// Find 查询死信
+func (q *DeadLetterQueue) Find(ctx context.Context, id uint64) (*eventEntity.DeadLetter, error) {
+	var record eventEntity.DeadLetter
+	if err := q.txManager.GetTx(ctx).Where("id = ?", id).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeadLetterNotFound
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Replay 重放死信，将原始事件重新投递到MQ
+// 事件限定由死信记录的处理器处理，已成功的处理器不会再次执行；升级失败的死信重新分发给全部处理器
+func (q *DeadLetterQueue) Replay(ctx context.Context, id uint64) error {
+	return q.txManager.Transaction(ctx, func(txCtx context.Context) error {
+		record, err := q.lockPending(txCtx, id)
+		if err != nil {
+			return err
+		}
+		payload, err := replayPayload(record)
+		if err != nil {
+			return fmt.Errorf("replay event dead letter failed: %w", err)
+		}
+		if err = q.publisher.Publish(txCtx, record.EventType, payload); err != nil {
+			return fmt.Errorf("replay event dead letter failed: %w", err)
+		}
+		return q.updateStatus(txCtx, id, eventEntity.DeadLetterStatusReplayed)
+	})
+}
+
+// Discard 丢弃死信
+func (q *DeadLetterQueue) Discard(ctx context.Context, id uint64) error {
+	return q.txManager.Transaction(ctx, func(txCtx context.Context) error {
+		if _, err := q.lockPending(txCtx, id); err != nil {
+			return err
+		}
+		return q.updateStatus(txCtx, id, eventEntity.DeadLetterStatusDiscarded)
+	})
+}
+
+// replayPayload 重放的事件数据，标记仅由死信记录的处理器处理
+func replayPayload(record *eventEntity.DeadLetter) ([]byte, error) {
+	if record.Handler == upcastDeadLetterHandler {
+		return []byte(record.Payload), nil
+	}
+	var data eventData
+	if err := sonic.Unmarshal([]byte(record.Payload), &data); err != nil {
+		return nil, err
+	}
+	data.ReplayHandler = record.Handler
+	return sonic.Marshal(&data)
+}
+
+func (q *DeadLetterQueue) lockPending(ctx context.Context, id uint64) (*eventEntity.DeadLetter, error) {
+	var record eventEntity.DeadLetter
+	err := q.txManager.GetTx(ctx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("id = ?", id).
+		First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeadLetterNotFound
+		}
+		return nil, err
+	}
+	if record.Status != eventEntity.DeadLetterStatusPending {
+		return nil, ErrDeadLetterNotPending
+	}
+	return &record, nil
+}
+
+func (q *DeadLetterQueue) updateStatus(ctx context.Context, id uint64, status uint8) error {
+	return q.txManager.GetTx(ctx).Model(&eventEntity.DeadLetter{}).Where("id = ?", id).Update("status", status).Error
+}
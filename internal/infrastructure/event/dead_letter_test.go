@@ -0,0 +1,150 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dysodeng/mq/message"
+	"go.uber.org/zap"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+)
+
+type recordingPublisher struct {
+	mu        sync.Mutex
+	published [][]byte
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, _ string, eventData []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, eventData)
+	return nil
+}
+
+type failingHandler struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (h *failingHandler) Handle(context.Context, any) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	return errors.New("handler failed")
+}
+
+func (h *failingHandler) InterestedEventTypes() []string {
+	return []string{"order.created"}
+}
+
+type countingHandler struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (h *countingHandler) Handle(context.Context, any) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	return nil
+}
+
+func (h *countingHandler) InterestedEventTypes() []string {
+	return []string{"order.created"}
+}
+
+func TestDeadLetterPushAndReplay(t *testing.T) {
+	ctx := context.Background()
+	tx := transactions.NewGormTransactionManager(dbtest.Open(t, &eventEntity.DeadLetter{}))
+	publisher := &recordingPublisher{}
+	queue := NewDeadLetterQueue(tx, publisher)
+
+	handler := &failingHandler{}
+	succeeded := &countingHandler{}
+	consumer := NewEventConsumerService(nil, zap.NewNop(),
+		WithHandlerRetry(2, time.Millisecond, time.Millisecond),
+		WithDeadLetter(queue),
+	)
+	if err := consumer.SubscribeHandler(handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := consumer.SubscribeHandler(succeeded); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重试耗尽后写入死信，消息确认消费不再整体重新投递
+	payload := []byte(`{"id":"e1","type":"order.created","version":1,"data":{"order_id":"1"}}`)
+	if err := consumer.handleMessage(ctx, message.New("order.created", payload)); err != nil {
+		t.Fatalf("handle message: %v", err)
+	}
+	if handler.calls != 2 {
+		t.Fatalf("handler calls = %d, want 2", handler.calls)
+	}
+
+	records, total, err := queue.List(ctx, DeadLetterQuery{Status: eventEntity.DeadLetterStatusPending})
+	if err != nil || total != 1 {
+		t.Fatalf("dead letters = %d, err = %v", total, err)
+	}
+	record := records[0]
+	if record.EventID != "e1" || record.Attempts != 2 || record.Handler != "*event.failingHandler" || record.Error == "" {
+		t.Fatalf("dead letter = %+v", record)
+	}
+
+	if err = queue.Replay(ctx, record.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(publisher.published) != 1 {
+		t.Fatalf("replayed = %s", publisher.published)
+	}
+
+	// 未配置收件箱时重放仅由失败的处理器处理
+	if err = consumer.handleMessage(ctx, message.New("order.created", publisher.published[0])); err != nil {
+		t.Fatalf("handle replayed message: %v", err)
+	}
+	if handler.calls != 4 || succeeded.calls != 1 {
+		t.Fatalf("after replay failing calls = %d, succeeded calls = %d", handler.calls, succeeded.calls)
+	}
+	if found, _ := queue.Find(ctx, record.ID); found.Status != eventEntity.DeadLetterStatusReplayed {
+		t.Fatalf("status = %d", found.Status)
+	}
+
+	// 已处理的死信不可重复重放或丢弃
+	if err = queue.Replay(ctx, record.ID); !errors.Is(err, ErrDeadLetterNotPending) {
+		t.Fatalf("replay again err = %v", err)
+	}
+	if err = queue.Discard(ctx, record.ID); !errors.Is(err, ErrDeadLetterNotPending) {
+		t.Fatalf("discard replayed err = %v", err)
+	}
+	if _, err = queue.Find(ctx, record.ID+100); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Fatalf("find missing err = %v", err)
+	}
+}
+
+func TestDeadLetterSkippedWhenAborted(t *testing.T) {
+	tx := transactions.NewGormTransactionManager(dbtest.Open(t, &eventEntity.DeadLetter{}))
+	queue := NewDeadLetterQueue(tx, &recordingPublisher{})
+	consumer := NewEventConsumerService(nil, zap.NewNop(),
+		WithHandlerRetry(3, time.Millisecond, time.Millisecond),
+		WithDeadLetter(queue),
+	)
+	if err := consumer.SubscribeHandler(&failingHandler{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 停止服务取消上下文后重试中止，消息重新投递而不写入死信
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	payload := []byte(`{"id":"e1","type":"order.created","version":1,"data":{}}`)
+	if err := consumer.handleMessage(ctx, message.New("order.created", payload)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("handle message err = %v, want canceled", err)
+	}
+	if _, total, _ := queue.List(context.Background(), DeadLetterQuery{}); total != 0 {
+		t.Fatalf("dead letters = %d, want 0", total)
+	}
+}
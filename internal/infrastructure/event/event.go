@@ -7,12 +7,18 @@ import (
 	"time"
 
 	"github.com/bytedance/sonic"
+
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
 )
 
 // Event 泛型事件接口
 type Event[T any] interface {
+	// EventID 返回事件唯一ID
+	EventID() string
 	// EventType 返回事件类型
 	EventType() string
+	// EventVersion 返回事件结构版本
+	EventVersion() int
 	// OccurredAt 返回事件发生时间
 	OccurredAt() time.Time
 	// Payload 返回事件数据
@@ -21,15 +27,26 @@ type Event[T any] interface {
 
 // BaseEvent 基础事件实现
 type BaseEvent[T any] struct {
+	ID        string    `json:"id"`
 	Type      string    `json:"type"`
+	Version   int       `json:"version"`
 	Timestamp time.Time `json:"timestamp"`
 	Data      T         `json:"data"`
+	Tenant    string    `json:"tenant_id,omitempty"` // 所属租户，消费者在该租户上下文中执行处理器
+}
+
+func (e BaseEvent[T]) EventID() string {
+	return e.ID
 }
 
 func (e BaseEvent[T]) EventType() string {
 	return e.Type
 }
 
+func (e BaseEvent[T]) EventVersion() int {
+	return e.Version
+}
+
 func (e BaseEvent[T]) OccurredAt() time.Time {
 	return e.Timestamp
 }
@@ -38,10 +55,17 @@ func (e BaseEvent[T]) Payload() T {
 	return e.Data
 }
 
+// TenantID 返回事件所属租户
+func (e BaseEvent[T]) TenantID() string {
+	return e.Tenant
+}
+
 // NewEvent 创建新事件
 func NewEvent[T any](eventType string, data T) Event[T] {
 	return BaseEvent[T]{
+		ID:        domainEvent.NewEventID(),
 		Type:      eventType,
+		Version:   InitialSchemaVersion,
 		Timestamp: time.Now(),
 		Data:      data,
 	}
@@ -75,7 +99,9 @@ func (e BaseDomainEvent[T]) AggregateName() string {
 func NewDomainEvent[T any](eventType string, aggregateID string, aggregateName string, data T) DomainEvent[T] {
 	return BaseDomainEvent[T]{
 		BaseEvent: BaseEvent[T]{
+			ID:        domainEvent.NewEventID(),
 			Type:      eventType,
+			Version:   InitialSchemaVersion,
 			Timestamp: time.Now(),
 			Data:      data,
 		},
@@ -96,10 +122,20 @@ type Handler interface {
 type Bus interface {
 	// PublishEvent 发布事件
 	PublishEvent(ctx context.Context, event any) error
+	// PublishEventAt 在指定时间发布事件
+	PublishEventAt(ctx context.Context, event any, at time.Time) error
+	// PublishEventAfter 延迟指定时长后发布事件
+	PublishEventAfter(ctx context.Context, event any, delay time.Duration) error
 	// SubscribeHandler 订阅事件处理器
 	SubscribeHandler(handler any) error
 }
 
+// RawPublisher 原始事件数据发布接口
+type RawPublisher interface {
+	// Publish 发布已序列化的事件数据
+	Publish(ctx context.Context, eventType string, eventData []byte) error
+}
+
 // DomainEventHandler 领域事件基础处理器
 type DomainEventHandler[T any] struct{}
 
@@ -111,7 +147,7 @@ func (h *DomainEventHandler[T]) ParseDomainEvent(ctx context.Context, event any)
 		return zero, fmt.Errorf("expected event.BaseDomainEvent[json.RawMessage], got %T", event)
 	}
 
-	// 解析JSON数据为类型T结构
+	// 解析JSON数据为类型T结构，旧版本数据已由消费者服务按 SchemaRegistry 升级到当前版本
 	var payload T
 	if err := sonic.Unmarshal(domainEventRaw.Data, &payload); err != nil {
 		return zero, fmt.Errorf("failed to unmarshal %T event: %w", payload, err)
@@ -120,9 +156,12 @@ func (h *DomainEventHandler[T]) ParseDomainEvent(ctx context.Context, event any)
 	// 创建强类型的领域事件
 	domainEvent := BaseDomainEvent[T]{
 		BaseEvent: BaseEvent[T]{
+			ID:        domainEventRaw.ID,
 			Type:      domainEventRaw.Type,
+			Version:   domainEventRaw.Version,
 			Timestamp: domainEventRaw.Timestamp,
 			Data:      payload,
+			Tenant:    domainEventRaw.Tenant,
 		},
 		AggID:   domainEventRaw.AggID,
 		AggName: domainEventRaw.AggName,
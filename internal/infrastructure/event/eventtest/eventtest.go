@@ -0,0 +1,80 @@
+// Package eventtest 事件测试辅助工具
+package eventtest
+
+import (
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+
+	"github.com/dysodeng/app/internal/infrastructure/event"
+)
+
+// NewBus 创建记录已发布事件的进程内同步事件总线，并订阅给定处理器
+// 事件在分发时记录，事务回滚或未到期的延时事件不会出现在断言结果中
+func NewBus(t testing.TB, handlers ...event.Handler) *event.SyncEventBus {
+	t.Helper()
+	bus := event.NewSyncEventBus(zap.NewNop(), event.WithSyncRecording())
+	for _, handler := range handlers {
+		if err := bus.SubscribeHandler(handler); err != nil {
+			t.Fatalf("subscribe handler %T failed: %v", handler, err)
+		}
+	}
+	return bus
+}
+
+// Published 指定类型的已发布事件
+func Published(bus *event.SyncEventBus, eventType string) []event.RecordedEvent {
+	var records []event.RecordedEvent
+	for _, record := range bus.Published() {
+		if record.Type == eventType {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// AssertPublished 断言指定类型的事件已发布，返回最近一次发布的事件
+func AssertPublished(t testing.TB, bus *event.SyncEventBus, eventType string) event.RecordedEvent {
+	t.Helper()
+	records := Published(bus, eventType)
+	if len(records) == 0 {
+		t.Fatalf("expected event %s to be published, got %v", eventType, eventTypes(bus))
+	}
+	return records[len(records)-1]
+}
+
+// AssertNotPublished 断言指定类型的事件未发布
+func AssertNotPublished(t testing.TB, bus *event.SyncEventBus, eventType string) {
+	t.Helper()
+	if records := Published(bus, eventType); len(records) > 0 {
+		t.Fatalf("expected event %s not to be published, got %d", eventType, len(records))
+	}
+}
+
+// AssertPublishedCount 断言指定类型的事件发布次数
+func AssertPublishedCount(t testing.TB, bus *event.SyncEventBus, eventType string, count int) {
+	t.Helper()
+	if records := Published(bus, eventType); len(records) != count {
+		t.Fatalf("expected event %s to be published %d times, got %d", eventType, count, len(records))
+	}
+}
+
+// DecodePayload 解析已发布事件的数据
+func DecodePayload[T any](t testing.TB, record event.RecordedEvent) T {
+	t.Helper()
+	var payload T
+	if err := sonic.Unmarshal(record.Data, &payload); err != nil {
+		t.Fatalf("decode event %s payload failed: %v", record.Type, err)
+	}
+	return payload
+}
+
+func eventTypes(bus *event.SyncEventBus) []string {
+	published := bus.Published()
+	types := make([]string, len(published))
+	for i, record := range published {
+		types[i] = record.Type
+	}
+	return types
+}
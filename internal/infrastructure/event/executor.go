@@ -0,0 +1,150 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+)
+
+// partitionBuffer 有序模式单个分区的队列长度，队列满时阻塞消息回调
+const partitionBuffer = 64
+
+// errExecutorClosed 执行器已关闭，拒绝新任务由MQ重新投递
+var errExecutorClosed = errors.New("event executor is closed")
+
+// executorJob 事件处理任务
+type executorJob struct {
+	ctx  context.Context
+	fn   func(ctx context.Context) error
+	done chan error // 处理结果
+}
+
+// eventExecutor 事件处理执行器，限制单个事件类型的并发处理数量
+// 有序模式下按分区键(聚合根ID)将事件分配到固定分区，同一分区内的事件按提交顺序串行处理；
+// 提交后等待处理完成再返回，消息在处理成功后才确认，处理失败或关闭时未处理的事件由MQ重新投递
+type eventExecutor struct {
+	sem        chan struct{}       // 无序模式并发信号量
+	partitions []chan *executorJob // 有序模式分区队列
+
+	ctx    context.Context // 关闭时取消，中止处理中的任务
+	cancel context.CancelFunc
+	mu     sync.RWMutex // 保护提交与关闭
+	closed bool
+	wg     sync.WaitGroup // 分区协程
+}
+
+// newEventExecutor 创建事件处理执行器
+// concurrency 为并发数量(有序模式下为分区数量)，无序且不限制并发时返回nil
+func newEventExecutor(concurrency int, ordered bool) *eventExecutor {
+	if !ordered {
+		if concurrency <= 0 {
+			return nil
+		}
+		return &eventExecutor{sem: make(chan struct{}, concurrency)}
+	}
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	e := &eventExecutor{partitions: make([]chan *executorJob, concurrency)}
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+	for i := range e.partitions {
+		e.partitions[i] = make(chan *executorJob, partitionBuffer)
+		e.wg.Add(1)
+		go e.work(e.partitions[i])
+	}
+	return e
+}
+
+// execute 执行事件处理并返回处理结果
+// 有序模式下提交到分区队列后等待分区协程处理完成
+func (e *eventExecutor) execute(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	if e.partitions == nil {
+		select {
+		case e.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		defer func() { <-e.sem }()
+		return fn(ctx)
+	}
+
+	job := &executorJob{ctx: ctx, fn: fn, done: make(chan error, 1)}
+	if err := e.submit(ctx, key, job); err != nil {
+		return err
+	}
+	// 分区协程处理或关闭时丢弃任务均会回传结果
+	return <-job.done
+}
+
+func (e *eventExecutor) submit(ctx context.Context, key string, job *executorJob) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return errExecutorClosed
+	}
+	select {
+	case e.partitions[e.partition(key)] <- job:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-e.ctx.Done():
+		return errExecutorClosed
+	}
+}
+
+// close 中止处理中的任务并等待分区协程退出，队列中未处理的任务返回 errExecutorClosed，由MQ重新投递
+func (e *eventExecutor) close() {
+	if e.partitions == nil {
+		return
+	}
+	e.cancel()
+	e.wg.Wait()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	e.closed = true
+	for _, partition := range e.partitions {
+		for len(partition) > 0 {
+			(<-partition).done <- errExecutorClosed
+		}
+	}
+}
+
+func (e *eventExecutor) work(partition chan *executorJob) {
+	defer e.wg.Done()
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case job := <-partition:
+			if e.ctx.Err() != nil {
+				job.done <- errExecutorClosed
+				return
+			}
+			job.done <- e.run(job)
+		}
+	}
+}
+
+func (e *eventExecutor) run(job *executorJob) error {
+	// 提交方已放弃等待的任务不再处理
+	if err := job.ctx.Err(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(job.ctx)
+	defer cancel()
+	stop := context.AfterFunc(e.ctx, cancel)
+	defer stop()
+	return job.fn(ctx)
+}
+
+func (e *eventExecutor) partition(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(e.partitions)))
+}
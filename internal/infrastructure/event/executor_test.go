@@ -0,0 +1,140 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOrderedExecutorKeyOrder(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		got = make(map[string][]int)
+		wg  sync.WaitGroup
+	)
+	executor := newEventExecutor(4, true)
+	defer executor.close()
+
+	// 同一分区键的事件按提交顺序处理，不同分区并行；处理结果同步返回给提交方
+	keys := []string{"order:1", "order:2", "order:3", "order:4", "order:5"}
+	const perKey = 50
+	failed := make(chan error, len(keys))
+	for _, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seq := 0; seq < perKey; seq++ {
+				err := executor.execute(context.Background(), key, func(context.Context) error {
+					time.Sleep(time.Duration(rand.IntN(200)) * time.Microsecond)
+					mu.Lock()
+					defer mu.Unlock()
+					got[key] = append(got[key], seq)
+					if seq == perKey-1 {
+						return fmt.Errorf("%s failed", key)
+					}
+					return nil
+				})
+				if err != nil {
+					failed <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(failed)
+
+	for _, key := range keys {
+		if len(got[key]) != perKey {
+			t.Fatalf("%s processed %d events, want %d", key, len(got[key]), perKey)
+		}
+		for i, seq := range got[key] {
+			if seq != i {
+				t.Fatalf("%s out of order: %v", key, got[key])
+			}
+		}
+	}
+	if len(failed) != len(keys) {
+		t.Fatalf("returned errors = %d, want %d", len(failed), len(keys))
+	}
+}
+
+func TestOrderedExecutorClose(t *testing.T) {
+	executor := newEventExecutor(1, true)
+
+	// 处理中的事件随关闭取消，队列中未处理的事件返回关闭错误，均由MQ重新投递
+	started := make(chan struct{})
+	running := make(chan error, 1)
+	go func() {
+		running <- executor.execute(context.Background(), "k", func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	<-started
+
+	queued := false
+	pending := make(chan error, 1)
+	go func() {
+		pending <- executor.execute(context.Background(), "k", func(context.Context) error {
+			queued = true
+			return nil
+		})
+	}()
+	for len(executor.partitions[0]) == 0 {
+		runtime.Gosched()
+	}
+
+	executor.close()
+	if err := <-running; !errors.Is(err, context.Canceled) {
+		t.Fatalf("running job err = %v", err)
+	}
+	if err := <-pending; !errors.Is(err, errExecutorClosed) || queued {
+		t.Fatalf("queued job err = %v, executed = %v", err, queued)
+	}
+	if err := executor.execute(context.Background(), "k", func(context.Context) error { return nil }); !errors.Is(err, errExecutorClosed) {
+		t.Fatalf("execute after close err = %v", err)
+	}
+}
+
+func TestConcurrentExecutorLimit(t *testing.T) {
+	executor := newEventExecutor(2, false)
+
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = executor.execute(context.Background(), "", func(context.Context) error {
+				mu.Lock()
+				running++
+				peak = max(peak, running)
+				mu.Unlock()
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Fatalf("peak concurrency = %d, want <= 2", peak)
+	}
+
+	// 无序模式同步返回处理结果
+	if err := executor.execute(context.Background(), "", func(context.Context) error { return errors.New("failed") }); err == nil {
+		t.Fatal("expected handler error")
+	}
+}
@@ -0,0 +1,123 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goRedis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm/clause"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/redis"
+)
+
+// Inbox 事件消费收件箱，保证同一事件对同一处理器至多执行一次
+type Inbox interface {
+	// Execute 在去重保护下执行处理函数
+	// 若(事件ID, 处理器)已处理过则跳过执行并返回 executed=false
+	Execute(ctx context.Context, eventID, eventType, handler string, fn func(ctx context.Context) error) (executed bool, err error)
+}
+
+// InboxPruner 可清理过期记录的收件箱
+type InboxPruner interface {
+	// Prune 清理早于 before 的处理记录
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// DBInbox 基于数据库的收件箱
+// 去重记录与处理器在同一事务中写入：处理器失败时记录随事务回滚，
+// 并发的重复投递会在唯一索引上等待首个事务提交后被判定为已处理
+type DBInbox struct {
+	txManager transactions.TransactionManager
+}
+
+// NewDBInbox 创建基于数据库的收件箱
+func NewDBInbox(txManager transactions.TransactionManager) *DBInbox {
+	return &DBInbox{txManager: txManager}
+}
+
+func (i *DBInbox) Execute(ctx context.Context, eventID, eventType, handler string, fn func(ctx context.Context) error) (bool, error) {
+	executed := false
+	err := i.txManager.Transaction(ctx, func(txCtx context.Context) error {
+		record := eventEntity.Inbox{
+			EventID:   eventID,
+			Handler:   handler,
+			EventType: eventType,
+		}
+		result := i.txManager.GetTx(txCtx).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+		if result.Error != nil {
+			return fmt.Errorf("write event inbox failed: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			// 已处理过
+			return nil
+		}
+		executed = true
+		return fn(txCtx)
+	})
+	return executed, err
+}
+
+func (i *DBInbox) Prune(ctx context.Context, before time.Time) error {
+	return i.txManager.GetTx(ctx).Where("created_at < ?", before).Delete(&eventEntity.Inbox{}).Error
+}
+
+// 收件箱redis记录状态
+const (
+	inboxStateProcessing = "processing"
+	inboxStateDone       = "done"
+)
+
+// inboxProcessingTTL 处理中状态的过期时间，避免进程崩溃后事件永远被跳过
+const inboxProcessingTTL = 5 * time.Minute
+
+// RedisInbox 基于redis的收件箱
+// 处理前以 SETNX 占位，成功后标记为已处理并保留 ttl，失败时释放占位以便重新投递
+type RedisInbox struct {
+	client goRedis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisInbox 创建基于redis的收件箱
+func NewRedisInbox(client goRedis.UniversalClient, ttl time.Duration) *RedisInbox {
+	if ttl <= 0 {
+		ttl = 7 * 24 * time.Hour
+	}
+	return &RedisInbox{client: client, ttl: ttl}
+}
+
+func (i *RedisInbox) Execute(ctx context.Context, eventID, eventType, handler string, fn func(ctx context.Context) error) (bool, error) {
+	key := i.key(eventID, handler)
+	ok, err := i.client.SetNX(ctx, key, inboxStateProcessing, inboxProcessingTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("write event inbox failed: %w", err)
+	}
+	if !ok {
+		state, err := i.client.Get(ctx, key).Result()
+		if err != nil && !errors.Is(err, goRedis.Nil) {
+			return false, fmt.Errorf("read event inbox failed: %w", err)
+		}
+		if state == inboxStateProcessing {
+			// 其它消费者正在处理，返回错误交由MQ稍后重新投递
+			return false, fmt.Errorf("event %s is being processed by handler %s", eventID, handler)
+		}
+		return false, nil
+	}
+
+	if err = fn(ctx); err != nil {
+		_ = i.client.Del(context.WithoutCancel(ctx), key).Err()
+		return true, err
+	}
+
+	if err = i.client.Set(context.WithoutCancel(ctx), key, inboxStateDone, i.ttl).Err(); err != nil {
+		return true, fmt.Errorf("mark event inbox failed: %w", err)
+	}
+	return true, nil
+}
+
+func (i *RedisInbox) key(eventID, handler string) string {
+	return redis.MainKey(fmt.Sprintf("event:inbox:%s:%s", handler, eventID))
+}
@@ -0,0 +1,76 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+// OutboxEventBus 基于事务发件箱的事件总线
+// 事件与业务数据写入同一事务的发件箱表，由 OutboxRelay 异步投递到MQ，
+// 避免事务提交后投递失败丢失事件，或事务回滚后投递出幽灵事件
+type OutboxEventBus struct {
+	txManager transactions.TransactionManager
+	scheduler *DBEventScheduler
+}
+
+// NewOutboxEventBus 创建基于事务发件箱的事件总线
+func NewOutboxEventBus(txManager transactions.TransactionManager) *OutboxEventBus {
+	return &OutboxEventBus{
+		txManager: txManager,
+		scheduler: NewDBEventScheduler(txManager),
+	}
+}
+
+// PublishEvent 发布事件(写入发件箱)
+func (b *OutboxEventBus) PublishEvent(ctx context.Context, event any) error {
+	eventType, data, err := marshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	// 保存发布方的追踪上下文，投递时恢复
+	headers := make(map[string]string)
+	injectTraceHeaders(ctx, headers)
+	headerData, err := sonic.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("marshal event headers failed: %w", err)
+	}
+
+	record := eventEntity.Outbox{
+		EventType:   eventType,
+		Payload:     string(data),
+		Headers:     string(headerData),
+		Status:      eventEntity.OutboxStatusPending,
+		NextRetryAt: time.Now(),
+	}
+	// 在事务上下文中调用时，与业务数据同一事务提交或回滚
+	if err = b.txManager.GetTx(ctx).Create(&record).Error; err != nil {
+		return fmt.Errorf("write event outbox failed: %w", err)
+	}
+	return nil
+}
+
+// PublishEventAt 在指定时间发布事件(写入定时事件表)
+func (b *OutboxEventBus) PublishEventAt(ctx context.Context, event any, at time.Time) error {
+	return b.scheduler.ScheduleEvent(ctx, event, at)
+}
+
+// PublishEventAfter 延迟指定时长后发布事件(写入定时事件表)
+func (b *OutboxEventBus) PublishEventAfter(ctx context.Context, event any, delay time.Duration) error {
+	return b.scheduler.ScheduleEvent(ctx, event, time.Now().Add(delay))
+}
+
+// SubscribeHandler 订阅事件处理器
+func (b *OutboxEventBus) SubscribeHandler(handler any) error {
+	if _, ok := handler.(interface{ InterestedEventTypes() []string }); ok {
+		// 实际订阅由ConsumerService处理
+		return nil
+	}
+	return fmt.Errorf("unsupported handler type: %T", handler)
+}
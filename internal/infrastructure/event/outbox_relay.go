@@ -0,0 +1,373 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+// lastErrorMaxLength 投递错误信息最大长度
+const lastErrorMaxLength = 500
+
+// outboxRelayOption 发件箱投递选项
+type outboxRelayOption struct {
+	pollInterval     time.Duration // 轮询间隔
+	batchSize        int           // 单批投递数量
+	maxAttempts      int           // 最大投递次数
+	retryInterval    time.Duration // 初始重试间隔
+	maxRetryInterval time.Duration // 最大重试间隔
+	retention        time.Duration // 已投递记录保留时长
+	pruneInterval    time.Duration // 清理间隔
+}
+
+// OutboxRelayOption 发件箱投递选项
+type OutboxRelayOption func(option *outboxRelayOption)
+
+func defaultOutboxRelayOptions() *outboxRelayOption {
+	return &outboxRelayOption{
+		pollInterval:     time.Second,
+		batchSize:        100,
+		maxAttempts:      10,
+		retryInterval:    time.Second,
+		maxRetryInterval: 5 * time.Minute,
+		retention:        24 * time.Hour,
+		pruneInterval:    10 * time.Minute,
+	}
+}
+
+// WithOutboxPollInterval 设置轮询间隔
+func WithOutboxPollInterval(interval time.Duration) OutboxRelayOption {
+	return func(option *outboxRelayOption) {
+		if interval > 0 {
+			option.pollInterval = interval
+		}
+	}
+}
+
+// WithOutboxBatchSize 设置单批投递数量
+func WithOutboxBatchSize(size int) OutboxRelayOption {
+	return func(option *outboxRelayOption) {
+		if size > 0 {
+			option.batchSize = size
+		}
+	}
+}
+
+// WithOutboxMaxAttempts 设置最大投递次数
+func WithOutboxMaxAttempts(attempts int) OutboxRelayOption {
+	return func(option *outboxRelayOption) {
+		if attempts > 0 {
+			option.maxAttempts = attempts
+		}
+	}
+}
+
+// WithOutboxRetryInterval 设置重试间隔(指数退避的初始值与上限)
+func WithOutboxRetryInterval(interval, maxInterval time.Duration) OutboxRelayOption {
+	return func(option *outboxRelayOption) {
+		if interval > 0 {
+			option.retryInterval = interval
+		}
+		if maxInterval > 0 {
+			option.maxRetryInterval = maxInterval
+		}
+	}
+}
+
+// WithOutboxRetention 设置已投递记录保留时长
+func WithOutboxRetention(retention time.Duration) OutboxRelayOption {
+	return func(option *outboxRelayOption) {
+		if retention > 0 {
+			option.retention = retention
+		}
+	}
+}
+
+// OutboxRelay 发件箱投递器
+// 按写入顺序投递待发送事件，失败时按指数退避重试，投递成功的记录标记后定期清理；
+// 同时投递定时事件表中已到期的事件
+type OutboxRelay struct {
+	txManager transactions.TransactionManager
+	publisher RawPublisher
+	logger    *zap.Logger
+	opts      *outboxRelayOption
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewOutboxRelay 创建发件箱投递器
+func NewOutboxRelay(
+	txManager transactions.TransactionManager,
+	publisher RawPublisher,
+	logger *zap.Logger,
+	opts ...OutboxRelayOption,
+) *OutboxRelay {
+	options := defaultOutboxRelayOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return &OutboxRelay{
+		txManager: txManager,
+		publisher: publisher,
+		logger:    logger,
+		opts:      options,
+	}
+}
+
+// Start 启动投递
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+	go r.run(ctx)
+	r.logger.Info("Event outbox relay started",
+		zap.Duration("pollInterval", r.opts.pollInterval),
+		zap.Int("batchSize", r.opts.batchSize),
+	)
+	return nil
+}
+
+// Stop 停止投递，等待当前批次完成
+func (r *OutboxRelay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.done:
+		r.logger.Info("Event outbox relay stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *OutboxRelay) run(ctx context.Context) {
+	defer close(r.done)
+
+	pollTicker := time.NewTicker(r.opts.pollInterval)
+	defer pollTicker.Stop()
+	pruneTicker := time.NewTicker(r.opts.pruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pollTicker.C:
+			r.drain(ctx)
+			r.drainScheduled(ctx)
+		case <-pruneTicker.C:
+			if err := r.Prune(ctx); err != nil {
+				r.logger.Error("Failed to prune event outbox", zap.Error(err))
+			}
+		}
+	}
+}
+
+// drain 持续投递直至没有满批的待投递事件
+func (r *OutboxRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivered, err := r.RelayBatch(ctx)
+		if err != nil {
+			r.logger.Error("Failed to relay event outbox", zap.Error(err))
+			return
+		}
+		if delivered < r.opts.batchSize {
+			return
+		}
+	}
+}
+
+// drainScheduled 持续投递直至没有满批的到期定时事件
+func (r *OutboxRelay) drainScheduled(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivered, err := r.RelayScheduledBatch(ctx)
+		if err != nil {
+			r.logger.Error("Failed to relay scheduled events", zap.Error(err))
+			return
+		}
+		if delivered < r.opts.batchSize {
+			return
+		}
+	}
+}
+
+// RelayBatch 投递一批待发送事件，返回成功投递数量
+// 记录按ID顺序加行锁投递，某条投递失败时中止本批次，保证同一发件箱内的事件顺序
+func (r *OutboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	var delivered int
+	err := r.txManager.Transaction(ctx, func(txCtx context.Context) error {
+		tx := r.txManager.GetTx(txCtx)
+
+		var records []eventEntity.Outbox
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("status = ?", eventEntity.OutboxStatusPending).
+			Order("id ASC").
+			Limit(r.opts.batchSize).
+			Find(&records).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range records {
+			record := &records[i]
+			// 队首事件尚未到重试时间，后续事件需等待以保证顺序
+			if record.NextRetryAt.After(now) {
+				return nil
+			}
+
+			if err := r.publisher.Publish(r.headersContext(txCtx, record.Headers, record.ID), record.EventType, []byte(record.Payload)); err != nil {
+				return r.markRetry(txCtx, record, err)
+			}
+
+			if err := tx.Model(&eventEntity.Outbox{}).Where("id = ?", record.ID).Updates(map[string]any{
+				"status":       eventEntity.OutboxStatusDelivered,
+				"attempts":     record.Attempts + 1,
+				"last_error":   "",
+				"delivered_at": time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+			delivered++
+		}
+		return nil
+	})
+	return delivered, err
+}
+
+// headersContext 从记录的消息头恢复事件发布方的追踪上下文
+func (r *OutboxRelay) headersContext(ctx context.Context, rawHeaders string, id uint64) context.Context {
+	if rawHeaders == "" {
+		return ctx
+	}
+	var headers map[string]string
+	if err := sonic.UnmarshalString(rawHeaders, &headers); err != nil {
+		r.logger.Warn("Failed to parse event headers", zap.Uint64("id", id), zap.Error(err))
+		return ctx
+	}
+	return extractTraceContext(ctx, headers)
+}
+
+// truncateError 截断错误信息
+func truncateError(err error) string {
+	lastError := err.Error()
+	if len(lastError) > lastErrorMaxLength {
+		lastError = lastError[:lastErrorMaxLength]
+	}
+	return lastError
+}
+
+// RelayScheduledBatch 投递一批到期的定时事件，返回处理数量
+// 定时事件之间无顺序要求，跳过已被其它实例锁定的记录以便并行投递
+func (r *OutboxRelay) RelayScheduledBatch(ctx context.Context) (int, error) {
+	var processed int
+	err := r.txManager.Transaction(ctx, func(txCtx context.Context) error {
+		tx := r.txManager.GetTx(txCtx)
+
+		var records []eventEntity.Scheduled
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Where("status = ? AND deliver_at <= ?", eventEntity.ScheduledStatusPending, time.Now()).
+			Order("deliver_at ASC").
+			Limit(r.opts.batchSize).
+			Find(&records).Error; err != nil {
+			return err
+		}
+
+		for i := range records {
+			record := &records[i]
+			updates := map[string]any{"attempts": record.Attempts + 1}
+
+			pubCtx := r.headersContext(txCtx, record.Headers, record.ID)
+			if err := r.publisher.Publish(pubCtx, record.EventType, []byte(record.Payload)); err != nil {
+				updates["last_error"] = truncateError(err)
+				if record.Attempts+1 >= r.opts.maxAttempts {
+					updates["status"] = eventEntity.ScheduledStatusFailed
+					r.logger.Error("Scheduled event exceeded max attempts",
+						zap.Uint64("scheduledID", record.ID),
+						zap.String("eventType", record.EventType),
+						zap.Error(err),
+					)
+				} else {
+					updates["deliver_at"] = time.Now().Add(r.backoff(record.Attempts + 1))
+					r.logger.Warn("Failed to publish scheduled event, will retry",
+						zap.Uint64("scheduledID", record.ID),
+						zap.String("eventType", record.EventType),
+						zap.Error(err),
+					)
+				}
+			} else {
+				updates["status"] = eventEntity.ScheduledStatusDelivered
+				updates["last_error"] = ""
+				updates["delivered_at"] = time.Now()
+			}
+
+			if err := tx.Model(&eventEntity.Scheduled{}).Where("id = ?", record.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+			processed++
+		}
+		return nil
+	})
+	return processed, err
+}
+
+// markRetry 记录投递失败，超过最大投递次数时标记为失败
+func (r *OutboxRelay) markRetry(ctx context.Context, record *eventEntity.Outbox, publishErr error) error {
+	attempts := record.Attempts + 1
+	lastError := truncateError(publishErr)
+
+	updates := map[string]any{
+		"attempts":   attempts,
+		"last_error": lastError,
+	}
+	if attempts >= r.opts.maxAttempts {
+		updates["status"] = eventEntity.OutboxStatusFailed
+		r.logger.Error("Event outbox record exceeded max attempts",
+			zap.Uint64("outboxID", record.ID),
+			zap.String("eventType", record.EventType),
+			zap.Int("attempts", attempts),
+			zap.Error(publishErr),
+		)
+	} else {
+		updates["next_retry_at"] = time.Now().Add(r.backoff(attempts))
+		r.logger.Warn("Failed to publish event outbox record, will retry",
+			zap.Uint64("outboxID", record.ID),
+			zap.String("eventType", record.EventType),
+			zap.Int("attempts", attempts),
+			zap.Error(publishErr),
+		)
+	}
+
+	return r.txManager.GetTx(ctx).Model(&eventEntity.Outbox{}).Where("id = ?", record.ID).Updates(updates).Error
+}
+
+// backoff 指数退避间隔
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	interval := r.opts.retryInterval
+	for i := 1; i < attempts; i++ {
+		interval *= 2
+		if interval >= r.opts.maxRetryInterval {
+			return r.opts.maxRetryInterval
+		}
+	}
+	return interval
+}
+
+// Prune 清理超过保留时长的已投递记录
+func (r *OutboxRelay) Prune(ctx context.Context) error {
+	before := time.Now().Add(-r.opts.retention)
+	if err := r.txManager.GetTx(ctx).
+		Where("status = ? AND delivered_at < ?", eventEntity.OutboxStatusDelivered, before).
+		Delete(&eventEntity.Outbox{}).Error; err != nil {
+		return err
+	}
+	return r.txManager.GetTx(ctx).
+		Where("status = ? AND delivered_at < ?", eventEntity.ScheduledStatusDelivered, before).
+		Delete(&eventEntity.Scheduled{}).Error
+}
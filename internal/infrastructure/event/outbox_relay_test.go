@@ -0,0 +1,155 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dysodeng/app/internal/infrastructure/event"
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+)
+
+// stubPublisher 记录发布的事件，failures 指定第N次(从1开始)发布返回错误
+type stubPublisher struct {
+	mu        sync.Mutex
+	calls     int
+	failures  map[int]bool
+	published []string
+}
+
+func (p *stubPublisher) Publish(_ context.Context, _ string, eventData []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.failures[p.calls] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, string(eventData))
+	return nil
+}
+
+func newOutbox(t *testing.T) transactions.TransactionManager {
+	conn := dbtest.Open(t, &eventEntity.Outbox{}, &eventEntity.Scheduled{})
+	return transactions.NewGormTransactionManager(conn)
+}
+
+func writeOutbox(t *testing.T, tx transactions.TransactionManager, orderIDs ...string) []string {
+	t.Helper()
+	bus := event.NewOutboxEventBus(tx)
+	payloads := make([]string, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		evt := event.NewDomainEvent("order.created", id, "order", orderCreated{OrderID: id})
+		if err := bus.PublishEvent(context.Background(), evt); err != nil {
+			t.Fatalf("write outbox failed: %v", err)
+		}
+	}
+	var records []eventEntity.Outbox
+	tx.GetTx(context.Background()).Order("id ASC").Find(&records)
+	for _, record := range records {
+		payloads = append(payloads, record.Payload)
+	}
+	return payloads
+}
+
+func TestOutboxRelayBatch(t *testing.T) {
+	ctx := context.Background()
+	tx := newOutbox(t)
+	payloads := writeOutbox(t, tx, "1", "2", "3")
+
+	publisher := &stubPublisher{failures: map[int]bool{2: true}}
+	relay := event.NewOutboxRelay(tx, publisher, zap.NewNop(), event.WithOutboxRetryInterval(time.Hour, time.Hour))
+
+	// 第二条投递失败时中止本批次，第三条不越过失败记录投递
+	delivered, err := relay.RelayBatch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivered != 1 || len(publisher.published) != 1 || publisher.published[0] != payloads[0] {
+		t.Fatalf("delivered = %d, published = %v", delivered, publisher.published)
+	}
+
+	var failed eventEntity.Outbox
+	tx.GetTx(ctx).Order("id ASC").Where("status = ?", eventEntity.OutboxStatusPending).First(&failed)
+	if failed.Attempts != 1 || failed.LastError == "" || !failed.NextRetryAt.After(time.Now()) {
+		t.Fatalf("failed record = %+v", failed)
+	}
+
+	// 未到重试时间，队首记录阻塞后续投递
+	if delivered, _ = relay.RelayBatch(ctx); delivered != 0 {
+		t.Fatalf("relay before retry time delivered %d", delivered)
+	}
+
+	tx.GetTx(ctx).Model(&eventEntity.Outbox{}).Where("id = ?", failed.ID).Update("next_retry_at", time.Now().Add(-time.Second))
+	if delivered, err = relay.RelayBatch(ctx); err != nil || delivered != 2 {
+		t.Fatalf("retry delivered = %d, err = %v", delivered, err)
+	}
+	if len(publisher.published) != 3 || publisher.published[1] != payloads[1] || publisher.published[2] != payloads[2] {
+		t.Fatalf("published out of order: %v", publisher.published)
+	}
+
+	var pending int64
+	tx.GetTx(ctx).Model(&eventEntity.Outbox{}).Where("status = ?", eventEntity.OutboxStatusPending).Count(&pending)
+	if pending != 0 {
+		t.Fatalf("pending records = %d", pending)
+	}
+}
+
+func TestOutboxRelayMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	tx := newOutbox(t)
+	writeOutbox(t, tx, "1")
+
+	publisher := &stubPublisher{failures: map[int]bool{1: true}}
+	relay := event.NewOutboxRelay(tx, publisher, zap.NewNop(), event.WithOutboxMaxAttempts(1))
+	if _, err := relay.RelayBatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var record eventEntity.Outbox
+	tx.GetTx(ctx).First(&record)
+	if record.Status != eventEntity.OutboxStatusFailed || record.Attempts != 1 {
+		t.Fatalf("record = %+v", record)
+	}
+
+	// 投递失败的记录不再阻塞后续事件
+	writeOutbox(t, tx, "2")
+	if delivered, err := relay.RelayBatch(ctx); err != nil || delivered != 1 {
+		t.Fatalf("delivered = %d, err = %v", delivered, err)
+	}
+}
+
+func TestOutboxRelayPrune(t *testing.T) {
+	ctx := context.Background()
+	tx := newOutbox(t)
+	writeOutbox(t, tx, "1", "2", "3")
+
+	relay := event.NewOutboxRelay(tx, &stubPublisher{}, zap.NewNop(), event.WithOutboxRetention(time.Hour))
+	if _, err := relay.RelayBatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 第一条超过保留时长，第二条仍在保留期内，第三条恢复为待投递
+	var records []eventEntity.Outbox
+	tx.GetTx(ctx).Order("id ASC").Find(&records)
+	tx.GetTx(ctx).Model(&eventEntity.Outbox{}).Where("id = ?", records[0].ID).Update("delivered_at", time.Now().Add(-2*time.Hour))
+	tx.GetTx(ctx).Model(&eventEntity.Outbox{}).Where("id = ?", records[2].ID).Updates(map[string]any{
+		"status":       eventEntity.OutboxStatusPending,
+		"delivered_at": nil,
+	})
+
+	if err := relay.Prune(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var remaining []eventEntity.Outbox
+	tx.GetTx(ctx).Order("id ASC").Find(&remaining)
+	if len(remaining) != 2 || remaining[0].ID != records[1].ID || remaining[1].ID != records[2].ID {
+		t.Fatalf("remaining = %+v", remaining)
+	}
+}
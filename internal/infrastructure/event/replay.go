@@ -0,0 +1,112 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+)
+
+// ReplayOptions 事件回放选项
+type ReplayOptions struct {
+	DryRun          bool // 仅统计匹配的事件，不执行处理器
+	ContinueOnError bool // 处理器失败时继续回放后续事件
+}
+
+// ReplayResult 事件回放结果
+type ReplayResult struct {
+	Matched int // 匹配的事件数量
+	Handled int // 处理成功数量
+	Failed  int // 处理失败数量
+}
+
+// EventReplayer 事件回放器
+// 从事件存储读取事件流并直接分发给指定处理器，不经过MQ与收件箱去重，
+// 处理器需自行保证重复处理的正确性(如重建读模型前先清空)
+type EventReplayer struct {
+	store   *EventStore
+	schemas *SchemaRegistry
+	logger  *zap.Logger
+}
+
+// NewEventReplayer 创建事件回放器
+func NewEventReplayer(store *EventStore, schemas *SchemaRegistry, logger *zap.Logger) *EventReplayer {
+	return &EventReplayer{
+		store:   store,
+		schemas: schemas,
+		logger:  logger,
+	}
+}
+
+// Replay 按查询条件回放事件到处理器，仅回放处理器关注的事件类型
+func (r *EventReplayer) Replay(ctx context.Context, query EventStoreQuery, handler any, opts ReplayOptions) (*ReplayResult, error) {
+	eventHandler, ok := handler.(Handler)
+	if !ok {
+		return nil, fmt.Errorf("handler %T does not implement EventHandler interface", handler)
+	}
+
+	interested := eventHandler.InterestedEventTypes()
+	if len(query.EventTypes) == 0 {
+		query.EventTypes = interested
+	} else {
+		query.EventTypes = slices.DeleteFunc(slices.Clone(query.EventTypes), func(eventType string) bool {
+			return !slices.Contains(interested, eventType)
+		})
+	}
+	result := &ReplayResult{}
+	if len(query.EventTypes) == 0 {
+		return result, nil
+	}
+
+	wrapper := &handlerWrapper{handler: eventHandler, logger: r.logger}
+	err := r.store.Stream(ctx, query, func(record *eventEntity.StoredEvent) error {
+		result.Matched++
+		if opts.DryRun {
+			return nil
+		}
+
+		data, err := r.eventData(record)
+		if err == nil {
+			err = wrapper.handle(ctx, data)
+		}
+		if err != nil {
+			result.Failed++
+			r.logger.Error("Failed to replay event",
+				zap.String("eventID", record.EventID),
+				zap.String("eventType", record.EventType),
+				zap.String("handlerType", wrapper.name()),
+				zap.Error(err),
+			)
+			if opts.ContinueOnError {
+				return nil
+			}
+			return fmt.Errorf("replay event %s failed: %w", record.EventID, err)
+		}
+		result.Handled++
+		return nil
+	})
+	return result, err
+}
+
+// eventData 还原事件数据，旧版本事件按 SchemaRegistry 升级到当前版本
+func (r *EventReplayer) eventData(record *eventEntity.StoredEvent) (*eventData, error) {
+	var data eventData
+	if err := sonic.Unmarshal([]byte(record.Payload), &data); err != nil {
+		return nil, fmt.Errorf("unmarshal stored event failed: %w", err)
+	}
+	data.ID = record.EventID
+	data.Type = record.EventType
+	data.Version = record.EventVersion
+
+	if r.schemas != nil {
+		var err error
+		if data.Data, data.Version, err = r.schemas.Upcast(data.Type, data.Version, data.Data); err != nil {
+			return nil, err
+		}
+	}
+	return &data, nil
+}
@@ -0,0 +1,57 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+// DBEventScheduler 基于数据库的定时事件调度器
+// 定时事件写入定时事件表，由 OutboxRelay 在到期后投递到MQ；
+// 用于MQ不支持延时消息，或需要与业务数据同一事务提交的场景
+type DBEventScheduler struct {
+	txManager transactions.TransactionManager
+}
+
+// NewDBEventScheduler 创建基于数据库的定时事件调度器
+func NewDBEventScheduler(txManager transactions.TransactionManager) *DBEventScheduler {
+	return &DBEventScheduler{txManager: txManager}
+}
+
+// ScheduleEvent 调度事件在指定时间投递
+func (s *DBEventScheduler) ScheduleEvent(ctx context.Context, event any, deliverAt time.Time) error {
+	eventType, data, err := marshalEvent(event)
+	if err != nil {
+		return err
+	}
+	return s.Schedule(ctx, eventType, data, deliverAt)
+}
+
+// Schedule 调度已序列化的事件在指定时间投递
+func (s *DBEventScheduler) Schedule(ctx context.Context, eventType string, eventData []byte, deliverAt time.Time) error {
+	// 保存发布方的追踪上下文，投递时恢复
+	headers := make(map[string]string)
+	injectTraceHeaders(ctx, headers)
+	headerData, err := sonic.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("marshal event headers failed: %w", err)
+	}
+
+	record := eventEntity.Scheduled{
+		EventType: eventType,
+		Payload:   string(eventData),
+		Headers:   string(headerData),
+		Status:    eventEntity.ScheduledStatusPending,
+		DeliverAt: deliverAt,
+	}
+	// 在事务上下文中调用时，与业务数据同一事务提交或回滚
+	if err = s.txManager.GetTx(ctx).Create(&record).Error; err != nil {
+		return fmt.Errorf("write scheduled event failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// InitialSchemaVersion 事件初始版本，未携带版本号的历史消息视为该版本
+const InitialSchemaVersion = 1
+
+// Upcaster 事件数据升级函数，将 vN 版本的事件数据转换为 vN+1 版本
+type Upcaster func(data json.RawMessage) (json.RawMessage, error)
+
+// eventSchema 事件结构定义
+type eventSchema struct {
+	version   int              // 当前版本
+	upcasters map[int]Upcaster // 源版本 => 升级函数
+}
+
+// SchemaRegistry 事件结构版本注册表
+// 消费前将旧版本事件数据逐级升级到当前版本，避免事件结构变更后队列中的旧消息无法解析
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]*eventSchema
+}
+
+// NewSchemaRegistry 创建事件结构版本注册表
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{
+		schemas: make(map[string]*eventSchema),
+	}
+}
+
+// Register 注册事件类型及其当前版本
+func (r *SchemaRegistry) Register(eventType string, version int) *SchemaRegistry {
+	if version < InitialSchemaVersion {
+		version = InitialSchemaVersion
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if schema, ok := r.schemas[eventType]; ok {
+		schema.version = version
+		return r
+	}
+	r.schemas[eventType] = &eventSchema{
+		version:   version,
+		upcasters: make(map[int]Upcaster),
+	}
+	return r
+}
+
+// RegisterUpcaster 注册事件数据升级函数，将 fromVersion 版本升级到 fromVersion+1 版本
+func (r *SchemaRegistry) RegisterUpcaster(eventType string, fromVersion int, upcaster Upcaster) *SchemaRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	schema, ok := r.schemas[eventType]
+	if !ok {
+		schema = &eventSchema{
+			version:   fromVersion + 1,
+			upcasters: make(map[int]Upcaster),
+		}
+		r.schemas[eventType] = schema
+	}
+	schema.upcasters[fromVersion] = upcaster
+	return r
+}
+
+// Known 事件类型是否已注册
+func (r *SchemaRegistry) Known(eventType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.schemas[eventType]
+	return ok
+}
+
+// CurrentVersion 事件类型当前版本
+func (r *SchemaRegistry) CurrentVersion(eventType string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[eventType]
+	if !ok {
+		return 0, false
+	}
+	return schema.version, true
+}
+
+// EventTypes 已注册的事件类型
+func (r *SchemaRegistry) EventTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.schemas))
+	for eventType := range r.schemas {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Upcast 将事件数据从 version 版本逐级升级到当前版本，返回升级后的数据与版本
+func (r *SchemaRegistry) Upcast(eventType string, version int, data json.RawMessage) (json.RawMessage, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[eventType]
+	if !ok {
+		return nil, version, fmt.Errorf("unknown event schema: %s", eventType)
+	}
+
+	if version < InitialSchemaVersion {
+		version = InitialSchemaVersion
+	}
+	if version > schema.version {
+		return nil, version, fmt.Errorf("event %s version %d is newer than supported version %d", eventType, version, schema.version)
+	}
+
+	for version < schema.version {
+		upcaster, ok := schema.upcasters[version]
+		if !ok {
+			return nil, version, fmt.Errorf("missing upcaster for event %s from version %d", eventType, version)
+		}
+		upcasted, err := upcaster(data)
+		if err != nil {
+			return nil, version, fmt.Errorf("upcast event %s from version %d failed: %w", eventType, version, err)
+		}
+		data = upcasted
+		version++
+	}
+	return data, version, nil
+}
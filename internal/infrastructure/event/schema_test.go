@@ -0,0 +1,105 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dysodeng/mq/message"
+	"go.uber.org/zap"
+
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+)
+
+func TestSchemaRegistryUpcast(t *testing.T) {
+	registry := NewSchemaRegistry().
+		Register("order.created", 3).
+		RegisterUpcaster("order.created", 1, func(data json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(strings.Replace(string(data), `"id"`, `"order_id"`, 1)), nil
+		}).
+		RegisterUpcaster("order.created", 2, func(data json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(strings.TrimSuffix(string(data), "}") + `,"currency":"CNY"}`), nil
+		})
+
+	// 未携带版本号的历史消息按初始版本逐级升级
+	data, version, err := registry.Upcast("order.created", 0, json.RawMessage(`{"id":"1"}`))
+	if err != nil || version != 3 || string(data) != `{"order_id":"1","currency":"CNY"}` {
+		t.Fatalf("upcast = %s, %d, %v", data, version, err)
+	}
+
+	if data, version, err = registry.Upcast("order.created", 3, json.RawMessage(`{"order_id":"1"}`)); err != nil || version != 3 || string(data) != `{"order_id":"1"}` {
+		t.Fatalf("current version upcast = %s, %d, %v", data, version, err)
+	}
+
+	cases := map[string]func() error{
+		"unknown type": func() error {
+			_, _, err := registry.Upcast("order.paid", 1, nil)
+			return err
+		},
+		"newer version": func() error {
+			_, _, err := registry.Upcast("order.created", 4, nil)
+			return err
+		},
+		"missing upcaster": func() error {
+			_, _, err := NewSchemaRegistry().Register("order.paid", 2).Upcast("order.paid", 1, nil)
+			return err
+		},
+		"upcaster error": func() error {
+			_, _, err := NewSchemaRegistry().
+				RegisterUpcaster("order.paid", 1, func(json.RawMessage) (json.RawMessage, error) { return nil, errors.New("bad data") }).
+				Upcast("order.paid", 1, nil)
+			return err
+		},
+	}
+	for name, upcast := range cases {
+		if err := upcast(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestUpcastFailureDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	tx := transactions.NewGormTransactionManager(dbtest.Open(t, &eventEntity.DeadLetter{}))
+	queue := NewDeadLetterQueue(tx, &recordingPublisher{})
+
+	handler := &failingHandler{}
+	consumer := NewEventConsumerService(nil, zap.NewNop(),
+		WithSchemaRegistry(NewSchemaRegistry().Register("order.created", 1)),
+		WithDeadLetter(queue),
+	)
+	if err := consumer.SubscribeHandler(handler); err != nil {
+		t.Fatal(err)
+	}
+
+	// 生产方版本高于消费方支持的版本，写入死信并确认消息，不再重复投递
+	payload := []byte(`{"id":"e2","type":"order.created","version":2,"data":{"order_id":"1"}}`)
+	if err := consumer.handleMessage(ctx, message.New("order.created", payload)); err != nil {
+		t.Fatalf("handle message: %v", err)
+	}
+	if handler.calls != 0 {
+		t.Fatalf("handler should not run, calls = %d", handler.calls)
+	}
+
+	records, total, err := queue.List(ctx, DeadLetterQuery{})
+	if err != nil || total != 1 {
+		t.Fatalf("dead letters = %d, err = %v", total, err)
+	}
+	var replay eventData
+	if err = json.Unmarshal([]byte(records[0].Payload), &replay); err != nil {
+		t.Fatal(err)
+	}
+	if records[0].Handler != upcastDeadLetterHandler || replay.Version != 2 || string(replay.Data) != `{"order_id":"1"}` {
+		t.Fatalf("dead letter = %+v", records[0])
+	}
+
+	// 未配置死信时返回错误
+	consumer.deadLetter = nil
+	if err = consumer.handleMessage(ctx, message.New("order.created", payload)); err == nil {
+		t.Fatal("expected upcast error without dead letter")
+	}
+}
@@ -0,0 +1,124 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domainEvent "github.com/dysodeng/app/internal/domain/shared/event"
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+// defaultStoreStreamBatchSize 事件流默认批量读取数量
+const defaultStoreStreamBatchSize = 500
+
+// EventStoreQuery 事件存储查询条件
+type EventStoreQuery struct {
+	AggregateID   string
+	AggregateName string
+	EventTypes    []string
+	StartTime     time.Time // 事件发生时间起(含)
+	EndTime       time.Time // 事件发生时间止(不含)
+}
+
+// EventStore 基于数据库的只追加事件存储
+// 记录经MQ发布的全部事件，用于重建读模型与问题排查
+type EventStore struct {
+	txManager transactions.TransactionManager
+}
+
+// NewEventStore 创建事件存储
+func NewEventStore(txManager transactions.TransactionManager) *EventStore {
+	return &EventStore{txManager: txManager}
+}
+
+// Append 追加事件，同一事件ID重复追加时忽略(发件箱重试、死信重放会重复发布同一事件)
+func (s *EventStore) Append(ctx context.Context, eventType string, payload []byte) error {
+	var data eventData
+	if err := sonic.Unmarshal(payload, &data); err != nil {
+		return fmt.Errorf("unmarshal event failed: %w", err)
+	}
+	if data.ID == "" {
+		data.ID = domainEvent.NewEventID()
+	}
+	if data.Type == "" {
+		data.Type = eventType
+	}
+	if data.Version <= 0 {
+		data.Version = InitialSchemaVersion
+	}
+	if data.Timestamp.IsZero() {
+		data.Timestamp = time.Now()
+	}
+
+	record := eventEntity.StoredEvent{
+		EventID:       data.ID,
+		EventType:     data.Type,
+		EventVersion:  data.Version,
+		AggregateID:   data.AggregateID,
+		AggregateName: data.AggregateName,
+		Payload:       string(payload),
+		OccurredAt:    data.Timestamp,
+	}
+	err := s.txManager.GetTx(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).
+		Create(&record).Error
+	if err != nil {
+		return fmt.Errorf("append event to store failed: %w", err)
+	}
+	return nil
+}
+
+// Stream 按记录顺序遍历符合条件的全部事件，fn 返回错误时停止遍历
+func (s *EventStore) Stream(ctx context.Context, query EventStoreQuery, fn func(record *eventEntity.StoredEvent) error) error {
+	var lastID uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var records []eventEntity.StoredEvent
+		if err := s.filter(ctx, query).
+			Where("id > ?", lastID).
+			Order("id ASC").
+			Limit(defaultStoreStreamBatchSize).
+			Find(&records).Error; err != nil {
+			return err
+		}
+
+		for i := range records {
+			if err := fn(&records[i]); err != nil {
+				return err
+			}
+		}
+		if len(records) < defaultStoreStreamBatchSize {
+			return nil
+		}
+		lastID = records[len(records)-1].ID
+	}
+}
+
+func (s *EventStore) filter(ctx context.Context, query EventStoreQuery) *gorm.DB {
+	tx := s.txManager.GetTx(ctx).Model(&eventEntity.StoredEvent{})
+	if query.AggregateID != "" {
+		tx = tx.Where("aggregate_id = ?", query.AggregateID)
+	}
+	if query.AggregateName != "" {
+		tx = tx.Where("aggregate_name = ?", query.AggregateName)
+	}
+	if len(query.EventTypes) > 0 {
+		tx = tx.Where("event_type IN ?", query.EventTypes)
+	}
+	if !query.StartTime.IsZero() {
+		tx = tx.Where("occurred_at >= ?", query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		tx = tx.Where("occurred_at < ?", query.EndTime)
+	}
+	return tx
+}
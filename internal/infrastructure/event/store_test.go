@@ -0,0 +1,154 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dysodeng/mq/message"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/dysodeng/app/internal/infrastructure/event"
+	eventEntity "github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+)
+
+// stubProducer 记录发送的消息，fail 为true时发送失败
+type stubProducer struct {
+	fail bool
+	sent []*message.Message
+}
+
+func (p *stubProducer) Send(_ context.Context, msg *message.Message) error {
+	if p.fail {
+		return errors.New("broker unavailable")
+	}
+	p.sent = append(p.sent, msg)
+	return nil
+}
+
+func (p *stubProducer) SendDelay(ctx context.Context, msg *message.Message, _ time.Duration) error {
+	return p.Send(ctx, msg)
+}
+
+func (p *stubProducer) SendBatch(ctx context.Context, msgs []*message.Message) error {
+	for _, msg := range msgs {
+		if err := p.Send(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *stubProducer) Close() error { return nil }
+
+func TestMQEventBusAppendStore(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.Open(t, &eventEntity.StoredEvent{})
+	tx := transactions.NewGormTransactionManager(conn)
+	store := event.NewEventStore(tx)
+	producer := &stubProducer{}
+	core, logs := observer.New(zap.ErrorLevel)
+	bus := event.NewMQEventBus(producer, event.WithBusEventStore(store), event.WithBusLogger(zap.New(core)))
+
+	payload := []byte(`{"id":"e1","type":"order.created","version":1,"aggregate_id":"1","aggregate_name":"order","data":{}}`)
+
+	// 发送失败时事件已写入存储，直接发布时记录未发送的事件；重新发布时按事件ID去重
+	producer.fail = true
+	if err := bus.Publish(ctx, "order.created", payload); err == nil {
+		t.Fatal("expected send error")
+	}
+	if unsent := logs.FilterMessage("Stored event was not sent").All(); len(unsent) != 1 || unsent[0].ContextMap()["eventID"] != "e1" {
+		t.Fatalf("unsent logs = %v", unsent)
+	}
+	producer.fail = false
+	if err := bus.Publish(ctx, "order.created", payload); err != nil {
+		t.Fatal(err)
+	}
+	if len(producer.sent) != 1 {
+		t.Fatalf("sent = %d, want 1", len(producer.sent))
+	}
+
+	var count int64
+	conn.Model(&eventEntity.StoredEvent{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("stored events = %d, want 1", count)
+	}
+
+	// 存储写入失败时不发送
+	if err := bus.Publish(ctx, "order.created", []byte(`not json`)); err == nil {
+		t.Fatal("expected append error")
+	}
+	if len(producer.sent) != 1 {
+		t.Fatalf("sent after append failure = %d, want 1", len(producer.sent))
+	}
+
+	// 调用方事务回滚时存储记录随之回滚
+	_ = tx.Transaction(ctx, func(txCtx context.Context) error {
+		if err := bus.Publish(txCtx, "order.created", []byte(`{"id":"e2","type":"order.created"}`)); err != nil {
+			t.Fatal(err)
+		}
+		return errors.New("rollback")
+	})
+	conn.Model(&eventEntity.StoredEvent{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("stored events after rollback = %d, want 1", count)
+	}
+}
+
+func TestEventStoreStream(t *testing.T) {
+	ctx := context.Background()
+	store := event.NewEventStore(transactions.NewGormTransactionManager(dbtest.Open(t, &eventEntity.StoredEvent{})))
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []string{
+		`{"id":"e1","type":"order.created","aggregate_id":"1","aggregate_name":"order","timestamp":"2026-01-01T00:00:00Z"}`,
+		`{"id":"e2","type":"order.paid","aggregate_id":"1","aggregate_name":"order","timestamp":"2026-01-01T01:00:00Z"}`,
+		`{"id":"e3","type":"order.created","aggregate_id":"2","aggregate_name":"order","timestamp":"2026-01-01T02:00:00Z"}`,
+	}
+	for _, payload := range events {
+		if err := store.Append(ctx, "", []byte(payload)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		name  string
+		query event.EventStoreQuery
+		want  []string
+	}{
+		{"all", event.EventStoreQuery{}, []string{"e1", "e2", "e3"}},
+		{"aggregate", event.EventStoreQuery{AggregateName: "order", AggregateID: "1"}, []string{"e1", "e2"}},
+		{"types", event.EventStoreQuery{EventTypes: []string{"order.created"}}, []string{"e1", "e3"}},
+		{"time range", event.EventStoreQuery{StartTime: base.Add(time.Hour), EndTime: base.Add(2 * time.Hour)}, []string{"e2"}},
+	}
+	for _, c := range cases {
+		var got []string
+		err := store.Stream(ctx, c.query, func(record *eventEntity.StoredEvent) error {
+			got = append(got, record.EventID)
+			return nil
+		})
+		if err != nil || len(got) != len(c.want) {
+			t.Fatalf("%s: got %v, err = %v", c.name, got, err)
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Fatalf("%s: got %v, want %v", c.name, got, c.want)
+			}
+		}
+	}
+
+	// 回调返回错误时停止遍历
+	stop := errors.New("stop")
+	calls := 0
+	err := store.Stream(ctx, event.EventStoreQuery{}, func(*eventEntity.StoredEvent) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("err = %v, calls = %d", err, calls)
+	}
+}
@@ -0,0 +1,238 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+// SyncDispatchMode 进程内事件分发模式
+type SyncDispatchMode string
+
+const (
+	// SyncDispatchImmediate 发布时立即分发，处理器错误返回给发布方
+	SyncDispatchImmediate SyncDispatchMode = "immediate"
+	// SyncDispatchAfterCommit 在事务中发布时延迟到事务提交后分发，不在事务中时立即分发
+	SyncDispatchAfterCommit SyncDispatchMode = "after_commit"
+)
+
+// RecordedEvent 进程内总线记录的已分发事件
+type RecordedEvent struct {
+	ID            string
+	Type          string
+	Version       int
+	AggregateID   string
+	AggregateName string
+	Data          []byte // 事件数据JSON
+	Event         any    // 原始事件对象
+}
+
+// SyncBusOption 进程内事件总线选项
+type SyncBusOption func(b *SyncEventBus)
+
+// WithSyncDispatchMode 设置分发模式
+func WithSyncDispatchMode(mode SyncDispatchMode) SyncBusOption {
+	return func(b *SyncEventBus) {
+		if mode != "" {
+			b.mode = mode
+		}
+	}
+}
+
+// WithSyncRecording 记录已分发的事件，用于测试断言
+// 事件在分发时记录，after_commit 模式下事务回滚的事件不会记录，延时事件到期分发后才记录
+func WithSyncRecording() SyncBusOption {
+	return func(b *SyncEventBus) {
+		b.recording = true
+	}
+}
+
+// SyncEventBus 进程内同步事件总线
+// 事件经与MQ消息相同的序列化后直接分发给已订阅的处理器，不经过MQ，
+// 适用于测试与单体部署
+type SyncEventBus struct {
+	mu        sync.RWMutex
+	handlers  map[string][]*handlerWrapper
+	logger    *zap.Logger
+	mode      SyncDispatchMode
+	recording bool
+	recorded  []RecordedEvent
+}
+
+// NewSyncEventBus 创建进程内同步事件总线
+func NewSyncEventBus(logger *zap.Logger, opts ...SyncBusOption) *SyncEventBus {
+	b := &SyncEventBus{
+		handlers: make(map[string][]*handlerWrapper),
+		logger:   logger,
+		mode:     SyncDispatchImmediate,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// PublishEvent 发布事件
+func (b *SyncEventBus) PublishEvent(ctx context.Context, event any) error {
+	data, err := b.prepare(event)
+	if err != nil {
+		return err
+	}
+
+	if b.afterCommit(ctx, func(ctx context.Context) {
+		if err := b.deliver(ctx, data, event); err != nil {
+			b.logger.Error("Failed to dispatch event after commit",
+				zap.String("eventID", data.ID),
+				zap.String("eventType", data.Type),
+				zap.Error(err),
+			)
+		}
+	}) {
+		return nil
+	}
+
+	return b.deliver(ctx, data, event)
+}
+
+// PublishEventAt 在指定时间发布事件
+func (b *SyncEventBus) PublishEventAt(ctx context.Context, event any, at time.Time) error {
+	return b.PublishEventAfter(ctx, event, time.Until(at))
+}
+
+// PublishEventAfter 延迟指定时长后发布事件
+// 延时事件由进程内定时器分发，进程退出时未到期的事件将丢失
+func (b *SyncEventBus) PublishEventAfter(ctx context.Context, event any, delay time.Duration) error {
+	if delay <= 0 {
+		return b.PublishEvent(ctx, event)
+	}
+
+	data, err := b.prepare(event)
+	if err != nil {
+		return err
+	}
+
+	schedule := func(ctx context.Context) {
+		ctx = context.WithoutCancel(ctx)
+		time.AfterFunc(delay, func() {
+			if err := b.deliver(ctx, data, event); err != nil {
+				b.logger.Error("Failed to dispatch delayed event",
+					zap.String("eventID", data.ID),
+					zap.String("eventType", data.Type),
+					zap.Error(err),
+				)
+			}
+		})
+	}
+	if !b.afterCommit(ctx, schedule) {
+		schedule(ctx)
+	}
+	return nil
+}
+
+// prepare 序列化事件
+func (b *SyncEventBus) prepare(event any) (*eventData, error) {
+	eventType, payload, err := marshalEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var data eventData
+	if err = sonic.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal event failed: %w", err)
+	}
+	if data.Type == "" {
+		data.Type = eventType
+	}
+	return &data, nil
+}
+
+// deliver 记录并分发事件
+func (b *SyncEventBus) deliver(ctx context.Context, data *eventData, event any) error {
+	if b.recording {
+		b.record(data, event)
+	}
+	return b.dispatch(ctx, data)
+}
+
+// afterCommit after_commit 模式下在事务中注册提交回调，返回是否已注册
+func (b *SyncEventBus) afterCommit(ctx context.Context, fn func(ctx context.Context)) bool {
+	if b.mode != SyncDispatchAfterCommit {
+		return false
+	}
+	return transactions.AfterCommit(ctx, fn)
+}
+
+// SubscribeHandler 订阅事件处理器
+func (b *SyncEventBus) SubscribeHandler(handler any) error {
+	eventHandler, ok := handler.(Handler)
+	if !ok {
+		return fmt.Errorf("handler %T does not implement EventHandler interface", handler)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wrapper := &handlerWrapper{
+		handler: eventHandler,
+		logger:  b.logger,
+	}
+	for _, eventType := range eventHandler.InterestedEventTypes() {
+		if eventType == "" {
+			continue
+		}
+		b.handlers[eventType] = append(b.handlers[eventType], wrapper)
+	}
+	return nil
+}
+
+// Published 已记录的分发事件
+func (b *SyncEventBus) Published() []RecordedEvent {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	recorded := make([]RecordedEvent, len(b.recorded))
+	copy(recorded, b.recorded)
+	return recorded
+}
+
+// Reset 清空已记录的分发事件
+func (b *SyncEventBus) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.recorded = nil
+}
+
+func (b *SyncEventBus) record(data *eventData, event any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.recorded = append(b.recorded, RecordedEvent{
+		ID:            data.ID,
+		Type:          data.Type,
+		Version:       data.Version,
+		AggregateID:   data.AggregateID,
+		AggregateName: data.AggregateName,
+		Data:          data.Data,
+		Event:         event,
+	})
+}
+
+// dispatch 按订阅顺序分发事件，汇总处理器错误
+func (b *SyncEventBus) dispatch(ctx context.Context, data *eventData) error {
+	b.mu.RLock()
+	handlers := b.handlers[data.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, wrapper := range handlers {
+		if err := wrapper.handle(ctx, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
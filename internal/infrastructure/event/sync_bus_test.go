@@ -0,0 +1,162 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/dysodeng/app/internal/infrastructure/event"
+	"github.com/dysodeng/app/internal/infrastructure/event/eventtest"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
+)
+
+type orderCreated struct {
+	OrderID string `json:"order_id"`
+}
+
+type orderCreatedHandler struct {
+	event.DomainEventHandler[orderCreated]
+	handled []string
+	tenants []string
+}
+
+func (h *orderCreatedHandler) Handle(ctx context.Context, e any) error {
+	evt, err := h.ParseDomainEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	h.handled = append(h.handled, evt.Payload().OrderID)
+	id, _ := tenant.FromContext(ctx)
+	h.tenants = append(h.tenants, id)
+	return nil
+}
+
+func (h *orderCreatedHandler) InterestedEventTypes() []string {
+	return []string{"order.created"}
+}
+
+func TestSyncEventBus(t *testing.T) {
+	handler := &orderCreatedHandler{}
+	bus := eventtest.NewBus(t, handler)
+
+	evt := event.NewDomainEvent("order.created", "1", "order", orderCreated{OrderID: "1"})
+	if err := bus.PublishEvent(context.Background(), evt); err != nil {
+		t.Fatalf("publish event failed: %v", err)
+	}
+
+	if len(handler.handled) != 1 || handler.handled[0] != "1" {
+		t.Fatalf("expected handler to receive order 1, got %v", handler.handled)
+	}
+
+	record := eventtest.AssertPublished(t, bus, "order.created")
+	if record.ID != evt.EventID() {
+		t.Fatalf("expected event id %s, got %s", evt.EventID(), record.ID)
+	}
+	if payload := eventtest.DecodePayload[orderCreated](t, record); payload.OrderID != "1" {
+		t.Fatalf("expected order 1, got %s", payload.OrderID)
+	}
+	eventtest.AssertNotPublished(t, bus, "order.paid")
+
+	bus.Reset()
+	eventtest.AssertPublishedCount(t, bus, "order.created", 0)
+}
+
+func TestSyncEventBusTenantEnvelope(t *testing.T) {
+	handler := &orderCreatedHandler{}
+	bus := eventtest.NewBus(t, handler)
+
+	// 发布方上下文无租户时，按事件信封中的租户执行处理器
+	evt := event.NewDomainEvent("order.created", "1", "order", orderCreated{OrderID: "1"}).(event.BaseDomainEvent[orderCreated])
+	evt.Tenant = "t1"
+	if err := bus.PublishEvent(context.Background(), evt); err != nil {
+		t.Fatalf("publish event failed: %v", err)
+	}
+
+	if len(handler.tenants) != 1 || handler.tenants[0] != "t1" {
+		t.Fatalf("expected handler to run under tenant t1, got %v", handler.tenants)
+	}
+}
+
+func TestSyncEventBusAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	handler := &orderCreatedHandler{}
+	bus := event.NewSyncEventBus(zap.NewNop(), event.WithSyncDispatchMode(event.SyncDispatchAfterCommit), event.WithSyncRecording())
+	if err := bus.SubscribeHandler(handler); err != nil {
+		t.Fatal(err)
+	}
+	tx := transactions.NewGormTransactionManager(dbtest.Open(t))
+	publish := func(ctx context.Context, orderID string) {
+		t.Helper()
+		if err := bus.PublishEvent(ctx, event.NewDomainEvent("order.created", orderID, "order", orderCreated{OrderID: orderID})); err != nil {
+			t.Fatalf("publish event failed: %v", err)
+		}
+	}
+	errRollback := errors.New("rollback")
+
+	// 提交后分发
+	err := tx.Transaction(ctx, func(txCtx context.Context) error {
+		publish(txCtx, "commit")
+		if len(handler.handled) != 0 {
+			t.Fatalf("event dispatched before commit: %v", handler.handled)
+		}
+		return nil
+	})
+	if err != nil || len(handler.handled) != 1 || handler.handled[0] != "commit" {
+		t.Fatalf("commit: handled = %v, err = %v", handler.handled, err)
+	}
+
+	// 回滚后丢弃
+	_ = tx.Transaction(ctx, func(txCtx context.Context) error {
+		publish(txCtx, "rollback")
+		return errRollback
+	})
+	if len(handler.handled) != 1 {
+		t.Fatalf("rollback: handled = %v", handler.handled)
+	}
+	// 回滚的事件未分发，也不记录
+	eventtest.AssertPublishedCount(t, bus, "order.created", 1)
+
+	// 嵌套事务的事件随最外层事务提交分发，回滚到保存点的嵌套事务中的事件丢弃
+	err = tx.Transaction(ctx, func(txCtx context.Context) error {
+		if err := tx.Transaction(txCtx, func(nestedCtx context.Context) error {
+			publish(nestedCtx, "nested")
+			return nil
+		}); err != nil {
+			return err
+		}
+		_ = tx.Transaction(txCtx, func(nestedCtx context.Context) error {
+			publish(nestedCtx, "savepoint")
+			return errRollback
+		})
+		if len(handler.handled) != 1 {
+			t.Fatalf("nested event dispatched before outer commit: %v", handler.handled)
+		}
+		return nil
+	})
+	if err != nil || len(handler.handled) != 2 || handler.handled[1] != "nested" {
+		t.Fatalf("nested: handled = %v, err = %v", handler.handled, err)
+	}
+
+	// 外层事务回滚时嵌套事务中的事件一并丢弃
+	_ = tx.Transaction(ctx, func(txCtx context.Context) error {
+		_ = tx.Transaction(txCtx, func(nestedCtx context.Context) error {
+			publish(nestedCtx, "outer-rollback")
+			return nil
+		})
+		return errRollback
+	})
+	if len(handler.handled) != 2 {
+		t.Fatalf("outer rollback: handled = %v", handler.handled)
+	}
+
+	// 不在事务中时立即分发
+	publish(ctx, "immediate")
+	if len(handler.handled) != 3 || handler.handled[2] != "immediate" {
+		t.Fatalf("immediate: handled = %v", handler.handled)
+	}
+	eventtest.AssertPublishedCount(t, bus, "order.created", 3)
+}
@@ -0,0 +1,61 @@
+package event
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+// defaultMessagingSystem 默认消息系统标识
+const defaultMessagingSystem = "mq"
+
+// injectTraceHeaders 将上下文中的追踪信息(W3C traceparent/baggage)注入消息头
+func injectTraceHeaders(ctx context.Context, headers map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+}
+
+// extractTraceContext 从消息头提取追踪信息
+func extractTraceContext(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+}
+
+// startPublishSpan 开启事件发布span
+func startPublishSpan(ctx context.Context, system, eventType string) (context.Context, oteltrace.Span) {
+	return trace.Tracer().Start(ctx, eventType+" publish",
+		oteltrace.WithSpanKind(oteltrace.SpanKindProducer),
+		oteltrace.WithAttributes(
+			semconv.MessagingSystemKey.String(system),
+			semconv.MessagingDestinationName(eventType),
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingOperationName("publish"),
+		),
+	)
+}
+
+// startProcessSpan 开启事件消费span，作为生产者span的子span并链接到生产者span
+func startProcessSpan(ctx context.Context, system, eventType, messageID string, headers map[string]string) (context.Context, oteltrace.Span) {
+	ctx = extractTraceContext(ctx, headers)
+
+	opts := []oteltrace.SpanStartOption{
+		oteltrace.WithSpanKind(oteltrace.SpanKindConsumer),
+		oteltrace.WithAttributes(
+			semconv.MessagingSystemKey.String(system),
+			semconv.MessagingDestinationName(eventType),
+			semconv.MessagingOperationTypeDeliver,
+			semconv.MessagingOperationName("process"),
+			semconv.MessagingMessageID(messageID),
+		),
+	}
+	if producer := oteltrace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, oteltrace.WithLinks(oteltrace.Link{SpanContext: producer}))
+	}
+	return trace.Tracer().Start(ctx, eventType+" process", opts...)
+}
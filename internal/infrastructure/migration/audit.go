@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/audit"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+var auditMigrations = []*gormigrate.Migration{
+	{
+		ID: "audit_202610191100",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&audit.AuditLog{}); err != nil {
+				return err
+			}
+			model.TableComment(tx, db.Driver(), (audit.AuditLog{}).TableName(), "管理操作审计日志表")
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&audit.AuditLog{})
+		},
+	},
+	{
+		ID: "audit_202610192000",
+		Migrate: func(tx *gorm.DB) error {
+			// 租户ID
+			return tx.AutoMigrate(&audit.AuditLog{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumns(tx, &audit.AuditLog{}, "tenant_id")
+		},
+	},
+}
@@ -0,0 +1,79 @@
+package migration
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultDir 迁移文件所在目录(相对项目根目录)
+const DefaultDir = "internal/infrastructure/migration"
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var migrationTemplate = template.Must(template.New("migration").Parse(`package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// {{.Var}} 创建后需追加到 allMigrations，执行顺序由迁移ID的时间后缀决定
+var {{.Var}} = []*gormigrate.Migration{
+	{
+		ID: "{{.ID}}",
+		Migrate: func(tx *gorm.DB) error {
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return nil
+		},
+	},
+}
+`))
+
+// Create 在 dir 下生成迁移文件骨架，返回文件路径与迁移变量名
+// 迁移ID沿用 <name>_<yyyyMMddHHmm> 格式
+func Create(dir, name string, now time.Time) (string, string, error) {
+	if !migrationNamePattern.MatchString(name) {
+		return "", "", errors.New("migration name must be snake_case, e.g. add_user_nickname")
+	}
+
+	id := name + "_" + now.Format("200601021504")
+	data := struct{ ID, Var string }{ID: id, Var: camelCase(name) + "Migrations" + now.Format("200601021504")}
+
+	var buf bytes.Buffer
+	if err := migrationTemplate.Execute(&buf, data); err != nil {
+		return "", "", err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", "", err
+	}
+
+	path := filepath.Join(dir, id+".go")
+	if _, err = os.Stat(path); err == nil {
+		return "", "", fmt.Errorf("migration file %s already exists", path)
+	}
+	if err = os.WriteFile(path, src, 0o644); err != nil {
+		return "", "", err
+	}
+	return path, data.Var, nil
+}
+
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
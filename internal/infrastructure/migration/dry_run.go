@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"gorm.io/gorm"
+)
+
+// dryRunPool 演练连接池：查询语句照常访问数据库(迁移需要读取表结构)，
+// 变更语句只输出不执行
+type dryRunPool struct {
+	db *sql.DB
+	w  io.Writer
+	// explain 将占位符参数代入 SQL
+	explain func(sql string, vars ...any) string
+}
+
+func (p *dryRunPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, query)
+}
+
+func (p *dryRunPool) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	if _, err := fmt.Fprintf(p.w, "%s;\n", p.explain(query, args...)); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (p *dryRunPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, query, args...)
+}
+
+func (p *dryRunPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.db.QueryRowContext(ctx, query, args...)
+}
+
+// dryRunSession 返回将变更语句输出到 w 的会话
+func dryRunSession(tx *gorm.DB, w io.Writer) (*gorm.DB, error) {
+	sqlDB, err := tx.DB()
+	if err != nil {
+		return nil, err
+	}
+	session := tx.Session(&gorm.Session{NewDB: true, PrepareStmt: false})
+	session.Statement.ConnPool = &dryRunPool{db: sqlDB, w: w, explain: tx.Dialector.Explain}
+	return session, nil
+}
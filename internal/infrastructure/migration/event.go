@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/event"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+var eventMigrations = []*gormigrate.Migration{
+	{
+		ID: "event_202610190900",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&event.Outbox{}); err != nil {
+				return err
+			}
+			model.TableComment(tx, db.Driver(), (event.Outbox{}).TableName(), "领域事件发件箱表")
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&event.Outbox{})
+		},
+	},
+	{
+		ID: "event_202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&event.Inbox{}); err != nil {
+				return err
+			}
+			model.TableComment(tx, db.Driver(), (event.Inbox{}).TableName(), "领域事件消费收件箱表")
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&event.Inbox{})
+		},
+	},
+	{
+		ID: "event_202610191100",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&event.DeadLetter{}); err != nil {
+				return err
+			}
+			model.TableComment(tx, db.Driver(), (event.DeadLetter{}).TableName(), "领域事件处理死信表")
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&event.DeadLetter{})
+		},
+	},
+	{
+		ID: "event_202610191200",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&event.Outbox{}, "Headers") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&event.Outbox{}, "Headers")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&event.Outbox{}, "Headers")
+		},
+	},
+	{
+		ID: "event_202610191300",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&event.Scheduled{}); err != nil {
+				return err
+			}
+			model.TableComment(tx, db.Driver(), (event.Scheduled{}).TableName(), "定时领域事件表")
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&event.Scheduled{})
+		},
+	},
+	{
+		ID: "event_202610191400",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&event.StoredEvent{}); err != nil {
+				return err
+			}
+			model.TableComment(tx, db.Driver(), (event.StoredEvent{}).TableName(), "领域事件存储表")
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&event.StoredEvent{})
+		},
+	},
+	{
+		ID: "event_202610192000",
+		Migrate: func(tx *gorm.DB) error {
+			// 死信租户ID
+			return tx.AutoMigrate(&event.DeadLetter{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumns(tx, &event.DeadLetter{}, "tenant_id")
+		},
+	},
+}
@@ -24,4 +24,27 @@ var fileMigrations = []*gormigrate.Migration{
 			return tx.Migrator().DropTable(&file.File{}, &file.MultipartUpload{})
 		},
 	},
+	{
+		ID: "file_202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			// 乐观锁版本号与创建人/修改人
+			return tx.AutoMigrate(&file.File{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumns(tx, &file.File{}, "version", "created_by", "updated_by")
+		},
+	},
+	{
+		ID: "file_202610191200",
+		Migrate: func(tx *gorm.DB) error {
+			// 租户ID
+			return tx.AutoMigrate(&file.File{}, &file.MultipartUpload{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := dropColumns(tx, &file.File{}, "tenant_id"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &file.MultipartUpload{}, "tenant_id")
+		},
+	},
 }
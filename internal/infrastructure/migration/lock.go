@@ -0,0 +1,98 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// lockTimeout 等待迁移锁的最长时间
+	lockTimeout = 10 * time.Minute
+	// lockPollInterval postgres 轮询获取锁的间隔
+	lockPollInterval = time.Second
+)
+
+// ErrLockTimeout 等待迁移锁超时，通常是其他实例正在迁移
+var ErrLockTimeout = errors.New("migration: timed out waiting for lock")
+
+// withLock 持有数据库会话级咨询锁执行 fn，防止多个实例同时迁移
+// 锁绑定在独立连接上，fn 内的语句仍使用连接池执行；进程异常退出时连接断开锁自动释放
+func withLock(ctx context.Context, tx *gorm.DB, fn func() error) error {
+	sqlDB, err := tx.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	name := "migration:" + tx.Migrator().CurrentDatabase()
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+
+	var unlock func() error
+	switch tx.Dialector.Name() {
+	case "mysql":
+		unlock, err = mysqlLock(ctx, conn, name)
+	case "postgres":
+		unlock, err = postgresLock(ctx, conn, name)
+	default:
+		return fmt.Errorf("migration: lock unsupported for driver %s", tx.Dialector.Name())
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = unlock() }()
+
+	return fn()
+}
+
+func mysqlLock(ctx context.Context, conn *sql.Conn, name string) (func() error, error) {
+	deadline, _ := ctx.Deadline()
+	var acquired sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, int(time.Until(deadline).Seconds())).Scan(&acquired)
+	if err != nil {
+		return nil, err
+	}
+	if acquired.Int64 != 1 {
+		return nil, ErrLockTimeout
+	}
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", name)
+		return err
+	}, nil
+}
+
+func postgresLock(ctx context.Context, conn *sql.Conn, name string) (func() error, error) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	key := int64(h.Sum64())
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+	for {
+		var acquired bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+			return nil, err
+		}
+		if acquired {
+			return func() error {
+				_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+				return err
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ErrLockTimeout
+		case <-ticker.C:
+		}
+	}
+}
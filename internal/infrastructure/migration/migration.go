@@ -2,34 +2,67 @@ package migration
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
 
 	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
 
 	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/permission"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
 	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
 )
 
-// 定义数据库迁移
-var migrations []*gormigrate.Migration
+// MigrationStatus 迁移状态
+type MigrationStatus struct {
+	ID      string
+	Applied bool
+}
 
-func margeMigrations() {
+// allMigrations 汇总全部迁移，按迁移ID的时间后缀排序作为执行顺序，时间相同时保持分组顺序
+// 每次调用返回新切片，避免重复调用时迁移被重复追加
+func allMigrations() []*gormigrate.Migration {
+	var migrations []*gormigrate.Migration
 	migrations = append(migrations, permissionMigrations...)
 	migrations = append(migrations, userMigrations...)
 	migrations = append(migrations, fileMigrations...)
+	migrations = append(migrations, eventMigrations...)
+	migrations = append(migrations, webhookMigrations...)
+	migrations = append(migrations, auditMigrations...)
+	slices.SortStableFunc(migrations, func(a, b *gormigrate.Migration) int {
+		return strings.Compare(migrationTime(a.ID), migrationTime(b.ID))
+	})
+	return migrations
+}
+
+// migrationTime 迁移ID中的时间后缀，ID 格式为 <name>_<yyyyMMddHHmm>
+func migrationTime(id string) string {
+	return id[strings.LastIndexByte(id, '_')+1:]
 }
 
-// Migrate 执行数据库迁移
-func Migrate(ctx context.Context, tx transactions.TransactionManager) error {
+// Migrate 执行数据库迁移，指定 version 时迁移到该版本(含)为止
+func Migrate(ctx context.Context, tx transactions.TransactionManager, version ...string) error {
 	logger.Info(ctx, "开始数据库迁移")
+	// 迁移依赖迁移记录表的最新状态，始终读主库；迁移面向全部租户的数据
+	ctx = tenant.Bypass(db.WithReadYourWrites(ctx))
 
-	margeMigrations()
+	migrations := allMigrations()
 	if len(migrations) == 0 {
 		return nil
 	}
 
-	// 自动迁移数据库表结构
-	err := gormigrate.New(tx.GetTx(ctx), gormigrate.DefaultOptions, migrations).Migrate()
+	conn := tx.GetTx(ctx)
+	err := withLock(ctx, conn, func() error {
+		m := gormigrate.New(conn, gormigrate.DefaultOptions, migrations)
+		if len(version) > 0 {
+			return m.MigrateTo(version[0])
+		}
+		return m.Migrate()
+	})
 	if err != nil {
 		logger.Error(ctx, "数据库迁移失败", logger.ErrorField(err))
 		return err
@@ -39,21 +72,26 @@ func Migrate(ctx context.Context, tx transactions.TransactionManager) error {
 	return nil
 }
 
-// Rollback 执行数据库回滚
+// Rollback 执行数据库回滚，未指定 version 时回滚最后一个迁移，
+// 指定时回滚其后的全部迁移(version 本身保留)
 func Rollback(ctx context.Context, tx transactions.TransactionManager, version ...string) error {
 	logger.Info(ctx, "开始数据库迁移回滚")
+	// 迁移依赖迁移记录表的最新状态，始终读主库
+	ctx = tenant.Bypass(db.WithReadYourWrites(ctx))
 
-	margeMigrations()
+	migrations := allMigrations()
 	if len(migrations) == 0 {
 		return nil
 	}
 
-	var err error
-	if len(version) > 0 {
-		err = gormigrate.New(tx.GetTx(ctx), gormigrate.DefaultOptions, migrations).RollbackTo(version[0])
-	} else {
-		err = gormigrate.New(tx.GetTx(ctx), gormigrate.DefaultOptions, migrations).RollbackLast()
-	}
+	conn := tx.GetTx(ctx)
+	err := withLock(ctx, conn, func() error {
+		m := gormigrate.New(conn, gormigrate.DefaultOptions, migrations)
+		if len(version) > 0 {
+			return m.RollbackTo(version[0])
+		}
+		return m.RollbackLast()
+	})
 	if err != nil {
 		logger.Error(ctx, "数据库迁移回滚失败", logger.ErrorField(err))
 		return err
@@ -63,16 +101,150 @@ func Rollback(ctx context.Context, tx transactions.TransactionManager, version .
 	return nil
 }
 
+// Status 查询全部迁移的执行状态
+func Status(ctx context.Context, tx transactions.TransactionManager) ([]MigrationStatus, error) {
+	ctx = tenant.Bypass(db.WithReadYourWrites(ctx))
+
+	applied, err := appliedMigrations(tx.GetTx(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := allMigrations()
+	status := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		_, ok := applied[m.ID]
+		status[i] = MigrationStatus{ID: m.ID, Applied: ok}
+	}
+	return status, nil
+}
+
+// MigrateDryRun 输出 Migrate 将执行的 SQL 而不执行
+// 查询表结构等读操作照常执行；待执行迁移之间相互依赖时(如前一个迁移建表、后一个加字段)，
+// 后者的输出基于当前库结构，可能与实际执行有出入
+func MigrateDryRun(ctx context.Context, tx transactions.TransactionManager, w io.Writer, version ...string) error {
+	ctx = tenant.Bypass(db.WithReadYourWrites(ctx))
+	conn := tx.GetTx(ctx)
+
+	applied, err := appliedMigrations(conn)
+	if err != nil {
+		return err
+	}
+
+	migrations := allMigrations()
+	if len(version) > 0 {
+		i := slices.IndexFunc(migrations, func(m *gormigrate.Migration) bool { return m.ID == version[0] })
+		if i < 0 {
+			return fmt.Errorf("%w: %s", gormigrate.ErrMigrationIDDoesNotExist, version[0])
+		}
+		migrations = migrations[:i+1]
+	}
+
+	var pending []*gormigrate.Migration
+	for _, m := range migrations {
+		if _, ok := applied[m.ID]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return dryRun(conn, w, "migrate", pending, func(m *gormigrate.Migration) func(*gorm.DB) error { return m.Migrate })
+}
+
+// RollbackDryRun 输出 Rollback 将执行的 SQL 而不执行
+func RollbackDryRun(ctx context.Context, tx transactions.TransactionManager, w io.Writer, version ...string) error {
+	ctx = tenant.Bypass(db.WithReadYourWrites(ctx))
+	conn := tx.GetTx(ctx)
+
+	applied, err := appliedMigrations(conn)
+	if err != nil {
+		return err
+	}
+
+	var targets []*gormigrate.Migration
+	migrations := allMigrations()
+	found := len(version) == 0
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if len(version) > 0 && m.ID == version[0] {
+			found = true
+			break
+		}
+		if _, ok := applied[m.ID]; ok {
+			targets = append(targets, m)
+			if len(version) == 0 {
+				break
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", gormigrate.ErrMigrationIDDoesNotExist, version[0])
+	}
+	return dryRun(conn, w, "rollback", targets, func(m *gormigrate.Migration) func(*gorm.DB) error { return m.Rollback })
+}
+
+// dryRun 依次以演练会话执行迁移函数
+func dryRun(conn *gorm.DB, w io.Writer, action string, migrations []*gormigrate.Migration, fn func(*gormigrate.Migration) func(*gorm.DB) error) error {
+	if len(migrations) == 0 {
+		_, err := fmt.Fprintln(w, "-- nothing to "+action)
+		return err
+	}
+	session, err := dryRunSession(conn, w)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if _, err = fmt.Fprintf(w, "-- %s %s\n", action, m.ID); err != nil {
+			return err
+		}
+		f := fn(m)
+		if f == nil {
+			if _, err = fmt.Fprintln(w, "-- (no-op)"); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = f(session); err != nil {
+			return fmt.Errorf("%s %s: %w", action, m.ID, err)
+		}
+	}
+	return nil
+}
+
+// appliedMigrations 已执行的迁移ID，迁移记录表不存在时视为均未执行
+func appliedMigrations(conn *gorm.DB) (map[string]struct{}, error) {
+	opts := gormigrate.DefaultOptions
+	applied := make(map[string]struct{})
+	if !conn.Migrator().HasTable(opts.TableName) {
+		return applied, nil
+	}
+	var ids []string
+	if err := conn.Table(opts.TableName).Pluck(opts.IDColumnName, &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		applied[id] = struct{}{}
+	}
+	return applied, nil
+}
+
 // Seed 填充初始数据
 func Seed(ctx context.Context, tx transactions.TransactionManager) error {
 	logger.Info(ctx, "开始填充初始数据")
+	// 根据现有数据决定是否填充，始终读主库
+	ctx = tenant.Bypass(db.WithReadYourWrites(ctx))
 
-	// 检查是否已有管理员用户
-	var count int64
-	tx.GetTx(ctx).Model(&permission.Admin{}).Count(&count)
+	// 与迁移共用锁，避免多个实例重复填充
+	conn := tx.GetTx(ctx)
+	err := withLock(ctx, conn, func() error {
+		// 检查是否已有管理员用户
+		var count int64
+		if err := conn.Model(&permission.Admin{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
 
-	// 如果没有管理员用户，就创建一个
-	if count == 0 {
+		// 如果没有管理员用户，就创建一个
 		adminUser := &permission.Admin{
 			Username:     "admin",
 			SafePassword: "$2a$04$Vq4xCFDY9Iorlv89QcYrDubHZ4LRcRs6e4l4SVDKzkhd4BGWGbc7u", // 密码: 12345678
@@ -80,15 +252,43 @@ func Seed(ctx context.Context, tx transactions.TransactionManager) error {
 			IsSuper:      1,
 			Status:       1,
 		}
-
-		if err := tx.GetTx(ctx).Create(adminUser).Error; err != nil {
+		if err := conn.Create(adminUser).Error; err != nil {
 			logger.Error(ctx, "创建管理员用户失败", logger.ErrorField(err))
 			return err
 		}
-
 		logger.Info(ctx, "创建管理员用户成功")
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	logger.Info(ctx, "初始数据填充完成")
 	return nil
 }
+
+// dropColumns 删除字段，用于回滚新增字段的迁移
+func dropColumns(tx *gorm.DB, value any, columns ...string) error {
+	for _, column := range columns {
+		if !tx.Migrator().HasColumn(value, column) {
+			continue
+		}
+		if err := tx.Migrator().DropColumn(value, column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropIndexes 删除索引，用于调整索引定义的迁移
+func dropIndexes(tx *gorm.DB, value any, names ...string) error {
+	for _, name := range names {
+		if !tx.Migrator().HasIndex(value, name) {
+			continue
+		}
+		if err := tx.Migrator().DropIndex(value, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
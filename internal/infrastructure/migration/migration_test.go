@@ -0,0 +1,129 @@
+package migration
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/user"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+func TestAllMigrationsIsIdempotent(t *testing.T) {
+	first, second := allMigrations(), allMigrations()
+	if len(first) == 0 || len(first) != len(second) {
+		t.Fatalf("allMigrations length changed between calls: %d -> %d", len(first), len(second))
+	}
+
+	seen := make(map[string]struct{}, len(first))
+	for _, m := range first {
+		if _, ok := seen[m.ID]; ok {
+			t.Fatalf("duplicate migration id %s", m.ID)
+		}
+		seen[m.ID] = struct{}{}
+	}
+}
+
+func TestAllMigrationsOrderedByTime(t *testing.T) {
+	migrations := allMigrations()
+	for i, m := range migrations {
+		if !regexp.MustCompile(`_\d{12}$`).MatchString(m.ID) {
+			t.Fatalf("migration id %s missing yyyyMMddHHmm suffix", m.ID)
+		}
+		if i > 0 && migrationTime(migrations[i-1].ID) > migrationTime(m.ID) {
+			t.Fatalf("migration %s runs before %s", migrations[i-1].ID, m.ID)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 19, 12, 30, 0, 0, time.Local)
+
+	path, name, err := Create(dir, "add_user_nickname", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "add_user_nickname_202610191230.go" || name != "addUserNicknameMigrations202610191230" {
+		t.Fatalf("path = %s, var = %s", path, name)
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = parser.ParseFile(token.NewFileSet(), path, src, 0); err != nil {
+		t.Fatalf("generated file does not parse: %v", err)
+	}
+	if !strings.Contains(string(src), `ID: "add_user_nickname_202610191230"`) {
+		t.Fatalf("generated file missing id:\n%s", src)
+	}
+
+	if _, _, err = Create(dir, "add_user_nickname", now); err == nil {
+		t.Fatal("expected error when file already exists")
+	}
+	if _, _, err = Create(dir, "Bad-Name", now); err == nil {
+		t.Fatal("expected error for invalid name")
+	}
+}
+
+// piiUser 新增盲索引字段后尚未填充的用户表
+type piiUser struct {
+	ID                      uint64 `gorm:"primaryKey"`
+	Telephone               string
+	TelephoneBidx           *string
+	WxUnionID               string
+	WxUnionIDBidx           *string
+	WxMiniProgramOpenID     string  `gorm:"column:wx_mini_program_openid"`
+	WxMiniProgramOpenIDBidx *string `gorm:"column:wx_mini_program_openid_bidx"`
+	WxOfficialOpenID        string  `gorm:"column:wx_official_openid"`
+	WxOfficialOpenIDBidx    *string `gorm:"column:wx_official_openid_bidx"`
+}
+
+func (piiUser) TableName() string {
+	return (user.User{}).TableName()
+}
+
+func TestBackfillUserBlindIndex(t *testing.T) {
+	conn := dbtest.Open(t, &piiUser{})
+	if err := conn.Create(&[]piiUser{
+		{ID: 1, Telephone: "13800000000", WxMiniProgramOpenID: "openid-1"},
+		{ID: 2, WxUnionID: "union-2"},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 迁移 user_202610191400 在新增字段后填充存量用户的盲索引
+	result, err := backfillTable(context.Background(), conn, userPIITable(), 1, false)
+	if err != nil || result.Scanned != 2 || result.Updated != 2 {
+		t.Fatalf("result = %+v, err = %v", result, err)
+	}
+
+	var rows []piiUser
+	conn.Order("id").Find(&rows)
+	want := []struct{ telephone, unionID, openID string }{
+		{string(model.NewBlindIndex(user.TelephoneIndex, "13800000000")), "", string(model.NewBlindIndex(user.WxMiniProgramOpenIDIndex, "openid-1"))},
+		{"", string(model.NewBlindIndex(user.WxUnionIDIndex, "union-2")), ""},
+	}
+	for i, row := range rows {
+		// 空值不生成盲索引，保持NULL不受唯一索引约束
+		if value(row.TelephoneBidx) != want[i].telephone || value(row.WxUnionIDBidx) != want[i].unionID ||
+			value(row.WxMiniProgramOpenIDBidx) != want[i].openID || row.WxOfficialOpenIDBidx != nil {
+			t.Fatalf("row %d = %+v", row.ID, row)
+		}
+	}
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -26,4 +26,53 @@ var permissionMigrations = []*gormigrate.Migration{
 			return tx.Migrator().DropTable(&permission.Admin{}, &permission.Permission{}, &permission.AdminHasPermission{})
 		},
 	},
+	{
+		ID: "permission_202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			// 乐观锁版本号与创建人/修改人
+			return tx.AutoMigrate(&permission.Admin{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumns(tx, &permission.Admin{}, "version", "created_by", "updated_by")
+		},
+	},
+	{
+		ID: "permission_202610191200",
+		Migrate: func(tx *gorm.DB) error {
+			// 租户ID，用户名唯一索引改为租户内唯一
+			if err := dropIndexes(tx, &permission.Admin{}, "admin_username_idx"); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&permission.Admin{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := dropIndexes(tx, &permission.Admin{}, "admin_username_idx"); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, &permission.Admin{}, "tenant_id"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&adminUniqueIndexV1{}, "admin_username_idx")
+		},
+	},
+	{
+		ID: "permission_202610191400",
+		Migrate: func(tx *gorm.DB) error {
+			// 手机号加密存储，加宽字段
+			return tx.AutoMigrate(&permission.Admin{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// 保留加宽后的字段，回滚前需关闭加密并执行 pii:backfill 还原明文
+			return nil
+		},
+	},
+}
+
+// adminUniqueIndexV1 租户化之前的全局唯一索引，用于回滚
+type adminUniqueIndexV1 struct {
+	Username string `gorm:"index:admin_username_idx,unique"`
+}
+
+func (adminUniqueIndexV1) TableName() string {
+	return (permission.Admin{}).TableName()
 }
@@ -0,0 +1,202 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/permission"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/user"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+	"github.com/dysodeng/app/internal/infrastructure/shared/pii"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
+)
+
+// piiColumn 加密字段，index 为对应的盲索引字段，为空时无盲索引
+type piiColumn struct {
+	name    string
+	index   string
+	purpose string
+}
+
+// piiTable 包含加密字段的数据表，主键需为 id
+type piiTable struct {
+	table   string
+	columns []piiColumn
+}
+
+// backfillBatchSize 迁移中重写存量数据的批量大小
+const backfillBatchSize = 500
+
+func piiTables() []piiTable {
+	return []piiTable{
+		userPIITable(),
+		{
+			table:   (permission.Admin{}).TableName(),
+			columns: []piiColumn{{name: "telephone"}},
+		},
+	}
+}
+
+func userPIITable() piiTable {
+	return piiTable{
+		table: (user.User{}).TableName(),
+		columns: []piiColumn{
+			{name: "telephone", index: "telephone_bidx", purpose: user.TelephoneIndex},
+			{name: "wx_union_id", index: "wx_union_id_bidx", purpose: user.WxUnionIDIndex},
+			{name: "wx_mini_program_openid", index: "wx_mini_program_openid_bidx", purpose: user.WxMiniProgramOpenIDIndex},
+			{name: "wx_official_openid", index: "wx_official_openid_bidx", purpose: user.WxOfficialOpenIDIndex},
+		},
+	}
+}
+
+// BackfillResult 存量数据重写结果
+type BackfillResult struct {
+	Table   string
+	Scanned int
+	Updated int
+}
+
+// BackfillPII 按当前加密配置重写存量敏感字段
+// 明文与旧主密钥的密文使用主密钥重新加密，并重建盲索引；关闭加密时将密文还原为明文
+// 可重复执行，按主键分批处理，写入时校验原值，不覆盖期间被并发修改的数据
+func BackfillPII(ctx context.Context, tx transactions.TransactionManager, batchSize int, dryRun bool) ([]BackfillResult, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("pii backfill: invalid batch size %d", batchSize)
+	}
+	ctx = tenant.Bypass(db.WithReadYourWrites(ctx))
+	conn := tx.GetTx(ctx)
+
+	var results []BackfillResult
+	// 与迁移共用锁，避免多个实例同时重写
+	err := withLock(ctx, conn, func() error {
+		for _, table := range piiTables() {
+			result, err := backfillTable(ctx, conn, table, batchSize, dryRun)
+			results = append(results, result)
+			if err != nil {
+				return err
+			}
+			logger.Info(ctx, "敏感字段重写完成",
+				logger.AddField("table", result.Table),
+				logger.AddField("scanned", result.Scanned),
+				logger.AddField("updated", result.Updated),
+			)
+		}
+		return nil
+	})
+	return results, err
+}
+
+type piiRow struct {
+	id     any
+	values []sql.NullString
+}
+
+func backfillTable(ctx context.Context, conn *gorm.DB, table piiTable, batchSize int, dryRun bool) (BackfillResult, error) {
+	result := BackfillResult{Table: table.table}
+
+	selects := []string{"id"}
+	for _, column := range table.columns {
+		selects = append(selects, column.name)
+		if column.index != "" {
+			selects = append(selects, column.index)
+		}
+	}
+
+	var lastID any
+	for {
+		query := conn.WithContext(ctx).Table(table.table).Select(selects).Order("id").Limit(batchSize)
+		if lastID != nil {
+			query = query.Where("id > ?", lastID)
+		}
+		batch, err := scanPIIRows(query, len(selects)-1)
+		if err != nil {
+			return result, err
+		}
+
+		for _, row := range batch {
+			result.Scanned++
+			updated, err := backfillRow(ctx, conn, table, row, dryRun)
+			if err != nil {
+				return result, fmt.Errorf("pii backfill: %s id=%v: %w", table.table, row.id, err)
+			}
+			if updated {
+				result.Updated++
+			}
+		}
+
+		if len(batch) < batchSize {
+			return result, nil
+		}
+		lastID = batch[len(batch)-1].id
+	}
+}
+
+func scanPIIRows(query *gorm.DB, columns int) ([]piiRow, error) {
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var batch []piiRow
+	for rows.Next() {
+		row := piiRow{values: make([]sql.NullString, columns)}
+		dest := []any{&row.id}
+		for i := range row.values {
+			dest = append(dest, &row.values[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		batch = append(batch, row)
+	}
+	return batch, rows.Err()
+}
+
+// backfillRow 重写单行中与当前配置不一致的字段，返回是否有变更
+func backfillRow(ctx context.Context, conn *gorm.DB, table piiTable, row piiRow, dryRun bool) (bool, error) {
+	updates := make(map[string]any)
+	query := conn.WithContext(ctx).Table(table.table).Where("id = ?", row.id)
+
+	i := 0
+	for _, column := range table.columns {
+		raw := row.values[i].String
+		i++
+		// 以读取时的原值作为更新条件
+		query = query.Where(column.name+" = ?", raw)
+
+		plaintext, err := pii.Decrypt(raw)
+		if err != nil {
+			return false, err
+		}
+		if pii.Stale(raw) {
+			if updates[column.name], err = pii.Encrypt(plaintext); err != nil {
+				return false, err
+			}
+		}
+
+		if column.index == "" {
+			continue
+		}
+		current := row.values[i].String
+		i++
+		if index := model.NewBlindIndex(column.purpose, plaintext); string(index) != current {
+			updates[column.index] = index
+		}
+	}
+
+	if len(updates) == 0 {
+		return false, nil
+	}
+	if dryRun {
+		return true, nil
+	}
+	res := query.UpdateColumns(updates)
+	return res.RowsAffected > 0, res.Error
+}
@@ -24,4 +24,94 @@ var userMigrations = []*gormigrate.Migration{
 			return tx.Migrator().DropTable(&user.User{})
 		},
 	},
+	{
+		ID: "user_202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			// 乐观锁版本号与创建人/修改人
+			return tx.AutoMigrate(&user.User{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumns(tx, &user.User{}, "version", "created_by", "updated_by")
+		},
+	},
+	{
+		ID: "user_202610191200",
+		Migrate: func(tx *gorm.DB) error {
+			// 租户ID，手机号与小程序OpenID唯一索引改为租户内唯一
+			if err := dropIndexes(tx, &user.User{}, "user_telephone_idx", "user_wx_mp_idx"); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&user.User{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := dropIndexes(tx, &user.User{}, "user_telephone_idx", "user_wx_mp_idx"); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, &user.User{}, "tenant_id"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().CreateIndex(&userUniqueIndexV1{}, "user_telephone_idx"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&userUniqueIndexV1{}, "user_wx_mp_idx")
+		},
+	},
+	{
+		ID: "user_202610191400",
+		Migrate: func(tx *gorm.DB) error {
+			// 手机号与微信OpenID加密存储，索引改建在盲索引字段上
+			if err := dropIndexes(tx, &user.User{}, userIndexesV2...); err != nil {
+				return err
+			}
+			if err := tx.AutoMigrate(&user.User{}); err != nil {
+				return err
+			}
+			if tx.DryRun {
+				return nil
+			}
+			// 新增的盲索引字段为空时按手机号/OpenID查找不到存量用户，唯一索引也无法约束，需在迁移内填充(未开启加密时同样需要)
+			_, err := backfillTable(tx.Statement.Context, tx, userPIITable(), backfillBatchSize, false)
+			return err
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// 保留加宽后的字段，回滚前需关闭加密并执行 pii:backfill 还原明文
+			if err := dropIndexes(tx, &user.User{}, userIndexesV2...); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, &user.User{}, "telephone_bidx", "wx_union_id_bidx", "wx_mini_program_openid_bidx", "wx_official_openid_bidx"); err != nil {
+				return err
+			}
+			for _, name := range userIndexesV2 {
+				if err := tx.Migrator().CreateIndex(&userIndexV2{}, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+}
+
+// userUniqueIndexV1 租户化之前的全局唯一索引，用于回滚
+type userUniqueIndexV1 struct {
+	Telephone           string `gorm:"index:user_telephone_idx,unique"`
+	WxMiniProgramOpenID string `gorm:"column:wx_mini_program_openid;index:user_wx_mp_idx,unique"`
+}
+
+func (userUniqueIndexV1) TableName() string {
+	return (user.User{}).TableName()
+}
+
+var userIndexesV2 = []string{"user_telephone_idx", "user_wx_union_idx", "user_wx_mp_idx", "user_wx_official_idx"}
+
+// userIndexV2 改用盲索引之前建在明文字段上的索引，用于回滚
+type userIndexV2 struct {
+	TenantID            string `gorm:"index:user_telephone_idx,unique,priority:1;index:user_wx_mp_idx,unique,priority:1"`
+	Telephone           string `gorm:"index:user_telephone_idx,unique"`
+	WxUnionID           string `gorm:"index:user_wx_union_idx"`
+	WxMiniProgramOpenID string `gorm:"column:wx_mini_program_openid;index:user_wx_mp_idx,unique"`
+	WxOfficialOpenID    string `gorm:"column:wx_official_openid;index:user_wx_official_idx"`
+}
+
+func (userIndexV2) TableName() string {
+	return (user.User{}).TableName()
 }
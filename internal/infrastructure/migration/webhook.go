@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/webhook"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+var webhookMigrations = []*gormigrate.Migration{
+	{
+		ID: "webhook_202610191500",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&webhook.Subscription{}, &webhook.Delivery{}); err != nil {
+				return err
+			}
+			model.TableComment(tx, db.Driver(), (webhook.Subscription{}).TableName(), "Webhook订阅表")
+			model.TableComment(tx, db.Driver(), (webhook.Delivery{}).TableName(), "Webhook投递记录表")
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&webhook.Delivery{}, &webhook.Subscription{})
+		},
+	},
+	{
+		ID: "webhook_202610192000",
+		Migrate: func(tx *gorm.DB) error {
+			// 租户ID
+			return tx.AutoMigrate(&webhook.Subscription{}, &webhook.Delivery{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := dropColumns(tx, &webhook.Subscription{}, "tenant_id"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &webhook.Delivery{}, "tenant_id")
+		},
+	},
+}
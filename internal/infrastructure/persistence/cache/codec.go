@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/contracts"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/serializer"
+)
+
+var (
+	keyringOnce sync.Once
+	keyring     *serializer.Keyring
+	keyringErr  error
+)
+
+func init() {
+	// 启动加载配置时校验密钥配置，避免创建缓存时才发现错误
+	config.RegisterValidator(validateEncryption)
+}
+
+// validateEncryption 校验缓存加密密钥配置
+func validateEncryption(c *config.Config) error {
+	if !c.Cache.Encryption.Enabled {
+		return nil
+	}
+	_, err := parseKeyring(c.Cache.Encryption)
+	return err
+}
+
+// newSerializer 按配置组装序列化器：基础序列化 -> 压缩 -> 加密
+// 压缩与加密包装始终参与解码，关闭压缩或加密后仍可读取此前写入的数据
+func newSerializer[T any](namespace string) (contracts.Serializer[T], error) {
+	cfg := config.GlobalConfig.Cache
+
+	s := serializer.NewJSONSerializer[T]()
+	if cfg.Serializer == "msgpack" {
+		s = serializer.NewMsgpackSerializer[T]()
+	}
+
+	s = serializer.NewCompressSerializer(s, serializer.ParseCompression(cfg.Compression.Algorithm), cfg.Compression.Threshold)
+
+	if !cfg.Encryption.Enabled {
+		return serializer.NewDecryptSerializer(s, nil), nil
+	}
+	keyringOnce.Do(func() {
+		keyring, keyringErr = parseKeyring(cfg.Encryption)
+	})
+	if keyringErr != nil {
+		return nil, keyringErr
+	}
+	if slices.Contains(cfg.Encryption.Namespaces, namespace) {
+		return serializer.NewEncryptSerializer(s, keyring), nil
+	}
+	return serializer.NewDecryptSerializer(s, keyring), nil
+}
+
+// parseKeyring 解析密钥配置，格式 id:base64密钥，逗号分隔
+func parseKeyring(cfg config.CacheEncryption) (*serializer.Keyring, error) {
+	keys := make(map[string][]byte)
+	for i, item := range strings.Split(cfg.Keys, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		// 错误信息仅包含密钥ID或序号，不输出密钥内容
+		id, encoded, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("cache encryption: key #%d: missing key id", i+1)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("cache encryption: key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return serializer.NewKeyring(cfg.PrimaryKey, keys)
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+)
+
+func TestValidateEncryption(t *testing.T) {
+	secret := "c2VjcmV0LWtleS1tYXRlcmlhbC0wMTIzNDU2Nzg5YWI="
+	cases := []struct {
+		name string
+		keys string
+	}{
+		{"missing id", secret},
+		{"bad base64", "k1:" + secret + "!"},
+		{"bad size", "k1:c2hvcnQ="},
+	}
+	for _, c := range cases {
+		cfg := &config.Config{}
+		cfg.Cache.Encryption = config.CacheEncryption{Enabled: true, PrimaryKey: "k1", Keys: c.keys}
+		err := validateEncryption(cfg)
+		if err == nil {
+			t.Fatalf("%s: expected error", c.name)
+		}
+		// 错误信息不得包含密钥内容
+		if strings.Contains(err.Error(), secret) || strings.Contains(err.Error(), "c2hvcnQ=") {
+			t.Fatalf("%s: error leaks key material: %v", c.name, err)
+		}
+	}
+
+	cfg := &config.Config{}
+	cfg.Cache.Encryption = config.CacheEncryption{Enabled: true, PrimaryKey: "k1", Keys: "k1:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}
+	if err := validateEncryption(cfg); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -14,3 +14,9 @@ type Cache interface {
 	ScanDeleteByPrefix(ctx context.Context, prefix string) error
 	Incr(ctx context.Context, key string) (int64, error) // 原子自增（用于标签版本）
 }
+
+// Locker 分布式锁，由支持跨进程互斥的缓存驱动实现(如Redis)
+type Locker interface {
+	// TryLock 尝试加锁，成功时返回解锁函数；锁被占用时返回 false
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
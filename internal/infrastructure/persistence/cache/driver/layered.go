@@ -0,0 +1,150 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// invalidation 跨节点失效消息
+type invalidation struct {
+	Node   string   `json:"node"`
+	Keys   []string `json:"keys,omitempty"`
+	Prefix string   `json:"prefix,omitempty"`
+}
+
+// Layered 两级缓存驱动：L1 进程内LRU + L2 Redis
+// 写入与失效操作通过 Redis pub/sub 广播，各节点收到后清理本地L1；
+// 订阅断线期间丢失的消息由 L1 驻留时间兜底
+type Layered struct {
+	l1      *lru
+	l2      *Redis
+	l1TTL   time.Duration
+	channel string
+	nodeID  string
+
+	pubSub    *redis.PubSub
+	closeOnce sync.Once
+}
+
+// NewLayeredCache 创建两级缓存驱动
+// maxEntries: L1 最大条目数
+// l1TTL: L1 最长驻留时间
+// onEvict: L1 容量淘汰回调，可为nil
+// opts: L2 Redis驱动选项
+func NewLayeredCache(maxEntries int, l1TTL time.Duration, onEvict func(key string), opts ...RedisOption) *Layered {
+	l2 := NewRedisCache(opts...)
+	l := &Layered{
+		l1:      newLRU(maxEntries, onEvict),
+		l2:      l2,
+		l1TTL:   l1TTL,
+		channel: l2.key("__cache:invalidate"),
+		nodeID:  uuid.NewString(),
+	}
+	l.pubSub = l2.client.Subscribe(context.Background(), l.channel)
+	go l.subscribe()
+	return l
+}
+
+// Close 关闭失效广播订阅，不关闭共享的 Redis 客户端
+func (l *Layered) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		err = l.pubSub.Close()
+	})
+	return err
+}
+
+func (l *Layered) Exists(ctx context.Context, key string) (bool, error) {
+	if _, ok := l.l1.get(key); ok {
+		return true, nil
+	}
+	return l.l2.Exists(ctx, key)
+}
+
+func (l *Layered) Get(ctx context.Context, key string) ([]byte, error) {
+	if val, ok := l.l1.get(key); ok {
+		return val, nil
+	}
+	val, err := l.l2.Get(ctx, key)
+	if err != nil || val == nil {
+		return val, err
+	}
+	l.l1.set(key, val, l.l1TTL)
+	return val, nil
+}
+
+func (l *Layered) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	if err := l.l2.Set(ctx, key, val, ttl); err != nil {
+		return err
+	}
+	l.l1.set(key, val, l.ttl(ttl))
+	return l.publish(ctx, invalidation{Keys: []string{key}})
+}
+
+func (l *Layered) Delete(ctx context.Context, key string) error {
+	l.l1.delete(key)
+	if err := l.l2.Delete(ctx, key); err != nil {
+		return err
+	}
+	return l.publish(ctx, invalidation{Keys: []string{key}})
+}
+
+func (l *Layered) ScanDeleteByPrefix(ctx context.Context, prefix string) error {
+	l.l1.deletePrefix(prefix)
+	if err := l.l2.ScanDeleteByPrefix(ctx, prefix); err != nil {
+		return err
+	}
+	return l.publish(ctx, invalidation{Prefix: prefix})
+}
+
+func (l *Layered) Incr(ctx context.Context, key string) (int64, error) {
+	l.l1.delete(key)
+	n, err := l.l2.Incr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return n, l.publish(ctx, invalidation{Keys: []string{key}})
+}
+
+// TryLock 分布式锁直接使用 L2
+func (l *Layered) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	return l.l2.TryLock(ctx, key, ttl)
+}
+
+// ttl L1 驻留时间不超过数据本身的TTL
+func (l *Layered) ttl(ttl time.Duration) time.Duration {
+	if ttl > 0 && (l.l1TTL <= 0 || ttl < l.l1TTL) {
+		return ttl
+	}
+	return l.l1TTL
+}
+
+func (l *Layered) publish(ctx context.Context, msg invalidation) error {
+	msg.Node = l.nodeID
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return l.l2.client.Publish(ctx, l.channel, b).Err()
+}
+
+// subscribe 接收其他节点的失效广播，连接断开后由 go-redis 自动重连，Close 后退出
+func (l *Layered) subscribe() {
+	for msg := range l.pubSub.Channel() {
+		var inv invalidation
+		if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil || inv.Node == l.nodeID {
+			continue
+		}
+		if len(inv.Keys) > 0 {
+			l.l1.delete(inv.Keys...)
+		}
+		if inv.Prefix != "" {
+			l.l1.deletePrefix(inv.Prefix)
+		}
+	}
+}
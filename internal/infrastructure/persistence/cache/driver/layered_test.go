@@ -0,0 +1,110 @@
+package driver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLayeredInvalidation(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	newNode := func() *Layered {
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		node := NewLayeredCache(100, time.Minute, nil, WithRedisClient(client, "test"))
+		t.Cleanup(func() { _ = node.Close() })
+		return node
+	}
+	nodeA, nodeB := newNode(), newNode()
+
+	// 等待两个节点完成失效广播订阅
+	deadline := time.Now().Add(time.Second)
+	for mr.PubSubNumSub(nodeA.channel)[nodeA.channel] < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("invalidation subscription not ready")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := nodeA.Set(ctx, "k", []byte("v1"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := nodeB.Get(ctx, "k"); err != nil || string(v) != "v1" {
+		t.Fatalf("node B get = %s, %v", v, err)
+	}
+
+	// 绕过驱动修改L2，节点B命中L1旧值
+	mr.Set("test:k", "v2")
+	if v, _ := nodeB.Get(ctx, "k"); string(v) != "v1" {
+		t.Fatalf("node B should hit L1, got %s", v)
+	}
+
+	// 节点A写入后广播失效，节点B清理L1并读取L2新值
+	eventually := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for {
+			v, err := nodeB.Get(ctx, "k")
+			if err == nil && string(v) == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("node B get = %s, %v, want %s", v, err, want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	if err := nodeA.Set(ctx, "k", []byte("v3"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	eventually("v3")
+
+	// 前缀删除同样广播到其他节点
+	if err := nodeA.ScanDeleteByPrefix(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	deadline = time.Now().Add(time.Second)
+	for {
+		if v, _ := nodeB.Get(ctx, "k"); v == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("node B L1 not invalidated by prefix delete")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// 写入方自身的L1直接更新，不依赖广播
+	if err := nodeA.Set(ctx, "own", []byte("v"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := nodeA.l1.get("own"); !ok {
+		t.Fatal("writer L1 should be populated")
+	}
+}
+
+func TestLayeredClose(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	node := NewLayeredCache(100, time.Minute, nil, WithRedisClient(client, "test"))
+
+	// 关闭后退出失效广播订阅，可重复关闭
+	if err := node.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.Close(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for mr.PubSubNumSub(node.channel)[node.channel] > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("invalidation subscription not closed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,42 @@
+package driver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newLRU(2, nil)
+	c.set("a", []byte("1"), 0)
+	c.set("b", []byte("2"), 0)
+	c.get("a")
+	c.set("c", []byte("3"), 0)
+
+	if _, ok := c.get("b"); ok {
+		t.Fatal("b should be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.get(key); !ok {
+			t.Fatalf("%s should be kept", key)
+		}
+	}
+}
+
+func TestLRUExpireAndDeletePrefix(t *testing.T) {
+	c := newLRU(10, nil)
+	c.set("ns:a", []byte("1"), time.Millisecond)
+	c.set("ns:b", []byte("2"), 0)
+	c.set("other", []byte("3"), 0)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.get("ns:a"); ok {
+		t.Fatal("ns:a should be expired")
+	}
+	c.deletePrefix("ns:")
+	if _, ok := c.get("ns:b"); ok {
+		t.Fatal("ns:b should be deleted")
+	}
+	if _, ok := c.get("other"); !ok {
+		t.Fatal("other should be kept")
+	}
+}
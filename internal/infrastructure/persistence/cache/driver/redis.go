@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 
 	"github.com/dysodeng/app/internal/infrastructure/config"
@@ -18,11 +19,27 @@ type Redis struct {
 	keyPrefix string
 }
 
-func NewRedisCache() *Redis {
-	return &Redis{
-		client:    infraRedis.CacheClient(),
-		keyPrefix: config.GlobalConfig.Redis.Cache.KeyPrefix,
+// RedisOption Redis驱动选项
+type RedisOption func(r *Redis)
+
+// WithRedisClient 指定Redis连接与key前缀，默认使用全局缓存连接及配置的前缀
+func WithRedisClient(client redis.UniversalClient, keyPrefix string) RedisOption {
+	return func(r *Redis) {
+		r.client = client
+		r.keyPrefix = keyPrefix
+	}
+}
+
+func NewRedisCache(opts ...RedisOption) *Redis {
+	r := &Redis{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.client == nil {
+		r.client = infraRedis.CacheClient()
+		r.keyPrefix = config.GlobalConfig.Redis.Cache.KeyPrefix
 	}
+	return r
 }
 
 func (r *Redis) Exists(ctx context.Context, key string) (bool, error) {
@@ -70,6 +87,27 @@ func (r *Redis) Incr(ctx context.Context, key string) (int64, error) {
 	return r.client.Incr(ctx, r.key(key)).Result()
 }
 
+// unlockScript 仅删除自己持有的锁，避免锁过期后误删其他进程的锁
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// TryLock 基于 SET NX PX 的分布式锁
+func (r *Redis) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	lockKey := r.key(key)
+	token := uuid.NewString()
+	ok, err := r.client.SetNX(ctx, lockKey, token, ttl).Result()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return func() {
+		_ = unlockScript.Run(context.WithoutCancel(ctx), r.client, []string{lockKey}, token).Err()
+	}, true, nil
+}
+
 func (r *Redis) key(key string) string {
 	return fmt.Sprintf("%s:%s", r.keyPrefix, key)
 }
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// entryMagic 缓存条目头标识，无此头的数据按旧格式(纯序列化值)读取
+var entryMagic = []byte{0x00, 'T', 'C', 0x01}
+
+const entryHeaderSize = 4 + 8 + 8
+
+// entry 缓存条目，携带逻辑过期时间与加载耗时，用于 XFetch 与 stale-while-revalidate
+type entry struct {
+	payload  []byte
+	expireAt time.Time     // 逻辑过期时间，零值表示永不过期
+	delta    time.Duration // 最近一次加载耗时
+}
+
+func encodeEntry(payload []byte, ttl, delta time.Duration) []byte {
+	var expireAt int64
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl).UnixNano()
+	}
+	b := make([]byte, entryHeaderSize, entryHeaderSize+len(payload))
+	copy(b, entryMagic)
+	binary.BigEndian.PutUint64(b[4:12], uint64(expireAt))
+	binary.BigEndian.PutUint64(b[12:20], uint64(delta))
+	return append(b, payload...)
+}
+
+func decodeEntry(raw []byte) entry {
+	if len(raw) < entryHeaderSize || !bytes.Equal(raw[:4], entryMagic) {
+		return entry{payload: raw}
+	}
+	e := entry{
+		payload: raw[entryHeaderSize:],
+		delta:   time.Duration(binary.BigEndian.Uint64(raw[12:20])),
+	}
+	if ns := int64(binary.BigEndian.Uint64(raw[4:12])); ns > 0 {
+		e.expireAt = time.Unix(0, ns)
+	}
+	return e
+}
+
+// negative 是否为空值缓存，序列化后的有效值不会为空
+func (e entry) negative() bool {
+	return len(e.payload) == 0
+}
+
+// fresh 是否处于逻辑有效期内
+func (e entry) fresh(now time.Time) bool {
+	return e.expireAt.IsZero() || now.Before(e.expireAt)
+}
+
+// earlyExpired XFetch 概率提前过期：now - delta*beta*ln(rand) >= expireAt
+// 越接近过期、加载越慢，提前刷新的概率越大
+func (e entry) earlyExpired(now time.Time, beta float64) bool {
+	if beta <= 0 || e.delta <= 0 || e.expireAt.IsZero() {
+		return false
+	}
+	r := rand.Float64()
+	if r == 0 {
+		return true
+	}
+	gap := time.Duration(-float64(e.delta) * beta * math.Log(r))
+	return !now.Add(gap).Before(e.expireAt)
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/metrics"
+)
+
+// cacheMetrics 缓存监控指标，按命名空间打标签
+type cacheMetrics struct {
+	hits         metric.Int64Counter
+	misses       metric.Int64Counter
+	loads        metric.Int64Counter
+	loadErrors   metric.Int64Counter
+	loadDuration metric.Float64Histogram
+	evictions    metric.Int64Counter
+	bytes        metric.Int64Counter
+}
+
+var (
+	metricsOnce sync.Once
+	instruments *cacheMetrics
+)
+
+// getMetrics 指标在 metrics.Init 之后才可用，未初始化时返回nil
+func getMetrics() *cacheMetrics {
+	if metrics.Meter() == nil {
+		return nil
+	}
+	metricsOnce.Do(func() {
+		meter := metrics.Meter()
+		m := &cacheMetrics{}
+		m.hits, _ = meter.Int64Counter(
+			"cache.hits",
+			metric.WithDescription("Number of cache hits"),
+			metric.WithUnit("{hit}"),
+		)
+		m.misses, _ = meter.Int64Counter(
+			"cache.misses",
+			metric.WithDescription("Number of cache misses"),
+			metric.WithUnit("{miss}"),
+		)
+		m.loads, _ = meter.Int64Counter(
+			"cache.loads",
+			metric.WithDescription("Number of loader calls"),
+			metric.WithUnit("{load}"),
+		)
+		m.loadErrors, _ = meter.Int64Counter(
+			"cache.load.errors",
+			metric.WithDescription("Number of failed loader calls"),
+			metric.WithUnit("{error}"),
+		)
+		m.loadDuration, _ = meter.Float64Histogram(
+			"cache.load.duration",
+			metric.WithDescription("Duration of loader calls"),
+			metric.WithUnit("s"),
+		)
+		m.evictions, _ = meter.Int64Counter(
+			"cache.evictions",
+			metric.WithDescription("Number of entries evicted from the in-process tier"),
+			metric.WithUnit("{entry}"),
+		)
+		m.bytes, _ = meter.Int64Counter(
+			"cache.bytes",
+			metric.WithDescription("Bytes read from and written to the cache"),
+			metric.WithUnit("By"),
+		)
+		instruments = m
+	})
+	return instruments
+}
+
+func namespaceAttrs(ns string) metric.MeasurementOption {
+	return metric.WithAttributeSet(attribute.NewSet(attribute.String("cache.namespace", ns)))
+}
+
+func (c *TypedCache[T]) recordHit(ctx context.Context) {
+	if m := getMetrics(); m != nil {
+		m.hits.Add(ctx, 1, c.attrs)
+	}
+}
+
+func (c *TypedCache[T]) recordMiss(ctx context.Context) {
+	if m := getMetrics(); m != nil {
+		m.misses.Add(ctx, 1, c.attrs)
+	}
+}
+
+func (c *TypedCache[T]) recordLoad(ctx context.Context, seconds float64, failed bool) {
+	m := getMetrics()
+	if m == nil {
+		return
+	}
+	m.loads.Add(ctx, 1, c.attrs)
+	m.loadDuration.Record(ctx, seconds, c.attrs)
+	if failed {
+		m.loadErrors.Add(ctx, 1, c.attrs)
+	}
+}
+
+func (c *TypedCache[T]) recordBytes(ctx context.Context, op string, n int) {
+	if m := getMetrics(); m != nil && n > 0 {
+		m.bytes.Add(ctx, int64(n), metric.WithAttributes(
+			attribute.String("cache.namespace", c.ns),
+			attribute.String("cache.operation", op),
+		))
+	}
+}
+
+// recordEviction 记录L1淘汰，命名空间从缓存key中解析
+func recordEviction(key string) {
+	if m := getMetrics(); m != nil {
+		m.evictions.Add(context.Background(), 1, namespaceAttrs(namespaceOf(key)))
+	}
+}
+
+// namespaceOf 从缓存key中解析命名空间
+// 数据key格式 ns:{ns}|[tn:{tenant}|]k:{base}|t:...，标签版本key格式 __cv:{ns}:tag:[tn:{tenant}:]{tag}
+func namespaceOf(key string) string {
+	switch {
+	case strings.HasPrefix(key, "ns:"):
+		ns, _, _ := strings.Cut(strings.TrimPrefix(key, "ns:"), "|")
+		return ns
+	case strings.HasPrefix(key, "__cv:"):
+		ns, _, _ := strings.Cut(strings.TrimPrefix(key, "__cv:"), ":tag:")
+		return ns
+	default:
+		return ""
+	}
+}
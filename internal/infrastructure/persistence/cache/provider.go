@@ -1,31 +1,56 @@
 package cache
 
 import (
+	"sync"
 	"time"
 
+	"github.com/dysodeng/app/internal/infrastructure/config"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/contracts"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/driver"
 )
 
+var (
+	layeredOnce   sync.Once
+	layeredDriver *driver.Layered
+)
+
 // NewCacheDriver 创建缓存驱动
+// layered 两级缓存在进程内共享同一个L1与失效订阅
 func NewCacheDriver(driverName string) contracts.Cache {
 	switch driverName {
 	case "redis":
 		return driver.NewRedisCache()
+	case "layered":
+		layeredOnce.Do(func() {
+			cfg := config.GlobalConfig.Cache.Layered
+			layeredDriver = driver.NewLayeredCache(cfg.MaxEntries, cfg.L1TTL, recordEviction)
+		})
+		return layeredDriver
 	default:
 		return driver.NewMemoryCache()
 	}
 }
 
+// Close 关闭进程内共享的两级缓存失效订阅
+func Close() error {
+	if layeredDriver == nil {
+		return nil
+	}
+	return layeredDriver.Close()
+}
+
 // NewTypedCacheWith 优雅构建 TypedCache，包含默认 TTL 与序列化器选择
-// driverName: "memory" 或 "redis"
+// driverName: "memory"、"redis" 或 "layered"
 // namespace: 缓存命名空间前缀
 // ttl: 默认TTL（<=0 则不设置默认TTL）
-func NewTypedCacheWith[T any](driverName, namespace string, ttl time.Duration) *TypedCache[T] {
+func NewTypedCacheWith[T any](driverName, namespace string, ttl time.Duration) (*TypedCache[T], error) {
 	cacheDriver := NewCacheDriver(driverName)
-	tc := NewTypedCache[T](namespace, cacheDriver)
+	tc, err := NewTypedCache[T](namespace, cacheDriver)
+	if err != nil {
+		return nil, err
+	}
 	if ttl > 0 {
 		tc.WithDefaultTTL(ttl)
 	}
-	return tc
+	return tc, nil
 }
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/driver"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+func newRedisDriver(t *testing.T) (*driver.Redis, *miniredis.Miniredis) {
+	t.Helper()
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return driver.NewRedisCache(driver.WithRedisClient(client, "test")), mr
+}
+
+// lockKeys 当前持有的加载锁
+func lockKeys(mr *miniredis.Miniredis) []string {
+	var keys []string
+	for _, key := range mr.Keys() {
+		if strings.Contains(key, "__lock:") {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func TestRedisTryLock(t *testing.T) {
+	ctx := context.Background()
+	drv, mr := newRedisDriver(t)
+
+	unlock, ok, err := drv.TryLock(ctx, "k", time.Second)
+	if err != nil || !ok {
+		t.Fatalf("first lock = %v, %v", ok, err)
+	}
+	if _, ok, err = drv.TryLock(ctx, "k", time.Second); err != nil || ok {
+		t.Fatalf("second lock should fail, got %v, %v", ok, err)
+	}
+
+	// 锁过期后被其他节点持有，原持有者解锁不会删除他人的锁
+	mr.FastForward(2 * time.Second)
+	unlock2, ok, err := drv.TryLock(ctx, "k", time.Second)
+	if err != nil || !ok {
+		t.Fatalf("lock after expire = %v, %v", ok, err)
+	}
+	unlock()
+	if !mr.Exists("test:k") {
+		t.Fatal("expired holder removed the new lock")
+	}
+	unlock2()
+	if mr.Exists("test:k") {
+		t.Fatal("lock should be released")
+	}
+}
+
+func TestGetOrLoadRedisLock(t *testing.T) {
+	ctx := context.Background()
+	drv, mr := newRedisDriver(t)
+	newNode := func() *TypedCache[string] {
+		c, err := NewTypedCache[string]("test", drv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.WithEarlyExpiration(0).WithDistributedLock(time.Second, time.Second)
+	}
+	nodeA, nodeB := newNode(), newNode()
+
+	// 节点A持锁加载期间，节点B等待A回写而不重复加载
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := nodeA.GetOrLoad(ctx, "k", time.Minute, func(context.Context) (string, error) {
+			close(started)
+			<-release
+			return "a", nil
+		})
+		done <- err
+	}()
+	<-started
+	if keys := lockKeys(mr); len(keys) != 1 {
+		t.Fatalf("lock keys = %v", keys)
+	}
+
+	var calls atomic.Int32
+	go func() {
+		time.Sleep(2 * lockPollInterval)
+		close(release)
+	}()
+	v, err := nodeB.GetOrLoad(ctx, "k", time.Minute, func(context.Context) (string, error) {
+		calls.Add(1)
+		return "b", nil
+	})
+	if err != nil || v != "a" || calls.Load() != 0 {
+		t.Fatalf("node B = %q, %v, loader calls = %d", v, err, calls.Load())
+	}
+	if err = <-done; err != nil {
+		t.Fatal(err)
+	}
+	if keys := lockKeys(mr); len(keys) != 0 {
+		t.Fatalf("lock not released: %v", keys)
+	}
+
+	// 强制刷新时其他节点持锁直接返回，不等待
+	key, _ := nodeA.buildKey(ctx, "k", nil)
+	unlock, ok, _ := drv.TryLock(ctx, nodeA.lockKey(key), time.Second)
+	if !ok {
+		t.Fatal("lock should be acquired")
+	}
+	defer unlock()
+	if _, err = nodeB.refresh(ctx, key, time.Minute, func(context.Context) (string, error) { return "b", nil }); !errors.Is(err, errRefreshing) {
+		t.Fatalf("refresh err = %v, want errRefreshing", err)
+	}
+}
+
+func TestRefreshAsyncWithoutTx(t *testing.T) {
+	c := newTestCache(t).WithStaleWhileRevalidate(time.Minute)
+
+	// 后台刷新不继承请求的事务
+	ctx := context.WithValue(context.Background(), transactions.TxKey{}, "tx")
+	if _, err := c.GetOrLoad(ctx, "k", time.Millisecond, func(context.Context) (string, error) { return "v1", nil }); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	inTx := make(chan bool, 1)
+	if _, err := c.GetOrLoad(ctx, "k", time.Minute, func(ctx context.Context) (string, error) {
+		inTx <- ctx.Value(transactions.TxKey{}) != nil
+		return "v2", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case tx := <-inTx:
+		if tx {
+			t.Fatal("background refresh should not run in the caller's transaction")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("background refresh not triggered")
+	}
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TagVersion 标签版本
+type TagVersion struct {
+	Tag     string `json:"tag"`
+	Version string `json:"version"`
+}
+
+// KeyInfo 缓存key诊断信息，不包含缓存值
+type KeyInfo struct {
+	Namespace    string        `json:"namespace"`
+	Key          string        `json:"key"`
+	Tags         []TagVersion  `json:"tags"`
+	Exists       bool          `json:"exists"`
+	Negative     bool          `json:"negative"`
+	Fresh        bool          `json:"fresh"`
+	ExpireAt     *time.Time    `json:"expire_at"`
+	LoadDuration time.Duration `json:"load_duration"`
+	Size         int           `json:"size"`
+}
+
+// Inspector 缓存命名空间诊断与清理
+type Inspector interface {
+	// Namespace 命名空间
+	Namespace() string
+	// Inspect 查看缓存key及其标签版本
+	Inspect(ctx context.Context, base string, tags ...string) (*KeyInfo, error)
+	// InvalidateTags 标签失效
+	InvalidateTags(ctx context.Context, tags ...string) error
+	// Purge 清空命名空间下的全部缓存数据
+	Purge(ctx context.Context) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Inspector)
+)
+
+// register 登记命名空间，同名命名空间以最后创建的为准
+func register(inspector Inspector) {
+	if inspector.Namespace() == "" {
+		return
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[inspector.Namespace()] = inspector
+}
+
+// Lookup 按命名空间查找缓存
+func Lookup(namespace string) (Inspector, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	inspector, ok := registry[namespace]
+	return inspector, ok
+}
+
+// Namespaces 已登记的命名空间
+func Namespaces() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	list := make([]string, 0, len(registry))
+	for ns := range registry {
+		list = append(list, ns)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,100 @@
+package serializer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/contracts"
+)
+
+// Compression 压缩算法，写入数据头，解码时按数据头识别，与当前配置无关
+type Compression byte
+
+const (
+	CompressionNone   Compression = 0
+	CompressionZstd   Compression = 1
+	CompressionSnappy Compression = 2
+)
+
+// frameMagic 包装层数据头首字节，JSON 不以该字节开头，msgpack 中该字节仅表示单字节的 -2
+const frameMagic byte = 0xFE
+
+// compressFrame 压缩数据头：magic + 'c' + 算法
+var compressFrame = []byte{frameMagic, 'c'}
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// ParseCompression 解析压缩算法名称，未知名称视为不压缩
+func ParseCompression(name string) Compression {
+	switch name {
+	case "zstd":
+		return CompressionZstd
+	case "snappy":
+		return CompressionSnappy
+	default:
+		return CompressionNone
+	}
+}
+
+type compressSerializer[T any] struct {
+	inner     contracts.Serializer[T]
+	algorithm Compression
+	threshold int
+}
+
+// NewCompressSerializer 压缩包装，编码结果不小于 threshold 字节时压缩
+// 解码兼容未经包装的旧数据及任意算法压缩的数据，切换算法无需清空缓存
+// algorithm 为 CompressionNone 时编码不加数据头，仅用于解码已压缩的数据
+func NewCompressSerializer[T any](inner contracts.Serializer[T], algorithm Compression, threshold int) contracts.Serializer[T] {
+	return &compressSerializer[T]{inner: inner, algorithm: algorithm, threshold: threshold}
+}
+
+func (s *compressSerializer[T]) Encode(v T) ([]byte, error) {
+	b, err := s.inner.Encode(v)
+	if err != nil || s.algorithm == CompressionNone {
+		return b, err
+	}
+	algorithm := s.algorithm
+	if len(b) < s.threshold {
+		algorithm = CompressionNone
+	}
+
+	out := append(append(make([]byte, 0, len(compressFrame)+1+len(b)), compressFrame...), byte(algorithm))
+	switch algorithm {
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(b, out), nil
+	case CompressionSnappy:
+		return append(out, snappy.Encode(nil, b)...), nil
+	default:
+		return append(out, b...), nil
+	}
+}
+
+func (s *compressSerializer[T]) Decode(b []byte) (T, error) {
+	if len(b) <= len(compressFrame) || !bytes.HasPrefix(b, compressFrame) {
+		return s.inner.Decode(b)
+	}
+
+	algorithm, payload := Compression(b[len(compressFrame)]), b[len(compressFrame)+1:]
+	var err error
+	switch algorithm {
+	case CompressionNone:
+	case CompressionZstd:
+		payload, err = zstdDecoder.DecodeAll(payload, nil)
+	case CompressionSnappy:
+		payload, err = snappy.Decode(nil, payload)
+	default:
+		err = fmt.Errorf("cache serializer: unknown compression %d", algorithm)
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return s.inner.Decode(payload)
+}
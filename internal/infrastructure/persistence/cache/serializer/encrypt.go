@@ -0,0 +1,128 @@
+package serializer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/contracts"
+)
+
+// encryptFrame 加密数据头：magic + 'e' + keyID长度 + keyID + nonce + 密文
+var encryptFrame = []byte{frameMagic, 'e'}
+
+// Keyring AES-GCM 密钥环，使用主密钥加密，按数据头中的密钥ID解密，支持密钥轮换
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring 创建密钥环
+// primary: 主密钥ID，用于加密
+// keys: 密钥ID => 密钥(16/24/32字节)，轮换期间保留旧密钥用于解密
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("cache keyring: primary key %q not found", primary)
+	}
+	kr := &Keyring{primary: primary, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || len(id) > 255 {
+			return nil, fmt.Errorf("cache keyring: invalid key id %q", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("cache keyring: key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		kr.keys[id] = aead
+	}
+	return kr, nil
+}
+
+func (kr *Keyring) seal(plaintext []byte) ([]byte, error) {
+	aead := kr.keys[kr.primary]
+	out := make([]byte, 0, len(encryptFrame)+1+len(kr.primary)+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	out = append(out, encryptFrame...)
+	out = append(out, byte(len(kr.primary)))
+	out = append(out, kr.primary...)
+	header := len(out)
+
+	nonce := out[header : header+aead.NonceSize()]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out = out[:header+aead.NonceSize()]
+	// 数据头作为附加数据参与认证，防止篡改密钥ID
+	return aead.Seal(out, nonce, plaintext, out[:header]), nil
+}
+
+func (kr *Keyring) open(b []byte) ([]byte, error) {
+	pos := len(encryptFrame)
+	idLen := int(b[pos])
+	pos++
+	if len(b) < pos+idLen {
+		return nil, errors.New("cache keyring: malformed frame")
+	}
+	id := string(b[pos : pos+idLen])
+	pos += idLen
+	aead, ok := kr.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("cache keyring: unknown key %q", id)
+	}
+	if len(b) < pos+aead.NonceSize() {
+		return nil, errors.New("cache keyring: malformed frame")
+	}
+	nonce := b[pos : pos+aead.NonceSize()]
+	return aead.Open(nil, nonce, b[pos+aead.NonceSize():], b[:pos])
+}
+
+// ErrKeyringUnavailable 数据已加密但未配置密钥环
+var ErrKeyringUnavailable = errors.New("cache keyring: encrypted data but encryption is disabled")
+
+type encryptSerializer[T any] struct {
+	inner      contracts.Serializer[T]
+	keyring    *Keyring
+	decodeOnly bool
+}
+
+// NewEncryptSerializer AES-GCM 加密包装，需包在压缩包装外层
+// 解码兼容未加密的旧数据，开启加密无需清空缓存
+func NewEncryptSerializer[T any](inner contracts.Serializer[T], keyring *Keyring) contracts.Serializer[T] {
+	return &encryptSerializer[T]{inner: inner, keyring: keyring}
+}
+
+// NewDecryptSerializer 仅解密包装，编码不加密，用于未开启加密的命名空间读取此前加密写入的数据
+// keyring 为 nil 时遇到加密数据返回 ErrKeyringUnavailable
+func NewDecryptSerializer[T any](inner contracts.Serializer[T], keyring *Keyring) contracts.Serializer[T] {
+	return &encryptSerializer[T]{inner: inner, keyring: keyring, decodeOnly: true}
+}
+
+func (s *encryptSerializer[T]) Encode(v T) ([]byte, error) {
+	b, err := s.inner.Encode(v)
+	if err != nil || s.decodeOnly {
+		return b, err
+	}
+	return s.keyring.seal(b)
+}
+
+func (s *encryptSerializer[T]) Decode(b []byte) (T, error) {
+	if len(b) <= len(encryptFrame) || !bytes.HasPrefix(b, encryptFrame) {
+		return s.inner.Decode(b)
+	}
+	if s.keyring == nil {
+		var zero T
+		return zero, ErrKeyringUnavailable
+	}
+	plaintext, err := s.keyring.open(b)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return s.inner.Decode(plaintext)
+}
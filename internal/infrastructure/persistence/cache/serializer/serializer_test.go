@@ -0,0 +1,87 @@
+package serializer
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type payload struct {
+	Telephone string `json:"telephone"`
+	Bio       string `json:"bio"`
+}
+
+func TestCompressSerializer(t *testing.T) {
+	v := payload{Telephone: "13800000000", Bio: strings.Repeat("a", 4096)}
+	for _, algorithm := range []Compression{CompressionZstd, CompressionSnappy} {
+		s := NewCompressSerializer(NewJSONSerializer[payload](), algorithm, 1024)
+		b, err := s.Encode(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b) >= 4096 {
+			t.Fatalf("algorithm %d: not compressed, size %d", algorithm, len(b))
+		}
+		// 任意算法写入的数据均可被其他算法配置读取
+		got, err := NewCompressSerializer(NewJSONSerializer[payload](), CompressionNone, 0).Decode(b)
+		if err != nil || got != v {
+			t.Fatalf("algorithm %d: decode = %+v, %v", algorithm, got, err)
+		}
+	}
+
+	// 未包装的旧数据
+	got, err := NewCompressSerializer(NewJSONSerializer[payload](), CompressionZstd, 0).Decode([]byte(`{"telephone":"1"}`))
+	if err != nil || got.Telephone != "1" {
+		t.Fatalf("decode legacy = %+v, %v", got, err)
+	}
+}
+
+func TestEncryptSerializerKeyRotation(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)
+	v := payload{Telephone: "13800000000"}
+
+	oldRing, err := NewKeyring("k1", map[string][]byte{"k1": oldKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewEncryptSerializer(NewJSONSerializer[payload](), oldRing).Encode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte(v.Telephone)) {
+		t.Fatal("plaintext leaked")
+	}
+
+	// 轮换后主密钥为k2，仍可解密k1加密的数据
+	newRing, err := NewKeyring("k2", map[string][]byte{"k1": oldKey, "k2": newKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewEncryptSerializer(NewCompressSerializer(NewJSONSerializer[payload](), CompressionZstd, 0), newRing)
+	if got, err := s.Decode(b); err != nil || got != v {
+		t.Fatalf("decode rotated = %+v, %v", got, err)
+	}
+
+	// 关闭加密与压缩后仍可读取此前加密压缩写入的数据
+	b, err = s.Encode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := NewCompressSerializer(NewJSONSerializer[payload](), CompressionNone, 0)
+	if got, err := NewDecryptSerializer(plain, newRing).Decode(b); err != nil || got != v {
+		t.Fatalf("decode after disabling = %+v, %v", got, err)
+	}
+	if _, err := NewDecryptSerializer(plain, nil).Decode(b); !errors.Is(err, ErrKeyringUnavailable) {
+		t.Fatalf("decode without keyring = %v", err)
+	}
+	if out, _ := NewDecryptSerializer(plain, newRing).Encode(v); !bytes.Equal(out, []byte(`{"telephone":"13800000000","bio":""}`)) {
+		t.Fatalf("decrypt-only encode = %s", out)
+	}
+
+	// 篡改密文
+	b[len(b)-1] ^= 0xFF
+	if _, err := s.Decode(b); err == nil {
+		t.Fatal("tampered ciphertext should fail")
+	}
+}
@@ -2,16 +2,32 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
+
 	"github.com/dysodeng/app/internal/infrastructure/config"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/contracts"
-	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/serializer"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
 )
 
+// lockPollInterval 未抢到加载锁时轮询缓存的间隔
+const lockPollInterval = 50 * time.Millisecond
+
+// errRefreshing 其他节点正在刷新
+var errRefreshing = errors.New("cache: refreshing by another node")
+
+// ErrNotFound 数据不存在
+// loader 返回该错误时会写入空值缓存(需开启 WithNegativeTTL)，命中空值缓存时 GetOrLoad 同样返回该错误
+var ErrNotFound = errors.New("cache: not found")
+
 // TypedCache 基于泛型的强类型缓存，支持标签失效与单航班防击穿
 type TypedCache[T any] struct {
 	ns         string
@@ -19,19 +35,42 @@ type TypedCache[T any] struct {
 	serializer contracts.Serializer[T]
 	sf         SFGroup[T]
 	defaultTTL time.Duration
+	negTTL     time.Duration // 空值缓存TTL，<=0 不缓存空值
+	beta       float64       // XFetch 提前过期系数
+	staleTTL   time.Duration // stale-while-revalidate 窗口
+	lockTTL    time.Duration // 分布式加载锁过期时间，<=0 不加锁
+	lockWait   time.Duration // 未抢到锁时的最长等待时间
+	refreshing sync.Map      // 后台刷新中的key
+	attrs      metric.MeasurementOption
 }
 
 // NewTypedCache 创建类型缓存
-func NewTypedCache[T any](namespace string, cache contracts.Cache) *TypedCache[T] {
-	s := serializer.NewJSONSerializer[T]()
-	if config.GlobalConfig.Cache.Serializer == "msgpack" {
-		s = serializer.NewMsgpackSerializer[T]()
+func NewTypedCache[T any](namespace string, cache contracts.Cache) (*TypedCache[T], error) {
+	namespace = strings.TrimSpace(namespace)
+	s, err := newSerializer[T](namespace)
+	if err != nil {
+		return nil, err
 	}
-	return &TypedCache[T]{
-		ns:         strings.TrimSpace(namespace),
+	stampede := config.GlobalConfig.Cache.Stampede
+	c := &TypedCache[T]{
+		ns:         namespace,
 		cache:      cache,
 		serializer: s,
+		negTTL:     config.GlobalConfig.Cache.NegativeTTL,
+		beta:       stampede.Beta,
+		staleTTL:   stampede.StaleTTL,
+		attrs:      namespaceAttrs(namespace),
+	}
+	if stampede.Lock {
+		c.WithDistributedLock(stampede.LockTTL, stampede.LockWait)
 	}
+	register(c)
+	return c, nil
+}
+
+// Namespace 命名空间
+func (c *TypedCache[T]) Namespace() string {
+	return c.ns
 }
 
 func (c *TypedCache[T]) WithSerializer(serializer contracts.Serializer[T]) *TypedCache[T] {
@@ -44,17 +83,57 @@ func (c *TypedCache[T]) WithDefaultTTL(ttl time.Duration) *TypedCache[T] {
 	return c
 }
 
-func (c *TypedCache[T]) versionKey(tag string) string {
+// WithNegativeTTL 设置空值缓存TTL，<=0 关闭
+func (c *TypedCache[T]) WithNegativeTTL(ttl time.Duration) *TypedCache[T] {
+	c.negTTL = ttl
+	return c
+}
+
+// WithEarlyExpiration 设置 XFetch 提前过期系数，<=0 关闭
+func (c *TypedCache[T]) WithEarlyExpiration(beta float64) *TypedCache[T] {
+	c.beta = beta
+	return c
+}
+
+// WithStaleWhileRevalidate 设置过期后仍返回旧值的时间窗口，<=0 关闭
+func (c *TypedCache[T]) WithStaleWhileRevalidate(staleTTL time.Duration) *TypedCache[T] {
+	c.staleTTL = staleTTL
+	return c
+}
+
+// WithDistributedLock 加载数据时使用分布式锁，仅对实现了 contracts.Locker 的驱动生效，ttl<=0 关闭
+func (c *TypedCache[T]) WithDistributedLock(ttl, wait time.Duration) *TypedCache[T] {
+	c.lockTTL = ttl
+	c.lockWait = wait
+	if c.lockWait <= 0 {
+		c.lockWait = ttl
+	}
+	return c
+}
+
+// versionKey 标签版本key，上下文携带租户时标签按租户隔离
+func (c *TypedCache[T]) versionKey(ctx context.Context, tag string) string {
+	if id, ok := tenant.FromContext(ctx); ok {
+		tag = "tn:" + id + ":" + tag
+	}
 	return fmt.Sprintf("__cv:%s:tag:%s", c.ns, tag)
 }
 
-func (c *TypedCache[T]) buildKey(base string, tags []string) (string, error) {
+// keyPrefix 数据key前缀，上下文携带租户时按租户隔离
+func (c *TypedCache[T]) keyPrefix(ctx context.Context) string {
+	if id, ok := tenant.FromContext(ctx); ok {
+		return fmt.Sprintf("ns:%s|tn:%s|", c.ns, id)
+	}
+	return fmt.Sprintf("ns:%s|", c.ns)
+}
+
+func (c *TypedCache[T]) buildKey(ctx context.Context, base string, tags []string) (string, error) {
 	// 固定顺序，避免同一集合不同顺序造成缓存击穿
 	sort.Strings(tags)
-	parts := []string{fmt.Sprintf("ns:%s", c.ns), fmt.Sprintf("k:%s", base)}
+	parts := []string{c.keyPrefix(ctx) + fmt.Sprintf("k:%s", base)}
 	for _, tag := range tags {
 		// 获取标签版本，不存在视为0
-		verBytes, _ := c.cache.Get(context.Background(), c.versionKey(tag))
+		verBytes, _ := c.cache.Get(context.WithoutCancel(ctx), c.versionKey(ctx, tag))
 		ver := "0"
 		if len(verBytes) > 0 {
 			ver = string(verBytes)
@@ -66,23 +145,38 @@ func (c *TypedCache[T]) buildKey(base string, tags []string) (string, error) {
 
 // Get 从缓存获取
 func (c *TypedCache[T]) Get(ctx context.Context, base string, tags ...string) (T, bool, error) {
-	key, err := c.buildKey(base, tags)
+	key, err := c.buildKey(ctx, base, tags)
 	if err != nil {
 		var zero T
 		return zero, false, err
 	}
 	b, err := c.cache.Get(ctx, key)
 	if err != nil || len(b) == 0 {
+		c.recordMiss(ctx)
 		var zero T
 		return zero, false, err
 	}
-	val, err := c.serializer.Decode(b)
-	return val, err == nil, err
+	c.recordBytes(ctx, "read", len(b))
+	e := decodeEntry(b)
+	if e.negative() || !e.fresh(time.Now()) {
+		c.recordMiss(ctx)
+		var zero T
+		return zero, false, nil
+	}
+	// 无法解码(如序列化或密钥配置变更)视为未命中
+	val, err := c.serializer.Decode(e.payload)
+	if err != nil {
+		c.recordMiss(ctx)
+		var zero T
+		return zero, false, nil
+	}
+	c.recordHit(ctx)
+	return val, true, nil
 }
 
 // Set 写入缓存
 func (c *TypedCache[T]) Set(ctx context.Context, base string, val T, ttl time.Duration, tags ...string) error {
-	key, err := c.buildKey(base, tags)
+	key, err := c.buildKey(ctx, base, tags)
 	if err != nil {
 		return err
 	}
@@ -93,12 +187,14 @@ func (c *TypedCache[T]) Set(ctx context.Context, base string, val T, ttl time.Du
 	if ttl <= 0 {
 		ttl = c.defaultTTL
 	}
-	return c.cache.Set(ctx, key, b, ttl)
+	b = encodeEntry(b, ttl, 0)
+	c.recordBytes(ctx, "write", len(b))
+	return c.cache.Set(ctx, key, b, c.storeTTL(ttl))
 }
 
 // Delete 删除缓存
 func (c *TypedCache[T]) Delete(ctx context.Context, base string, tags ...string) error {
-	key, err := c.buildKey(base, tags)
+	key, err := c.buildKey(ctx, base, tags)
 	if err != nil {
 		return err
 	}
@@ -108,57 +204,250 @@ func (c *TypedCache[T]) Delete(ctx context.Context, base string, tags ...string)
 // InvalidateTags 标签失效（版本 +1），O(1) 完成，无需扫描
 func (c *TypedCache[T]) InvalidateTags(ctx context.Context, tags ...string) error {
 	for _, tag := range tags {
-		if _, err := c.cache.Incr(ctx, c.versionKey(tag)); err != nil {
+		if _, err := c.cache.Incr(ctx, c.versionKey(ctx, tag)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// Purge 清空命名空间下的全部缓存数据，标签版本保留
+// 上下文携带租户时仅清空该租户的数据
+func (c *TypedCache[T]) Purge(ctx context.Context) error {
+	return c.cache.ScanDeleteByPrefix(ctx, c.keyPrefix(ctx))
+}
+
+// Inspect 查看缓存key元数据及其标签版本，不解码缓存值
+func (c *TypedCache[T]) Inspect(ctx context.Context, base string, tags ...string) (*KeyInfo, error) {
+	key, err := c.buildKey(ctx, base, tags)
+	if err != nil {
+		return nil, err
+	}
+	info := &KeyInfo{Namespace: c.ns, Key: key, Tags: make([]TagVersion, 0, len(tags))}
+	for _, tag := range tags {
+		ver, err := c.cache.Get(ctx, c.versionKey(ctx, tag))
+		if err != nil {
+			return nil, err
+		}
+		tv := TagVersion{Tag: tag, Version: "0"}
+		if len(ver) > 0 {
+			tv.Version = string(ver)
+		}
+		info.Tags = append(info.Tags, tv)
+	}
+
+	raw, err := c.cache.Get(ctx, key)
+	if err != nil || len(raw) == 0 {
+		return info, err
+	}
+	e := decodeEntry(raw)
+	info.Exists = true
+	info.Size = len(raw)
+	info.Negative = e.negative()
+	info.Fresh = e.fresh(time.Now())
+	info.LoadDuration = e.delta
+	if !e.expireAt.IsZero() {
+		info.ExpireAt = &e.expireAt
+	}
+	return info, nil
+}
+
 // BatchDeleteByPrefix 前缀删除（用于紧急清理，或老组件兼容）
 func (c *TypedCache[T]) BatchDeleteByPrefix(ctx context.Context, prefix string) error {
 	return c.cache.ScanDeleteByPrefix(ctx, prefix)
 }
 
 // GetOrLoad 旁路缓存 + 单航班防击穿
+// 开启 XFetch 时临近过期的数据会被概率性提前刷新；开启 stale-while-revalidate 时过期窗口内返回旧值并后台刷新；
+// 开启分布式锁时(仅redis驱动)同一时刻只有一个节点执行 loader，其余节点等待其回写缓存
 func (c *TypedCache[T]) GetOrLoad(ctx context.Context, base string, ttl time.Duration, loader func(context.Context) (T, error), tags ...string) (T, error) {
 	var zero T
-	key, err := c.buildKey(base, tags)
+	key, err := c.buildKey(ctx, base, tags)
 	if err != nil {
 		return zero, err
 	}
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
 
 	// 先读缓存
 	if raw, err := c.cache.Get(ctx, key); err == nil && len(raw) > 0 {
-		return c.serializer.Decode(raw)
+		c.recordBytes(ctx, "read", len(raw))
+		e := decodeEntry(raw)
+		now := time.Now()
+		switch {
+		case e.negative():
+			if e.fresh(now) {
+				c.recordHit(ctx)
+				return zero, ErrNotFound
+			}
+		case e.fresh(now):
+			// 无法解码(如序列化或密钥配置变更)视为未命中，重新加载
+			if v, err := c.serializer.Decode(e.payload); err == nil {
+				c.recordHit(ctx)
+				if !e.earlyExpired(now, c.beta) {
+					return v, nil
+				}
+				// XFetch 命中：由当前请求提前刷新，刷新失败或其他节点正在刷新时返回现有值
+				if rv, err := c.refresh(ctx, key, ttl, loader); err == nil || errors.Is(err, ErrNotFound) {
+					return rv, err
+				}
+				return v, nil
+			}
+		case c.staleTTL > 0:
+			// 处于过期窗口内：返回旧值，后台刷新
+			if v, err := c.serializer.Decode(e.payload); err == nil {
+				c.recordHit(ctx)
+				c.refreshAsync(ctx, key, ttl, loader)
+				return v, nil
+			}
+		}
 	}
 
+	c.recordMiss(ctx)
+
 	// singleflight 防击穿
 	val, err := c.sf.Do(ctx, key, func() (T, error) {
 		// 双检：避免并发间隙重复加载
-		if raw, e := c.cache.Get(ctx, key); e == nil && len(raw) > 0 {
-			return c.serializer.Decode(raw)
+		if v, ok, err := c.getFresh(ctx, key); ok {
+			return v, err
 		}
+		return c.loadWithLock(ctx, key, ttl, loader, true)
+	})
+	if err != nil {
+		return zero, err
+	}
 
-		// 真正加载
-		v, e := loader(ctx)
-		if e != nil {
-			var z T
-			return z, e
+	return val, nil
+}
+
+// refresh 强制刷新缓存，其他节点持有加载锁时返回 errRefreshing
+func (c *TypedCache[T]) refresh(ctx context.Context, key string, ttl time.Duration, loader func(context.Context) (T, error)) (T, error) {
+	return c.sf.Do(ctx, key, func() (T, error) {
+		return c.loadWithLock(ctx, key, ttl, loader, false)
+	})
+}
+
+// refreshAsync 后台刷新，同一进程内同一个key只保留一个刷新任务
+func (c *TypedCache[T]) refreshAsync(ctx context.Context, key string, ttl time.Duration, loader func(context.Context) (T, error)) {
+	if _, loaded := c.refreshing.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	// 后台刷新在请求结束后执行，脱离请求的取消与事务
+	ctx = transactions.WithoutTx(context.WithoutCancel(ctx))
+	go func() {
+		defer c.refreshing.Delete(key)
+		if _, err := c.refresh(ctx, key, ttl, loader); err != nil && !errors.Is(err, errRefreshing) {
+			logger.Warn(ctx, "缓存后台刷新失败", logger.AddField("key", key), logger.ErrorField(err))
 		}
+	}()
+}
 
-		// 回写缓存
-		if enc, e := c.serializer.Encode(v); e == nil {
-			if ttl <= 0 {
-				ttl = c.defaultTTL
-			}
-			_ = c.cache.Set(ctx, key, enc, ttl)
+// loadWithLock 在分布式锁保护下执行加载
+// wait=true 时未抢到锁会等待持锁节点回写缓存，超时后自行加载；wait=false 时直接返回 errRefreshing
+func (c *TypedCache[T]) loadWithLock(ctx context.Context, key string, ttl time.Duration, loader func(context.Context) (T, error), wait bool) (T, error) {
+	locker, ok := c.cache.(contracts.Locker)
+	if !ok || c.lockTTL <= 0 {
+		return c.load(ctx, key, ttl, loader)
+	}
+
+	unlock, acquired, err := locker.TryLock(ctx, c.lockKey(key), c.lockTTL)
+	if err != nil {
+		// 锁不可用时降级为直接加载
+		return c.load(ctx, key, ttl, loader)
+	}
+	if !acquired {
+		if !wait {
+			var zero T
+			return zero, errRefreshing
 		}
-		return v, nil
-	})
+		if v, ok, err := c.waitFresh(ctx, key); ok {
+			return v, err
+		}
+		// 等待超时(持锁节点异常或加载过慢)，自行加载
+		return c.load(ctx, key, ttl, loader)
+	}
+	defer unlock()
+
+	if wait {
+		// 抢锁期间其他节点可能已回写
+		if v, ok, err := c.getFresh(ctx, key); ok {
+			return v, err
+		}
+	}
+	return c.load(ctx, key, ttl, loader)
+}
+
+// load 执行 loader 并回写缓存，记录加载耗时供 XFetch 使用
+func (c *TypedCache[T]) load(ctx context.Context, key string, ttl time.Duration, loader func(context.Context) (T, error)) (T, error) {
+	start := time.Now()
+	v, err := loader(ctx)
+	c.recordLoad(ctx, time.Since(start).Seconds(), err != nil && !errors.Is(err, ErrNotFound))
 	if err != nil {
+		if errors.Is(err, ErrNotFound) && c.negTTL > 0 {
+			_ = c.cache.Set(ctx, key, encodeEntry(nil, c.negTTL, 0), c.negTTL)
+		}
+		var zero T
 		return zero, err
 	}
+	if enc, err := c.serializer.Encode(v); err == nil {
+		enc = encodeEntry(enc, ttl, time.Since(start))
+		c.recordBytes(ctx, "write", len(enc))
+		_ = c.cache.Set(ctx, key, enc, c.storeTTL(ttl))
+	}
+	return v, nil
+}
 
-	return val, nil
+// waitFresh 轮询等待缓存被其他节点回写
+func (c *TypedCache[T]) waitFresh(ctx context.Context, key string) (T, bool, error) {
+	var zero T
+	timer := time.NewTimer(c.lockWait)
+	defer timer.Stop()
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return zero, false, nil
+		case <-timer.C:
+			return zero, false, nil
+		case <-ticker.C:
+			if v, ok, err := c.getFresh(ctx, key); ok {
+				return v, true, err
+			}
+		}
+	}
+}
+
+// getFresh 读取逻辑有效期内的缓存值，命中空值缓存时返回 ErrNotFound
+func (c *TypedCache[T]) getFresh(ctx context.Context, key string) (T, bool, error) {
+	var zero T
+	raw, err := c.cache.Get(ctx, key)
+	if err != nil || len(raw) == 0 {
+		return zero, false, nil
+	}
+	e := decodeEntry(raw)
+	if !e.fresh(time.Now()) {
+		return zero, false, nil
+	}
+	if e.negative() {
+		return zero, true, ErrNotFound
+	}
+	v, err := c.serializer.Decode(e.payload)
+	if err != nil {
+		return zero, false, nil
+	}
+	return v, true, nil
+}
+
+// storeTTL 实际存储TTL = 逻辑TTL + stale窗口
+func (c *TypedCache[T]) storeTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return ttl
+	}
+	return ttl + c.staleTTL
+}
+
+func (c *TypedCache[T]) lockKey(key string) string {
+	return "__lock:" + key
 }
@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/cache/driver"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
+)
+
+func newTestCache(t *testing.T) *TypedCache[string] {
+	t.Helper()
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+	c, err := NewTypedCache[string]("test", driver.NewMemoryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.WithEarlyExpiration(0)
+}
+
+func TestGetOrLoadStaleWhileRevalidate(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache(t).WithStaleWhileRevalidate(time.Minute)
+
+	var calls atomic.Int32
+	loader := func(context.Context) (string, error) {
+		n := calls.Add(1)
+		return map[int32]string{1: "v1", 2: "v2"}[n], nil
+	}
+
+	if v, err := c.GetOrLoad(ctx, "k", 20*time.Millisecond, loader); err != nil || v != "v1" {
+		t.Fatalf("first load = %q, %v", v, err)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// 逻辑过期但处于stale窗口内：返回旧值并触发后台刷新
+	if v, err := c.GetOrLoad(ctx, "k", 20*time.Millisecond, loader); err != nil || v != "v1" {
+		t.Fatalf("stale load = %q, %v", v, err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if v, ok, _ := c.Get(ctx, "k"); ok && v == "v2" {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("background refresh not applied, loader calls = %d", calls.Load())
+}
+
+func TestGetOrLoadEarlyExpiration(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache(t)
+
+	var calls atomic.Int32
+	loader := func(context.Context) (string, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return "v", nil
+	}
+	if _, err := c.GetOrLoad(ctx, "k", time.Hour, loader); err != nil {
+		t.Fatal(err)
+	}
+
+	// 关闭 XFetch 时有效期内不会重新加载
+	if _, err := c.GetOrLoad(ctx, "k", time.Hour, loader); err != nil || calls.Load() != 1 {
+		t.Fatalf("unexpected reload, calls = %d, err = %v", calls.Load(), err)
+	}
+
+	// 系数足够大时必然提前刷新
+	c.WithEarlyExpiration(1e9)
+	if _, err := c.GetOrLoad(ctx, "k", time.Hour, loader); err != nil || calls.Load() != 2 {
+		t.Fatalf("expected early refresh, calls = %d, err = %v", calls.Load(), err)
+	}
+}
+
+func TestGetLegacyEntry(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache(t)
+
+	key, _ := c.buildKey(ctx, "k", nil)
+	_ = c.cache.Set(ctx, key, []byte(`"legacy"`), time.Minute)
+
+	v, ok, err := c.Get(ctx, "k")
+	if err != nil || !ok || v != "legacy" {
+		t.Fatalf("Get legacy = %q, %v, %v", v, ok, err)
+	}
+}
+
+func TestGetOrLoadUndecodableEntry(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache(t)
+
+	// 加密写入后关闭了加密：数据无法解码时视为未命中并重新加载
+	key, _ := c.buildKey(ctx, "k", nil)
+	_ = c.cache.Set(ctx, key, encodeEntry([]byte{0xFE, 'e', 2, 'k', '1'}, time.Minute, 0), time.Minute)
+
+	if _, ok, err := c.Get(ctx, "k"); ok || err != nil {
+		t.Fatalf("Get undecodable = %v, %v", ok, err)
+	}
+	v, err := c.GetOrLoad(ctx, "k", time.Minute, func(context.Context) (string, error) { return "v", nil })
+	if err != nil || v != "v" {
+		t.Fatalf("GetOrLoad undecodable = %q, %v", v, err)
+	}
+	if v, ok, err := c.Get(ctx, "k"); err != nil || !ok || v != "v" {
+		t.Fatalf("Get reloaded = %q, %v, %v", v, ok, err)
+	}
+}
+
+func TestGetOrLoadNegativeCache(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache(t).WithNegativeTTL(time.Minute)
+
+	var calls atomic.Int32
+	loader := func(context.Context) (string, error) {
+		calls.Add(1)
+		return "", ErrNotFound
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetOrLoad(ctx, "missing", time.Minute, loader); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("err = %v, want ErrNotFound", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("loader calls = %d, want 1", calls.Load())
+	}
+	if _, ok, _ := c.Get(ctx, "missing"); ok {
+		t.Fatal("negative entry should not be returned by Get")
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	c := newTestCache(t)
+	t1 := tenant.WithContext(context.Background(), "t1")
+	t2 := tenant.WithContext(context.Background(), "t2")
+
+	if err := c.Set(t1, "k", "v1", time.Minute, "tag"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := c.Get(t2, "k", "tag"); ok {
+		t.Fatal("tenant t2 should not see t1 entry")
+	}
+
+	// 其他租户的标签失效不影响当前租户
+	if err := c.InvalidateTags(t2, "tag"); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, err := c.Get(t1, "k", "tag"); err != nil || !ok || v != "v1" {
+		t.Fatalf("t1 Get = %q, %v, %v", v, ok, err)
+	}
+
+	if err := c.InvalidateTags(t1, "tag"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := c.Get(t1, "k", "tag"); ok {
+		t.Fatal("t1 entry should be invalidated by its own tag")
+	}
+}
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// AuditLog 管理操作审计日志(只追加，不修改不删除)
+type AuditLog struct {
+	model.DistributedPrimaryKeyID
+	EventID    string `gorm:"type:varchar(64);uniqueIndex;not null;default:'';comment:来源事件ID" json:"event_id"`
+	ActorType  string `gorm:"type:varchar(20);index:audit_log_actor_idx,priority:1;not null;default:'';comment:操作人类型" json:"actor_type"`
+	ActorID    string `gorm:"type:varchar(64);index:audit_log_actor_idx,priority:2;not null;default:'';comment:操作人ID" json:"actor_id"`
+	Action     string `gorm:"type:varchar(50);index;not null;default:'';comment:操作" json:"action"`
+	TargetType string `gorm:"type:varchar(50);index:audit_log_target_idx,priority:1;not null;default:'';comment:操作对象类型" json:"target_type"`
+	TargetID   string `gorm:"type:varchar(64);index:audit_log_target_idx,priority:2;not null;default:'';comment:操作对象ID" json:"target_id"`
+	Before     string `gorm:"type:text;comment:变更前快照" json:"before"`
+	After      string `gorm:"type:text;comment:变更后快照" json:"after"`
+	Changes    string `gorm:"type:text;comment:变更字段" json:"changes"`
+	IP         string `gorm:"type:varchar(64);not null;default:'';comment:来源IP" json:"ip"`
+	UserAgent  string `gorm:"type:varchar(500);not null;default:'';comment:客户端标识" json:"user_agent"`
+	TraceID    string `gorm:"type:varchar(64);not null;default:'';comment:追踪ID" json:"trace_id"`
+	model.Tenant
+	OccurredAt time.Time `gorm:"type:timestamp(0) without time zone;index;not null;comment:操作时间" json:"occurred_at"`
+	CreatedAt  time.Time `gorm:"type:timestamp(0) without time zone;autoCreateTime;not null;comment:记录时间" json:"created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "ams_audit_logs"
+}
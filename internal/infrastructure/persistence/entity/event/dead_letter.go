@@ -0,0 +1,30 @@
+package event
+
+import (
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// 死信事件状态
+const (
+	DeadLetterStatusPending   uint8 = 1 // 待处理
+	DeadLetterStatusReplayed  uint8 = 2 // 已重放
+	DeadLetterStatusDiscarded uint8 = 3 // 已丢弃
+)
+
+// DeadLetter 事件处理死信
+type DeadLetter struct {
+	model.PrimaryKeyID
+	EventID   string `gorm:"type:varchar(64);index;not null;default:'';comment:事件ID" json:"event_id"`
+	EventType string `gorm:"type:varchar(100);not null;default:'';comment:事件类型" json:"event_type"`
+	Handler   string `gorm:"type:varchar(150);not null;default:'';comment:处理器" json:"handler"`
+	Payload   string `gorm:"type:text;not null;comment:事件数据" json:"payload"`
+	Error     string `gorm:"type:text;not null;comment:处理错误" json:"error"`
+	Attempts  int    `gorm:"not null;default:0;comment:已处理次数" json:"attempts"`
+	Status    uint8  `gorm:"index;not null;default:1;comment:状态 1-待处理 2-已重放 3-已丢弃" json:"status"`
+	model.Tenant
+	model.Time
+}
+
+func (DeadLetter) TableName() string {
+	return "event_dead_letter"
+}
@@ -0,0 +1,18 @@
+package event
+
+import (
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// Inbox 事件消费收件箱(幂等去重记录)
+type Inbox struct {
+	model.PrimaryKeyID
+	EventID   string `gorm:"type:varchar(64);index:event_inbox_idx,unique;not null;default:'';comment:事件ID" json:"event_id"`
+	Handler   string `gorm:"type:varchar(150);index:event_inbox_idx,unique;not null;default:'';comment:事件处理器" json:"handler"`
+	EventType string `gorm:"type:varchar(100);not null;default:'';comment:事件类型" json:"event_type"`
+	model.Time
+}
+
+func (Inbox) TableName() string {
+	return "event_inbox"
+}
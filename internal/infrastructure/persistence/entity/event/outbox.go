@@ -0,0 +1,32 @@
+package event
+
+import (
+	"time"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// 发件箱事件状态
+const (
+	OutboxStatusPending   uint8 = 1 // 待投递
+	OutboxStatusDelivered uint8 = 2 // 已投递
+	OutboxStatusFailed    uint8 = 3 // 投递失败(超过最大重试次数)
+)
+
+// Outbox 事务发件箱
+type Outbox struct {
+	model.PrimaryKeyID
+	EventType   string     `gorm:"type:varchar(100);not null;default:'';comment:事件类型" json:"event_type"`
+	Payload     string     `gorm:"type:text;not null;comment:事件数据" json:"payload"`
+	Headers     string     `gorm:"type:text;comment:消息头(JSON)，携带追踪上下文" json:"headers"`
+	Status      uint8      `gorm:"index:event_outbox_status_idx,priority:1;not null;default:1;comment:状态 1-待投递 2-已投递 3-投递失败" json:"status"`
+	Attempts    int        `gorm:"not null;default:0;comment:已投递次数" json:"attempts"`
+	LastError   string     `gorm:"type:varchar(500);not null;default:'';comment:最近一次投递错误" json:"last_error"`
+	NextRetryAt time.Time  `gorm:"type:timestamp(0) without time zone;index:event_outbox_status_idx,priority:2;not null;comment:下次投递时间" json:"next_retry_at"`
+	DeliveredAt *time.Time `gorm:"type:timestamp(0) without time zone;index;comment:投递成功时间" json:"delivered_at"`
+	model.Time
+}
+
+func (Outbox) TableName() string {
+	return "event_outbox"
+}
@@ -0,0 +1,24 @@
+package event
+
+import (
+	"time"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// StoredEvent 事件存储记录(只追加，不修改不删除)
+type StoredEvent struct {
+	model.PrimaryKeyID
+	EventID       string    `gorm:"type:varchar(64);uniqueIndex;not null;default:'';comment:事件ID" json:"event_id"`
+	EventType     string    `gorm:"type:varchar(100);index;not null;default:'';comment:事件类型" json:"event_type"`
+	EventVersion  int       `gorm:"not null;default:1;comment:事件结构版本" json:"event_version"`
+	AggregateID   string    `gorm:"type:varchar(64);index:event_store_aggregate_idx,priority:2;not null;default:'';comment:聚合根ID" json:"aggregate_id"`
+	AggregateName string    `gorm:"type:varchar(100);index:event_store_aggregate_idx,priority:1;not null;default:'';comment:聚合根名称" json:"aggregate_name"`
+	Payload       string    `gorm:"type:text;not null;comment:事件数据" json:"payload"`
+	OccurredAt    time.Time `gorm:"type:timestamp(0) without time zone;index;not null;comment:事件发生时间" json:"occurred_at"`
+	CreatedAt     time.Time `gorm:"type:timestamp(0) without time zone;autoCreateTime;not null;comment:记录时间" json:"created_at"`
+}
+
+func (StoredEvent) TableName() string {
+	return "event_store"
+}
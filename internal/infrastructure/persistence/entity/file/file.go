@@ -15,7 +15,10 @@ type File struct {
 	Ext       string `gorm:"type:varchar(10);not null;default:'';comment:文件扩展名" json:"ext"`
 	MimeType  string `gorm:"type:varchar(50);not null;default:'';comment:文件MIME类型" json:"mime_type"`
 	Status    uint8  `gorm:"not null;default:1;comment:文件状态 1-正常" json:"status"`
+	model.Tenant
 	model.Time
+	model.OptimisticLock
+	model.Operator
 }
 
 func (File) TableName() string {
@@ -32,6 +35,7 @@ type MultipartUpload struct {
 	MimeType string `gorm:"type:varchar(50);not null;default:'';comment:mime类型" json:"mime_type"`
 	Ext      string `gorm:"type:varchar(10);not null;default:'';comment:文件扩展名" json:"ext"`
 	Status   uint8  `gorm:"not null;default:1;comment:状态 1-进行中 2-已完成 3-已取消" json:"status"`
+	model.Tenant
 	model.Time
 }
 
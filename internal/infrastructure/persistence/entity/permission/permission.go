@@ -5,14 +5,18 @@ import "github.com/dysodeng/app/internal/infrastructure/shared/model"
 // Admin 管理员
 type Admin struct {
 	model.PrimaryKeyID
-	Username     string `gorm:"index:admin_username_idx,unique;type:varchar(50);not null;default:'';comment:用户名" json:"username"`
-	SafePassword string `gorm:"type:varchar(150);not null;default:;'';comment:登录密码" json:"safe_password"`
-	RealName     string `gorm:"type:varchar(50);not null;default:'';comment:姓名" json:"real_name"`
-	Telephone    string `gorm:"type:varchar(20);not null;default:'';comment:手机号" json:"telephone"`
-	Remark       string `gorm:"type:varchar(50);not null;default:'';comment:备注" json:"remark"`
-	IsSuper      uint8  `gorm:"not null;default:0;comment:是否超级管理员 0-否 1-是" json:"is_super"`
-	Status       uint8  `gorm:"not null;default:0;comment:状态 0-禁用 1-启用" json:"status"`
+	// TenantID 所属租户，用户名在租户内唯一
+	TenantID     string          `gorm:"type:varchar(64);index:admin_username_idx,unique,priority:1;not null;default:'';comment:租户ID" json:"tenant_id"`
+	Username     string          `gorm:"index:admin_username_idx,unique;type:varchar(50);not null;default:'';comment:用户名" json:"username"`
+	SafePassword string          `gorm:"type:varchar(150);not null;default:;'';comment:登录密码" json:"safe_password"`
+	RealName     string          `gorm:"type:varchar(50);not null;default:'';comment:姓名" json:"real_name"`
+	Telephone    model.Encrypted `gorm:"type:varchar(255);not null;default:'';comment:手机号(加密)" json:"telephone"`
+	Remark       string          `gorm:"type:varchar(50);not null;default:'';comment:备注" json:"remark"`
+	IsSuper      uint8           `gorm:"not null;default:0;comment:是否超级管理员 0-否 1-是" json:"is_super"`
+	Status       uint8           `gorm:"not null;default:0;comment:状态 0-禁用 1-启用" json:"status"`
 	model.Time
+	model.OptimisticLock
+	model.Operator
 }
 
 func (Admin) TableName() string {
@@ -2,16 +2,33 @@ package user
 
 import "github.com/dysodeng/app/internal/infrastructure/shared/model"
 
+// 盲索引字段标识
+const (
+	TelephoneIndex           = "users.telephone"
+	WxUnionIDIndex           = "users.wx_union_id"
+	WxMiniProgramOpenIDIndex = "users.wx_mini_program_openid"
+	WxOfficialOpenIDIndex    = "users.wx_official_openid"
+)
+
+// User 用户，手机号与微信OpenID加密存储，通过盲索引查询
 type User struct {
 	model.DistributedPrimaryKeyID
-	Telephone           string `gorm:"type:varchar(15);index:user_telephone_idx,unique;not null;default:'';comment:手机号" json:"telephone"`
-	WxUnionID           string `gorm:"type:varchar(36);index:user_wx_union_idx;not null;default:'';comment:微信开放平台用户UnionID" json:"wx_union_id"`
-	WxMiniProgramOpenID string `gorm:"column:wx_mini_program_openid;type:varchar(36);index:user_wx_mp_idx,unique;not null;default:'';comment:微信小程序用户OpenID" json:"wx_mini_program_openid"`
-	WxOfficialOpenID    string `gorm:"column:wx_official_openid;type:varchar(36);index:user_wx_official_idx;not null;default:'';comment:微信公众号用户OpenID" json:"wx_official_openid"`
-	Nickname            string `gorm:"type:varchar(50);not null;default:'';comment:用户昵称" json:"nickname"`
-	Avatar              string `gorm:"type:varchar(150);not null;default:'';comment:用户头像" json:"avatar"`
-	Status              uint8  `gorm:"not null;default:0;comment:状态 0-禁用 1-启用" json:"status"`
+	// TenantID 所属租户，手机号与小程序OpenID在租户内唯一
+	TenantID                string           `gorm:"type:varchar(64);index:user_telephone_idx,unique,priority:1;index:user_wx_mp_idx,unique,priority:1;not null;default:'';comment:租户ID" json:"tenant_id"`
+	Telephone               model.Encrypted  `gorm:"type:varchar(255);not null;default:'';comment:手机号" json:"telephone"`
+	TelephoneBidx           model.BlindIndex `gorm:"column:telephone_bidx;type:varchar(64);index:user_telephone_idx,unique;comment:手机号盲索引" json:"-"`
+	WxUnionID               model.Encrypted  `gorm:"type:varchar(255);not null;default:'';comment:微信开放平台用户UnionID" json:"wx_union_id"`
+	WxUnionIDBidx           model.BlindIndex `gorm:"column:wx_union_id_bidx;type:varchar(64);index:user_wx_union_idx;comment:微信开放平台用户UnionID盲索引" json:"-"`
+	WxMiniProgramOpenID     model.Encrypted  `gorm:"column:wx_mini_program_openid;type:varchar(255);not null;default:'';comment:微信小程序用户OpenID" json:"wx_mini_program_openid"`
+	WxMiniProgramOpenIDBidx model.BlindIndex `gorm:"column:wx_mini_program_openid_bidx;type:varchar(64);index:user_wx_mp_idx,unique;comment:微信小程序用户OpenID盲索引" json:"-"`
+	WxOfficialOpenID        model.Encrypted  `gorm:"column:wx_official_openid;type:varchar(255);not null;default:'';comment:微信公众号用户OpenID" json:"wx_official_openid"`
+	WxOfficialOpenIDBidx    model.BlindIndex `gorm:"column:wx_official_openid_bidx;type:varchar(64);index:user_wx_official_idx;comment:微信公众号用户OpenID盲索引" json:"-"`
+	Nickname                string           `gorm:"type:varchar(50);not null;default:'';comment:用户昵称" json:"nickname"`
+	Avatar                  string           `gorm:"type:varchar(150);not null;default:'';comment:用户头像" json:"avatar"`
+	Status                  uint8            `gorm:"not null;default:0;comment:状态 0-禁用 1-启用" json:"status"`
 	model.Time
+	model.OptimisticLock
+	model.Operator
 }
 
 func (User) TableName() string {
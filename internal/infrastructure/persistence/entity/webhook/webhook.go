@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// Subscription Webhook订阅
+type Subscription struct {
+	model.DistributedPrimaryKeyID
+	Name                string     `gorm:"type:varchar(100);not null;default:'';comment:订阅名称" json:"name"`
+	URL                 string     `gorm:"type:varchar(500);not null;default:'';comment:回调地址" json:"url"`
+	EventTypes          string     `gorm:"type:varchar(1000);not null;default:'';comment:订阅事件类型，逗号分隔" json:"event_types"`
+	Secret              string     `gorm:"type:varchar(100);not null;default:'';comment:签名密钥" json:"secret"`
+	Status              uint8      `gorm:"index;not null;default:1;comment:状态 0-停用 1-启用" json:"status"`
+	ConsecutiveFailures int        `gorm:"not null;default:0;comment:连续投递失败次数" json:"consecutive_failures"`
+	DisabledAt          *time.Time `gorm:"type:timestamp(0) without time zone;comment:最近一次停用时间" json:"disabled_at"`
+	model.Tenant
+	model.Time
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Delivery Webhook投递记录
+type Delivery struct {
+	model.DistributedPrimaryKeyID
+	SubscriptionID uuid.UUID  `gorm:"type:uuid;index:webhook_delivery_event_idx,unique,priority:1;not null;comment:订阅ID" json:"subscription_id"`
+	EventID        string     `gorm:"type:varchar(64);index:webhook_delivery_event_idx,unique,priority:2;not null;default:'';comment:事件ID" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(100);not null;default:'';comment:事件类型" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null;comment:请求内容" json:"payload"`
+	Status         uint8      `gorm:"index;not null;default:1;comment:状态 1-投递中 2-投递成功 3-投递失败" json:"status"`
+	Attempts       int        `gorm:"not null;default:0;comment:已尝试次数" json:"attempts"`
+	ResponseStatus int        `gorm:"not null;default:0;comment:最近一次响应状态码" json:"response_status"`
+	ResponseBody   string     `gorm:"type:text;comment:最近一次响应内容" json:"response_body"`
+	LastError      string     `gorm:"type:varchar(1000);not null;default:'';comment:最近一次投递错误" json:"last_error"`
+	NextRetryAt    *time.Time `gorm:"type:timestamp(0) without time zone;comment:下次重试时间" json:"next_retry_at"`
+	DeliveredAt    *time.Time `gorm:"type:timestamp(0) without time zone;comment:投递成功时间" json:"delivered_at"`
+	model.Tenant
+	model.Time
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/bytedance/sonic"
+	"gorm.io/gorm/clause"
+
+	"github.com/dysodeng/app/internal/domain/audit/model"
+	auditDomainRepository "github.com/dysodeng/app/internal/domain/audit/repository"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/audit"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/repository"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+type auditLogRepository struct {
+	baseTraceSpanName string
+	txManager         transactions.TransactionManager
+}
+
+func NewAuditLogRepository(txManager transactions.TransactionManager) auditDomainRepository.AuditLogRepository {
+	return &auditLogRepository{
+		baseTraceSpanName: "infrastructure.persistence.repository.audit.AuditLogRepository",
+		txManager:         txManager,
+	}
+}
+
+func (repo *auditLogRepository) Append(ctx context.Context, log *model.AuditLog) error {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".Append")
+	defer span.End()
+
+	changes, err := sonic.MarshalString(log.Changes)
+	if err != nil {
+		return err
+	}
+	dataModel := audit.AuditLog{
+		EventID:    log.EventID,
+		ActorType:  log.ActorType,
+		ActorID:    log.ActorID,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Before:     log.Before,
+		After:      log.After,
+		Changes:    changes,
+		IP:         log.IP,
+		UserAgent:  log.UserAgent,
+		TraceID:    log.TraceID,
+		OccurredAt: log.OccurredAt,
+	}
+	if err = repo.txManager.GetTx(spanCtx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).
+		Create(&dataModel).Error; err != nil {
+		return err
+	}
+	log.ID = dataModel.ID
+	return nil
+}
+
+func (repo *auditLogRepository) FindList(ctx context.Context, query auditDomainRepository.AuditLogQuery) ([]model.AuditLog, sharedVO.PageInfo, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".FindList")
+	defer span.End()
+
+	spec := repository.NewSpec("id").
+		Sortable("occurred_at", "occurred_at").
+		DefaultSort("occurred_at", true).
+		WhereIf(query.ActorType != "", "actor_type = ?", query.ActorType).
+		WhereIf(query.ActorID != "", "actor_id = ?", query.ActorID).
+		WhereIf(query.Action != "", "action = ?", query.Action).
+		WhereIf(query.TargetType != "", "target_type = ?", query.TargetType).
+		WhereIf(query.TargetID != "", "target_id = ?", query.TargetID).
+		WhereIf(query.StartTime != nil, "occurred_at >= ?", query.StartTime).
+		WhereIf(query.EndTime != nil, "occurred_at <= ?", query.EndTime)
+
+	list, page, err := repository.FindPage[audit.AuditLog](repo.txManager.GetTx(spanCtx), spec, query.PageRequest)
+	if err != nil {
+		return nil, page, err
+	}
+
+	result := make([]model.AuditLog, len(list))
+	for i := range list {
+		result[i] = repo.auditLogFromModel(&list[i])
+	}
+	return result, page, nil
+}
+
+func (repo *auditLogRepository) auditLogFromModel(m *audit.AuditLog) model.AuditLog {
+	var changes []model.FieldChange
+	_ = sonic.UnmarshalString(m.Changes, &changes)
+	return model.AuditLog{
+		ID:         m.ID,
+		EventID:    m.EventID,
+		ActorType:  m.ActorType,
+		ActorID:    m.ActorID,
+		Action:     m.Action,
+		TargetType: m.TargetType,
+		TargetID:   m.TargetID,
+		Before:     m.Before,
+		After:      m.After,
+		Changes:    changes,
+		IP:         m.IP,
+		UserAgent:  m.UserAgent,
+		TraceID:    m.TraceID,
+		OccurredAt: m.OccurredAt,
+	}
+}
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/dysodeng/app/internal/domain/permission/model"
+	permissionDomainRepo "github.com/dysodeng/app/internal/domain/permission/repository"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
+	persistCache "github.com/dysodeng/app/internal/infrastructure/persistence/cache"
+	permissionRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/permission"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	sharedModel "github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+// 管理员缓存DTO，值对象字段私有，需展开
+type adminCacheDTO struct {
+	ID           uint64 `json:"id"`
+	Username     string `json:"username"`
+	SafePassword string `json:"safe_password"`
+	RealName     string `json:"real_name"`
+	Telephone    string `json:"telephone"`
+	Remark       string `json:"remark"`
+	IsSuper      uint8  `json:"is_super"`
+	Status       uint8  `json:"status"`
+	Version      uint64 `json:"version"`
+}
+
+type cachedAdminRepository struct {
+	next     permissionDomainRepo.AdminRepository
+	cache    *persistCache.TypedCache[adminCacheDTO]
+	ids      *persistCache.TypedCache[uint64] // 用户名到管理员ID的映射
+	cacheTTL time.Duration
+}
+
+func NewCachedAdminRepository(txManager transactions.TransactionManager) (permissionDomainRepo.AdminRepository, error) {
+	cacheTTL := 10 * time.Minute
+	c, err := newTypedCache[adminCacheDTO]("admin", cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := newTypedCache[uint64]("admin_username", cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &cachedAdminRepository{
+		next:     permissionRepository.NewAdminRepository(txManager),
+		cache:    c,
+		ids:      ids,
+		cacheTTL: cacheTTL,
+	}, nil
+}
+
+func adminToDTO(a *model.Admin) adminCacheDTO {
+	return adminCacheDTO{
+		ID:           a.ID,
+		Username:     a.Username.Value(),
+		SafePassword: a.SafePassword.Value(),
+		RealName:     a.RealName,
+		Telephone:    a.Telephone.Value(),
+		Remark:       a.Remark,
+		IsSuper:      a.IsSuper.Uint(),
+		Status:       a.Status.Uint(),
+		Version:      a.Version,
+	}
+}
+
+func adminToDomain(dto *adminCacheDTO) *model.Admin {
+	username, _ := sharedVO.NewUsername(dto.Username)
+	password, _ := sharedVO.NewPasswordByHashText(dto.SafePassword)
+	telephone, _ := sharedVO.NewTelephone(dto.Telephone)
+	return &model.Admin{
+		ID:           dto.ID,
+		Username:     username,
+		SafePassword: password,
+		RealName:     dto.RealName,
+		Telephone:    telephone,
+		Remark:       dto.Remark,
+		IsSuper:      sharedModel.BinaryStatusByUint(dto.IsSuper),
+		Status:       sharedModel.BinaryStatusByUint(dto.Status),
+		Version:      dto.Version,
+	}
+}
+
+func adminTag(id uint64) string {
+	return "admin:" + strconv.FormatUint(id, 10)
+}
+
+func adminUsernameTag(username string) string {
+	return "username:" + username
+}
+
+// load 加载单个管理员，不存在时返回 persistCache.ErrNotFound 以写入空值缓存
+func (r *cachedAdminRepository) load(ctx context.Context, base string, find func(context.Context) (*model.Admin, error), tag string) (*model.Admin, error) {
+	dto, err := r.cache.GetOrLoad(ctx, base, r.cacheTTL, func(ctx context.Context) (adminCacheDTO, error) {
+		admin, err := find(ctx)
+		if err != nil {
+			return adminCacheDTO{}, err
+		}
+		if admin == nil || admin.ID == 0 {
+			return adminCacheDTO{}, persistCache.ErrNotFound
+		}
+		return adminToDTO(admin), nil
+	}, tag)
+	if errors.Is(err, persistCache.ErrNotFound) {
+		// 与底层仓储保持一致，不存在时返回空实体
+		return &model.Admin{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return adminToDomain(&dto), nil
+}
+
+func (r *cachedAdminRepository) FindById(ctx context.Context, id uint64) (*model.Admin, error) {
+	return r.load(ctx, "id:"+strconv.FormatUint(id, 10), func(ctx context.Context) (*model.Admin, error) {
+		return r.next.FindById(ctx, id)
+	}, adminTag(id))
+}
+
+// FindByUsername 用户名只缓存到管理员ID的映射，管理员数据复用按ID的缓存，按 adminTag(id) 失效时同样生效
+func (r *cachedAdminRepository) FindByUsername(ctx context.Context, username sharedVO.Username) (*model.Admin, error) {
+	tag := adminUsernameTag(username.Value())
+	id, err := r.ids.GetOrLoad(ctx, "username:"+username.Value(), r.cacheTTL, func(ctx context.Context) (uint64, error) {
+		admin, err := r.next.FindByUsername(ctx, username)
+		if err != nil {
+			return 0, err
+		}
+		if admin == nil || admin.ID == 0 {
+			return 0, persistCache.ErrNotFound
+		}
+		return admin.ID, nil
+	}, tag)
+	if errors.Is(err, persistCache.ErrNotFound) {
+		return &model.Admin{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := r.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if admin.ID == 0 || admin.Username.Value() != username.Value() {
+		// 映射已过期(管理员已删除或改名)，失效后回源
+		invalidateTags(ctx, r.ids, tag)
+		return r.next.FindByUsername(ctx, username)
+	}
+	return admin, nil
+}
+
+func (r *cachedAdminRepository) ExistsByUsername(ctx context.Context, username sharedVO.Username) (bool, error) {
+	return r.next.ExistsByUsername(ctx, username)
+}
+
+func (r *cachedAdminRepository) Save(ctx context.Context, admin *model.Admin) error {
+	if err := r.next.Save(ctx, admin); err != nil {
+		return err
+	}
+	r.invalidateByAdmin(ctx, admin)
+	return nil
+}
+
+func (r *cachedAdminRepository) ChangePassword(ctx context.Context, id uint64, password sharedVO.Password) error {
+	if err := r.next.ChangePassword(ctx, id, password); err != nil {
+		return err
+	}
+	// 按用户名查询复用按ID的缓存，失效 adminTag(id) 即可
+	invalidateTags(ctx, r.cache, adminTag(id))
+	return nil
+}
+
+func (r *cachedAdminRepository) invalidateByAdmin(ctx context.Context, admin *model.Admin) {
+	if admin == nil {
+		return
+	}
+	if admin.ID > 0 {
+		invalidateTags(ctx, r.cache, adminTag(admin.ID))
+	}
+	if v := admin.Username.Value(); v != "" {
+		invalidateTags(ctx, r.ids, adminUsernameTag(v))
+	}
+}
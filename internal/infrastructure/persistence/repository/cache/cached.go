@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	persistCache "github.com/dysodeng/app/internal/infrastructure/persistence/cache"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+)
+
+// newTypedCache 按配置的缓存驱动创建仓储缓存
+func newTypedCache[T any](namespace string, ttl time.Duration) (*persistCache.TypedCache[T], error) {
+	return persistCache.NewTypedCacheWith[T](config.GlobalConfig.Cache.Driver, namespace, ttl)
+}
+
+// invalidateTags 标签失效
+// 处于事务中时在提交后再失效一次，避免提交前并发读请求把旧数据回填进缓存
+func invalidateTags[T any](ctx context.Context, c *persistCache.TypedCache[T], tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+	invalidate := func(ctx context.Context) {
+		if err := c.InvalidateTags(ctx, tags...); err != nil {
+			logger.Error(ctx, "缓存失效失败", logger.AddField("tags", tags), logger.ErrorField(err))
+		}
+	}
+	invalidate(ctx)
+	transactions.AfterCommit(ctx, invalidate)
+}
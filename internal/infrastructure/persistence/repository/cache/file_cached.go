@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/domain/file/model"
+	fileDomainRepository "github.com/dysodeng/app/internal/domain/file/repository"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
+	persistCache "github.com/dysodeng/app/internal/infrastructure/persistence/cache"
+	fileRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/file"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+)
+
+type cachedFileRepository struct {
+	next     fileDomainRepository.FileRepository
+	cache    *persistCache.TypedCache[model.File]
+	cacheTTL time.Duration
+}
+
+func NewCachedFileRepository(txManager transactions.TransactionManager) (fileDomainRepository.FileRepository, error) {
+	cacheTTL := 10 * time.Minute
+	c, err := newTypedCache[model.File]("file", cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &cachedFileRepository{
+		next:     fileRepository.NewFileRepository(txManager),
+		cache:    c,
+		cacheTTL: cacheTTL,
+	}, nil
+}
+
+func fileTag(id uuid.UUID) string {
+	return "file:" + id.String()
+}
+
+func (r *cachedFileRepository) FindList(ctx context.Context, query fileDomainRepository.FileQuery) ([]model.File, sharedVO.PageInfo, error) {
+	return r.next.FindList(ctx, query)
+}
+
+func (r *cachedFileRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.File, error) {
+	f, err := r.cache.GetOrLoad(ctx, "id:"+id.String(), r.cacheTTL, func(ctx context.Context) (model.File, error) {
+		f, err := r.next.FindByID(ctx, id)
+		if err != nil {
+			return model.File{}, err
+		}
+		if f == nil || f.ID == uuid.Nil {
+			return model.File{}, persistCache.ErrNotFound
+		}
+		return *f, nil
+	}, fileTag(id))
+	if errors.Is(err, persistCache.ErrNotFound) {
+		// 与底层仓储保持一致，不存在时返回空实体
+		return &model.File{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *cachedFileRepository) FindListByIds(ctx context.Context, ids []uuid.UUID) ([]model.File, error) {
+	return r.next.FindListByIds(ctx, ids)
+}
+
+func (r *cachedFileRepository) Save(ctx context.Context, f *model.File) error {
+	if err := r.next.Save(ctx, f); err != nil {
+		return err
+	}
+	invalidateTags(ctx, r.cache, fileTag(f.ID))
+	return nil
+}
+
+func (r *cachedFileRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	invalidateTags(ctx, r.cache, fileTag(id))
+	return nil
+}
+
+func (r *cachedFileRepository) BatchDelete(ctx context.Context, ids []uuid.UUID) error {
+	if err := r.next.BatchDelete(ctx, ids); err != nil {
+		return err
+	}
+	tags := make([]string, 0, len(ids))
+	for _, id := range ids {
+		tags = append(tags, fileTag(id))
+	}
+	invalidateTags(ctx, r.cache, tags...)
+	return nil
+}
+
+func (r *cachedFileRepository) CheckFileNameExists(ctx context.Context, name string, excludeId uuid.UUID) (bool, error) {
+	return r.next.CheckFileNameExists(ctx, name, excludeId)
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/dysodeng/app/internal/domain/user/model"
 	userDomainRepo "github.com/dysodeng/app/internal/domain/user/repository"
 	"github.com/dysodeng/app/internal/domain/user/valueobject"
-	"github.com/dysodeng/app/internal/infrastructure/config"
 	persistCache "github.com/dysodeng/app/internal/infrastructure/persistence/cache"
 	userRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository/user"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
@@ -28,6 +27,7 @@ type userCacheDTO struct {
 	Nickname            string    `json:"nickname"`
 	Avatar              string    `json:"avatar"`
 	Status              uint8     `json:"status"`
+	Version             uint64    `json:"version"`
 	CreatedAt           time.Time `json:"created_at"`
 }
 
@@ -37,15 +37,17 @@ type cachedUserRepository struct {
 	cacheTTL time.Duration
 }
 
-func NewCachedUserRepository(txManager transactions.TransactionManager) userDomainRepo.UserRepository {
-	driver := config.GlobalConfig.Cache.Driver
+func NewCachedUserRepository(txManager transactions.TransactionManager) (userDomainRepo.UserRepository, error) {
 	cacheTTL := 10 * time.Minute
-	tc := persistCache.NewTypedCacheWith[userCacheDTO](driver, "user", cacheTTL)
+	c, err := newTypedCache[userCacheDTO]("user", cacheTTL)
+	if err != nil {
+		return nil, err
+	}
 	return &cachedUserRepository{
 		next:     userRepository.NewUserRepository(txManager),
-		cache:    tc,
+		cache:    c,
 		cacheTTL: cacheTTL,
-	}
+	}, nil
 }
 
 func toDTO(u *model.User) *userCacheDTO {
@@ -58,6 +60,7 @@ func toDTO(u *model.User) *userCacheDTO {
 		Nickname:            u.Nickname,
 		Avatar:              u.Avatar.FullURL(),
 		Status:              u.Status.Uint(),
+		Version:             u.Version,
 		CreatedAt:           u.CreatedAt,
 	}
 }
@@ -77,6 +80,7 @@ func toDomain(dto *userCacheDTO) *model.User {
 		Nickname:            dto.Nickname,
 		Avatar:              avatar,
 		Status:              sharedModel.BinaryStatusByUint(dto.Status),
+		Version:             dto.Version,
 		CreatedAt:           dto.CreatedAt,
 	}
 }
@@ -105,7 +109,7 @@ func (r *cachedUserRepository) invalidateByUser(ctx context.Context, u *model.Us
 	if u == nil {
 		return
 	}
-	_ = r.cache.InvalidateTags(ctx, r.tagsFor(u)...)
+	invalidateTags(ctx, r.cache, r.tagsFor(u)...)
 }
 
 func (r *cachedUserRepository) FindById(ctx context.Context, id uuid.UUID) (*model.User, error) {
@@ -2,7 +2,6 @@ package file
 
 import (
 	"context"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -11,6 +10,7 @@ import (
 	"github.com/dysodeng/app/internal/domain/file/model"
 	fileDomainRepository "github.com/dysodeng/app/internal/domain/file/repository"
 	"github.com/dysodeng/app/internal/domain/file/valueobject"
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/file"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/repository"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
@@ -29,57 +29,27 @@ func NewFileRepository(txManager transactions.TransactionManager) fileDomainRepo
 	}
 }
 
-func (repo *fileRepository) FindList(ctx context.Context, query fileDomainRepository.FileQuery) ([]model.File, int64, error) {
+func (repo *fileRepository) FindList(ctx context.Context, query fileDomainRepository.FileQuery) ([]model.File, sharedVO.PageInfo, error) {
 	tx := repo.txManager.GetTx(ctx)
 
-	// 构建查询条件
-	db := tx.Debug().Model(&file.File{})
-
-	if query.MediaType > 0 {
-		db = db.Where("media_type=?", query.MediaType)
-	}
-
-	if query.Keyword != "" {
-		db = repository.WhereLike(db, "name", query.Keyword)
-	}
-
-	if query.StartTime != nil {
-		db = db.Where("created_at >= ?", query.StartTime)
-	}
-
-	if query.EndTime != nil {
-		db = db.Where("created_at <= ?", query.EndTime)
-	}
-
-	// 获取总数
-	var total int64
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// 排序
-	if query.OrderBy != "" {
-		orderType := "asc"
-		if strings.ToLower(query.OrderType) == "desc" {
-			orderType = "desc"
-		}
-		db = db.Order(query.OrderBy + " " + orderType)
-	}
-
-	// 分页
-	if query.Page > 0 && query.PageSize > 0 {
-		offset := (query.Page - 1) * query.PageSize
-		db = db.Offset(offset).Limit(query.PageSize)
-	}
-
-	// 查询数据
-	var files []file.File
-	if err := db.Find(&files).Error; err != nil {
-		return nil, 0, err
+	// 构建查询规格
+	spec := repository.NewSpec("id").
+		Sortable("created_at", "created_at").
+		Sortable("name", "name").
+		Sortable("size", "size").
+		DefaultSort("created_at", true).
+		WhereIf(query.MediaType > 0, "media_type = ?", query.MediaType).
+		Like("name", query.Keyword).
+		WhereIf(query.StartTime != nil, "created_at >= ?", query.StartTime).
+		WhereIf(query.EndTime != nil, "created_at <= ?", query.EndTime)
+
+	files, page, err := repository.FindPage[file.File](tx, spec, query.PageRequest)
+	if err != nil {
+		return nil, page, err
 	}
 
 	// 转换为领域模型
-	return repo.fileListFromModel(ctx, files), total, nil
+	return repo.fileListFromModel(ctx, files), page, nil
 }
 
 func (repo *fileRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.File, error) {
@@ -128,9 +98,13 @@ func (repo *fileRepository) Save(ctx context.Context, f *model.File) error {
 		f.ID = dataModel.ID
 		f.CreatedAt = dataModel.CreatedAt.Time
 	} else {
-		// 更新文件信息
-		return tx.Debug().Model(&file.File{}).Where("id = ?", f.ID).Updates(&dataModel).Error
+		// 更新文件信息，版本号不匹配时返回冲突
+		dataModel.Version = f.Version + 1
+		if err := repository.UpdateVersioned(tx.Debug().Model(&file.File{}).Where("id = ?", f.ID), f.Version, &dataModel); err != nil {
+			return err
+		}
 	}
+	f.Version = dataModel.Version
 
 	return nil
 }
@@ -174,6 +148,7 @@ func (repo *fileRepository) fileFromModel(ctx context.Context, m file.File) *mod
 		Ext:       m.Ext,
 		MimeType:  m.MimeType,
 		Status:    m.Status,
+		Version:   m.Version,
 		CreatedAt: m.CreatedAt.Time,
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/dysodeng/app/internal/domain/permission/repository"
 	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/permission"
+	persistRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
 	sharedModel "github.com/dysodeng/app/internal/infrastructure/shared/model"
 	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
@@ -75,6 +76,44 @@ func (repo *adminRepository) ExistsByUsername(ctx context.Context, username shar
 }
 
 func (repo *adminRepository) Save(ctx context.Context, admin *model.Admin) error {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".Save")
+	defer span.End()
+
+	tx := repo.txManager.GetTx(spanCtx)
+
+	if admin.ID == 0 {
+		dataModel := permission.Admin{
+			Username:     admin.Username.Value(),
+			SafePassword: admin.SafePassword.Value(),
+			RealName:     admin.RealName,
+			Telephone:    sharedModel.Encrypted(admin.Telephone.Value()),
+			Remark:       admin.Remark,
+			IsSuper:      admin.IsSuper.Uint(),
+			Status:       admin.Status.Uint(),
+		}
+		if err := tx.Create(&dataModel).Error; err != nil {
+			return err
+		}
+		admin.ID = dataModel.ID
+		admin.Version = dataModel.Version
+		return nil
+	}
+
+	// 状态字段可能为零值，使用 map 更新；版本号不匹配时返回冲突
+	if err := persistRepository.UpdateVersioned(tx.Model(&permission.Admin{}).Where("id = ?", admin.ID), admin.Version, map[string]interface{}{
+		"username":      admin.Username.Value(),
+		"safe_password": admin.SafePassword.Value(),
+		"real_name":     admin.RealName,
+		"telephone":     sharedModel.Encrypted(admin.Telephone.Value()),
+		"remark":        admin.Remark,
+		"is_super":      admin.IsSuper.Uint(),
+		"status":        admin.Status.Uint(),
+		"version":       admin.Version + 1,
+	}); err != nil {
+		return err
+	}
+	admin.Version++
+
 	return nil
 }
 
@@ -82,13 +121,16 @@ func (repo *adminRepository) ChangePassword(ctx context.Context, id uint64, pass
 	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".ChangePassword")
 	defer span.End()
 	tx := repo.txManager.GetTx(spanCtx).Model(&permission.Admin{}).Debug()
-	return tx.Where("id=?", id).Update("safe_password", password.Value()).Error
+	return tx.Where("id=?", id).Updates(map[string]interface{}{
+		"safe_password": password.Value(),
+		"version":       gorm.Expr("version + 1"),
+	}).Error
 }
 
 func (repo *adminRepository) adminFromModel(admin *permission.Admin) *model.Admin {
 	username, _ := sharedVO.NewUsername(admin.Username)
 	password, _ := sharedVO.NewPasswordByHashText(admin.SafePassword)
-	telephone, _ := sharedVO.NewTelephone(admin.Telephone)
+	telephone, _ := sharedVO.NewTelephone(admin.Telephone.String())
 	return &model.Admin{
 		ID:           admin.ID,
 		Username:     username,
@@ -98,5 +140,6 @@ func (repo *adminRepository) adminFromModel(admin *permission.Admin) *model.Admi
 		Remark:       admin.Remark,
 		IsSuper:      sharedModel.BinaryStatusByUint(admin.IsSuper),
 		Status:       sharedModel.BinaryStatusByUint(admin.Status),
+		Version:      admin.Version,
 	}
 }
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
+)
+
+var (
+	// ErrInvalidSortField 排序字段不在白名单内
+	ErrInvalidSortField = sharedVO.ErrInvalidSortField
+	// ErrInvalidCursor 游标无法解析或与当前排序不匹配
+	ErrInvalidCursor = sharedVO.ErrInvalidCursor
+)
+
+// Spec 查询规格，组合过滤条件、排序白名单与分页
+// 排序字段只能取白名单内的值，并始终以唯一键兜底，保证游标分页结果稳定
+type Spec struct {
+	scopes      []func(*gorm.DB) *gorm.DB
+	sortable    map[string]string // 对外排序字段 -> 列名
+	defaultSort string
+	defaultDesc bool
+	keyColumn   string // 唯一键列
+}
+
+// NewSpec 创建查询规格，keyColumn 为唯一键列(通常为主键)，默认按其降序
+func NewSpec(keyColumn string) *Spec {
+	return &Spec{
+		sortable:    map[string]string{},
+		defaultSort: keyColumn,
+		defaultDesc: true,
+		keyColumn:   keyColumn,
+	}
+}
+
+// Where 追加过滤条件
+func (s *Spec) Where(query any, args ...any) *Spec {
+	s.scopes = append(s.scopes, func(db *gorm.DB) *gorm.DB {
+		return db.Where(query, args...)
+	})
+	return s
+}
+
+// WhereIf 条件成立时追加过滤条件
+func (s *Spec) WhereIf(ok bool, query any, args ...any) *Spec {
+	if ok {
+		s.Where(query, args...)
+	}
+	return s
+}
+
+// Like 关键词非空时追加模糊匹配
+func (s *Spec) Like(column, keyword string) *Spec {
+	if keyword != "" {
+		s.scopes = append(s.scopes, func(db *gorm.DB) *gorm.DB {
+			return WhereLike(db, column, keyword)
+		})
+	}
+	return s
+}
+
+// Sortable 登记允许排序的字段
+func (s *Spec) Sortable(field, column string) *Spec {
+	s.sortable[field] = column
+	return s
+}
+
+// DefaultSort 未指定排序字段时的默认排序
+func (s *Spec) DefaultSort(field string, desc bool) *Spec {
+	s.defaultSort = field
+	s.defaultDesc = desc
+	return s
+}
+
+// Scope 以 gorm scope 形式应用过滤条件
+func (s *Spec) Scope(db *gorm.DB) *gorm.DB {
+	for _, scope := range s.scopes {
+		db = scope(db)
+	}
+	return db
+}
+
+// sort 解析排序列与方向
+func (s *Spec) sort(orderBy, orderType string) (string, bool, error) {
+	if orderBy == "" {
+		orderBy, orderType = s.defaultSort, "asc"
+		if s.defaultDesc {
+			orderType = "desc"
+		}
+	}
+	column, ok := s.sortable[orderBy]
+	if !ok {
+		if orderBy != s.keyColumn {
+			return "", false, fmt.Errorf("%w: %s", ErrInvalidSortField, orderBy)
+		}
+		column = s.keyColumn
+	}
+	return column, strings.ToLower(orderType) == "desc", nil
+}
+
+// orderColumns 排序列，非唯一列时追加唯一键兜底
+func (s *Spec) orderColumns(column string) []string {
+	if column == s.keyColumn {
+		return []string{column}
+	}
+	return []string{column, s.keyColumn}
+}
+
+// FindPage 按规格分页查询
+// 请求携带游标时使用 keyset 分页，否则按页码偏移；两种方式均返回下一页游标
+func FindPage[T any](db *gorm.DB, spec *Spec, req sharedVO.PageRequest) ([]T, sharedVO.PageInfo, error) {
+	info := sharedVO.PageInfo{Total: -1}
+
+	column, desc, err := spec.sort(req.OrderBy, req.OrderType)
+	if err != nil {
+		return nil, info, err
+	}
+	columns := spec.orderColumns(column)
+	signature := sortSignature(columns, desc)
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = sharedVO.DefaultPageSize
+	}
+	pageSize = min(pageSize, sharedVO.MaxPageSize)
+
+	base := spec.Scope(db.Model(new(T))).Session(&gorm.Session{})
+
+	if req.WithTotal {
+		if err = base.Count(&info.Total).Error; err != nil {
+			return nil, info, err
+		}
+	}
+
+	query := base
+	if req.Cursor != "" {
+		values, err := decodeCursor(req.Cursor, signature)
+		if err != nil {
+			return nil, info, err
+		}
+		query = query.Where(keysetExpr(columns, values, desc))
+	} else if req.Page > 1 {
+		query = query.Offset((req.Page - 1) * pageSize)
+	}
+	for _, col := range columns {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: col}, Desc: desc})
+	}
+
+	var rows []T
+	result := query.Limit(pageSize + 1).Find(&rows)
+	if result.Error != nil {
+		return nil, info, result.Error
+	}
+
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		info.HasMore = true
+		if info.NextCursor, err = encodeCursor(result.Statement, &rows[pageSize-1], columns, signature); err != nil {
+			return nil, info, err
+		}
+	}
+
+	return rows, info, nil
+}
+
+// keysetExpr 构造 keyset 条件，如降序：(c < ? OR (c = ? AND id < ?))
+func keysetExpr(columns []string, values []any, desc bool) clause.Expr {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	if len(columns) == 1 {
+		return clause.Expr{SQL: "? " + op + " ?", Vars: []any{clause.Column{Name: columns[0]}, values[0]}}
+	}
+	col, key := clause.Column{Name: columns[0]}, clause.Column{Name: columns[1]}
+	return clause.Expr{
+		SQL:  "(? " + op + " ? OR (? = ? AND ? " + op + " ?))",
+		Vars: []any{col, values[0], col, values[0], key, values[1]},
+	}
+}
+
+func sortSignature(columns []string, desc bool) string {
+	dir := "asc"
+	if desc {
+		dir = "desc"
+	}
+	return strings.Join(columns, ",") + ":" + dir
+}
+
+// cursor 游标内容，值带类型标记以便还原为与列匹配的参数类型
+type cursor struct {
+	Sort   string        `json:"s"`
+	Values []cursorValue `json:"v"`
+}
+
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v"`
+}
+
+func encodeCursor(stmt *gorm.Statement, row any, columns []string, signature string) (string, error) {
+	if stmt.Schema == nil {
+		return "", errors.New("page cursor: schema not parsed")
+	}
+	rv := reflect.ValueOf(row).Elem()
+	c := cursor{Sort: signature, Values: make([]cursorValue, 0, len(columns))}
+	for _, col := range columns {
+		field := stmt.Schema.LookUpField(col)
+		if field == nil {
+			return "", fmt.Errorf("page cursor: unknown column %s", col)
+		}
+		v, _ := field.ValueOf(stmt.Context, rv)
+		cv, err := toCursorValue(v)
+		if err != nil {
+			return "", err
+		}
+		c.Values = append(c.Values, cv)
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(token, signature string) ([]any, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err = json.Unmarshal(b, &c); err != nil || c.Sort != signature || len(c.Values) != strings.Count(signature, ",")+1 {
+		return nil, ErrInvalidCursor
+	}
+	values := make([]any, len(c.Values))
+	for i, cv := range c.Values {
+		if values[i], err = fromCursorValue(cv); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return values, nil
+}
+
+func toCursorValue(v any) (cursorValue, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		dv, err := valuer.Value()
+		if err != nil {
+			return cursorValue{}, err
+		}
+		v = dv
+	}
+	switch t := v.(type) {
+	case time.Time:
+		return cursorValue{Type: "t", Value: t.Format(time.RFC3339Nano)}, nil
+	case string:
+		return cursorValue{Type: "s", Value: t}, nil
+	case []byte:
+		return cursorValue{Type: "s", Value: string(t)}, nil
+	case bool:
+		return cursorValue{Type: "b", Value: strconv.FormatBool(t)}, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cursorValue{Type: "i", Value: strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cursorValue{Type: "u", Value: strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return cursorValue{Type: "f", Value: strconv.FormatFloat(rv.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return cursorValue{Type: "s", Value: rv.String()}, nil
+	}
+	return cursorValue{}, fmt.Errorf("page cursor: unsupported value type %T", v)
+}
+
+func fromCursorValue(cv cursorValue) (any, error) {
+	switch cv.Type {
+	case "t":
+		return time.Parse(time.RFC3339Nano, cv.Value)
+	case "s":
+		return cv.Value, nil
+	case "b":
+		return strconv.ParseBool(cv.Value)
+	case "i":
+		return strconv.ParseInt(cv.Value, 10, 64)
+	case "u":
+		return strconv.ParseUint(cv.Value, 10, 64)
+	case "f":
+		return strconv.ParseFloat(cv.Value, 64)
+	}
+	return nil, fmt.Errorf("unknown cursor value type %q", cv.Type)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	sharedVO "github.com/dysodeng/app/internal/domain/shared/valueobject"
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+)
+
+type testRow struct {
+	model.DistributedPrimaryKeyID
+	Name string
+	model.Time
+}
+
+func newDryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sqls []string
+	_ = db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		sqls = append(sqls, tx.Statement.SQL.String())
+	})
+	return db, &sqls
+}
+
+func TestFindPageRejectsUnknownSortField(t *testing.T) {
+	db, _ := newDryRunDB(t)
+	spec := NewSpec("id").Sortable("name", "name")
+	_, _, err := FindPage[testRow](db, spec, sharedVO.PageRequest{OrderBy: "name; DROP TABLE users"})
+	if !errors.Is(err, ErrInvalidSortField) {
+		t.Fatalf("err = %v, want ErrInvalidSortField", err)
+	}
+}
+
+func TestFindPageKeyset(t *testing.T) {
+	db, sqls := newDryRunDB(t)
+	spec := NewSpec("id").Sortable("created_at", "created_at").Like("name", "go")
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&testRow{}); err != nil {
+		t.Fatal(err)
+	}
+	row := testRow{
+		DistributedPrimaryKeyID: model.DistributedPrimaryKeyID{ID: uuid.New()},
+		Time:                    model.Time{CreatedAt: model.JSONTime{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}},
+	}
+	signature := sortSignature([]string{"created_at", "id"}, true)
+	token, err := encodeCursor(stmt, &row, []string{"created_at", "id"}, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := decodeCursor(token, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts, ok := values[0].(time.Time); !ok || !ts.Equal(row.CreatedAt.Time) || values[1] != row.ID.String() {
+		t.Fatalf("decoded cursor = %v", values)
+	}
+	if _, err = decodeCursor(token, sortSignature([]string{"created_at", "id"}, false)); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("cursor with mismatched sort should be rejected, err = %v", err)
+	}
+
+	req := sharedVO.PageRequest{Cursor: token, OrderBy: "created_at", OrderType: "desc", PageSize: 10, WithTotal: true}
+	if _, _, err = FindPage[testRow](db, spec, req); err != nil {
+		t.Fatal(err)
+	}
+	if len(*sqls) != 2 {
+		t.Fatalf("expected count + select, got %v", *sqls)
+	}
+	got := (*sqls)[1]
+	for _, want := range []string{
+		`("created_at" < $2 OR ("created_at" = $3 AND "id" < $4))`,
+		`ORDER BY "created_at" DESC,"id" DESC LIMIT $5`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("sql %q missing %q", got, want)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/dysodeng/app/internal/domain/user/repository"
 	"github.com/dysodeng/app/internal/domain/user/valueobject"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/user"
+	persistRepository "github.com/dysodeng/app/internal/infrastructure/persistence/repository"
 	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
 	sharedModel "github.com/dysodeng/app/internal/infrastructure/shared/model"
 	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
@@ -52,7 +53,7 @@ func (repo *userRepository) FindByTelephone(ctx context.Context, telephone strin
 	tx := repo.txManager.GetTx(spanCtx).Debug()
 
 	var info user.User
-	if err := tx.Where("telephone = ?", telephone).First(&info).Error; err != nil {
+	if err := tx.Where("telephone_bidx = ?", sharedModel.NewBlindIndex(user.TelephoneIndex, telephone)).First(&info).Error; err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -68,7 +69,7 @@ func (repo *userRepository) FindByUnionId(ctx context.Context, unionId string) (
 	tx := repo.txManager.GetTx(spanCtx).Debug()
 
 	var info user.User
-	if err := tx.Where("union_id = ?", unionId).First(&info).Error; err != nil {
+	if err := tx.Where("wx_union_id_bidx = ?", sharedModel.NewBlindIndex(user.WxUnionIDIndex, unionId)).First(&info).Error; err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -84,9 +85,9 @@ func (repo *userRepository) FindByOpenId(ctx context.Context, platform, openId s
 	tx := repo.txManager.GetTx(spanCtx).Debug()
 
 	if platform == "WxMinioProgram" {
-		tx = tx.Where("wx_mini_program_openid = ?", openId)
+		tx = tx.Where("wx_mini_program_openid_bidx = ?", sharedModel.NewBlindIndex(user.WxMiniProgramOpenIDIndex, openId))
 	} else {
-		tx = tx.Where("wx_official_openid = ?", openId)
+		tx = tx.Where("wx_official_openid_bidx = ?", sharedModel.NewBlindIndex(user.WxOfficialOpenIDIndex, openId))
 	}
 
 	var info user.User
@@ -117,8 +118,9 @@ func (repo *userRepository) Save(ctx context.Context, userInfo *model.User) erro
 			userInfo.ID = userModel.ID
 			userInfo.CreatedAt = userModel.CreatedAt.Time
 		} else {
-			if err := tx.Where("id=?", userInfo.ID).
-				Updates(userModel).Error; err != nil {
+			// 版本号不匹配时返回冲突
+			userModel.Version = userInfo.Version + 1
+			if err := persistRepository.UpdateVersioned(tx.Where("id=?", userInfo.ID), userInfo.Version, userModel); err != nil {
 				return err
 			}
 		}
@@ -129,15 +131,16 @@ func (repo *userRepository) Save(ctx context.Context, userInfo *model.User) erro
 		userInfo.ID = userModel.ID
 		userInfo.CreatedAt = userModel.CreatedAt.Time
 	}
+	userInfo.Version = userModel.Version
 
 	return nil
 }
 
 func (repo *userRepository) userFromModel(u *user.User) *model.User {
-	telephone, _ := sharedVO.NewTelephone(u.Telephone)
-	wxUnionId, _ := valueobject.NewWxUnionID(u.WxUnionID)
-	wxMiniProgramOpenId, _ := valueobject.NewWxMiniProgramOpenID(u.WxMiniProgramOpenID)
-	wxOfficialOpenId, _ := valueobject.NewWxOfficialOpenID(u.WxOfficialOpenID)
+	telephone, _ := sharedVO.NewTelephone(u.Telephone.String())
+	wxUnionId, _ := valueobject.NewWxUnionID(u.WxUnionID.String())
+	wxMiniProgramOpenId, _ := valueobject.NewWxMiniProgramOpenID(u.WxMiniProgramOpenID.String())
+	wxOfficialOpenId, _ := valueobject.NewWxOfficialOpenID(u.WxOfficialOpenID.String())
 	avatar, _ := valueobject.NewAvatar(u.Avatar)
 	return &model.User{
 		ID:                  u.ID,
@@ -147,6 +150,7 @@ func (repo *userRepository) userFromModel(u *user.User) *model.User {
 		WxOfficialOpenID:    wxOfficialOpenId,
 		Nickname:            u.Nickname,
 		Avatar:              avatar,
+		Version:             u.Version,
 		CreatedAt:           u.CreatedAt.Time,
 	}
 }
@@ -162,10 +166,14 @@ func (repo *userRepository) userListFromModel(userList []user.User) []model.User
 func (repo *userRepository) toModel(u *model.User) *user.User {
 	return &user.User{
 		DistributedPrimaryKeyID: sharedModel.DistributedPrimaryKeyID{ID: u.ID},
-		Telephone:               u.Telephone.String(),
-		WxUnionID:               u.WxUnionID.String(),
-		WxMiniProgramOpenID:     u.WxMiniProgramOpenID.String(),
-		WxOfficialOpenID:        u.WxOfficialOpenID.String(),
+		Telephone:               sharedModel.Encrypted(u.Telephone.String()),
+		TelephoneBidx:           sharedModel.NewBlindIndex(user.TelephoneIndex, u.Telephone.String()),
+		WxUnionID:               sharedModel.Encrypted(u.WxUnionID.String()),
+		WxUnionIDBidx:           sharedModel.NewBlindIndex(user.WxUnionIDIndex, u.WxUnionID.String()),
+		WxMiniProgramOpenID:     sharedModel.Encrypted(u.WxMiniProgramOpenID.String()),
+		WxMiniProgramOpenIDBidx: sharedModel.NewBlindIndex(user.WxMiniProgramOpenIDIndex, u.WxMiniProgramOpenID.String()),
+		WxOfficialOpenID:        sharedModel.Encrypted(u.WxOfficialOpenID.String()),
+		WxOfficialOpenIDBidx:    sharedModel.NewBlindIndex(user.WxOfficialOpenIDIndex, u.WxOfficialOpenID.String()),
 		Nickname:                u.Nickname,
 		Avatar:                  u.Avatar.RelativePath(),
 		Status:                  u.Status.Uint(),
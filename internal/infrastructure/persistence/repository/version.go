@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	sharedErrors "github.com/dysodeng/app/internal/domain/shared/errors"
+)
+
+// UpdateVersioned 乐观锁更新
+// db 须已指定 Model 与主键条件，version 为读取时的版本号，values 中的版本号应为 version+1；
+// 版本号不匹配(记录已被并发修改)时返回 sharedErrors.ErrCommonConflict
+func UpdateVersioned(db *gorm.DB, version uint64, values any) error {
+	result := db.Where("version = ?", version).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.ErrCommonConflict
+	}
+	return nil
+}
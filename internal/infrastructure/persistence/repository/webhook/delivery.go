@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dysodeng/app/internal/domain/webhook/model"
+	webhookDomainRepository "github.com/dysodeng/app/internal/domain/webhook/repository"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/webhook"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+type deliveryRepository struct {
+	baseTraceSpanName string
+	txManager         transactions.TransactionManager
+}
+
+func NewDeliveryRepository(txManager transactions.TransactionManager) webhookDomainRepository.DeliveryRepository {
+	return &deliveryRepository{
+		baseTraceSpanName: "infrastructure.persistence.repository.webhook.DeliveryRepository",
+		txManager:         txManager,
+	}
+}
+
+func (repo *deliveryRepository) FindList(ctx context.Context, query webhookDomainRepository.DeliveryQuery) ([]model.Delivery, int64, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".FindList")
+	defer span.End()
+
+	db := repo.txManager.GetTx(spanCtx).Model(&webhook.Delivery{}).Where("subscription_id = ?", query.SubscriptionID)
+	if query.EventType != "" {
+		db = db.Where("event_type = ?", query.EventType)
+	}
+	if query.Status > 0 {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if query.Page > 0 && query.PageSize > 0 {
+		db = db.Offset((query.Page - 1) * query.PageSize).Limit(query.PageSize)
+	}
+
+	var list []webhook.Delivery
+	if err := db.Order("created_at DESC").Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]model.Delivery, len(list))
+	for i := range list {
+		result[i] = *repo.deliveryFromModel(&list[i])
+	}
+	return result, total, nil
+}
+
+func (repo *deliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Delivery, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".FindByID")
+	defer span.End()
+
+	var info webhook.Delivery
+	if err := repo.txManager.GetTx(spanCtx).Where("id = ?", id).First(&info).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return repo.deliveryFromModel(&info), nil
+}
+
+func (repo *deliveryRepository) Create(ctx context.Context, delivery *model.Delivery) (bool, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".Create")
+	defer span.End()
+
+	dataModel := repo.toModel(delivery)
+	result := repo.txManager.GetTx(spanCtx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(dataModel)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	delivery.ID = dataModel.ID
+	delivery.CreatedAt = dataModel.CreatedAt.Time
+	delivery.UpdatedAt = dataModel.UpdatedAt.Time
+	return true, nil
+}
+
+func (repo *deliveryRepository) Save(ctx context.Context, delivery *model.Delivery) error {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".Save")
+	defer span.End()
+
+	return repo.txManager.GetTx(spanCtx).Model(&webhook.Delivery{}).Where("id = ?", delivery.ID).Updates(map[string]any{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"response_status": delivery.ResponseStatus,
+		"response_body":   delivery.ResponseBody,
+		"last_error":      delivery.LastError,
+		"next_retry_at":   delivery.NextRetryAt,
+		"delivered_at":    delivery.DeliveredAt,
+	}).Error
+}
+
+func (repo *deliveryRepository) toModel(d *model.Delivery) *webhook.Delivery {
+	return &webhook.Delivery{
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		LastError:      d.LastError,
+		NextRetryAt:    d.NextRetryAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func (repo *deliveryRepository) deliveryFromModel(m *webhook.Delivery) *model.Delivery {
+	return &model.Delivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Payload:        m.Payload,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		ResponseStatus: m.ResponseStatus,
+		ResponseBody:   m.ResponseBody,
+		LastError:      m.LastError,
+		NextRetryAt:    m.NextRetryAt,
+		DeliveredAt:    m.DeliveredAt,
+		CreatedAt:      m.CreatedAt.Time,
+		UpdatedAt:      m.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/domain/webhook/model"
+	webhookDomainRepository "github.com/dysodeng/app/internal/domain/webhook/repository"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/entity/webhook"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/repository"
+	"github.com/dysodeng/app/internal/infrastructure/persistence/transactions"
+	sharedModel "github.com/dysodeng/app/internal/infrastructure/shared/model"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+)
+
+// eventTypesSeparator 订阅事件类型存储分隔符
+const eventTypesSeparator = ","
+
+type subscriptionRepository struct {
+	baseTraceSpanName string
+	txManager         transactions.TransactionManager
+}
+
+func NewSubscriptionRepository(txManager transactions.TransactionManager) webhookDomainRepository.SubscriptionRepository {
+	return &subscriptionRepository{
+		baseTraceSpanName: "infrastructure.persistence.repository.webhook.SubscriptionRepository",
+		txManager:         txManager,
+	}
+}
+
+func (repo *subscriptionRepository) FindList(ctx context.Context, query webhookDomainRepository.SubscriptionQuery) ([]model.Subscription, int64, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".FindList")
+	defer span.End()
+
+	db := repo.txManager.GetTx(spanCtx).Model(&webhook.Subscription{})
+	if query.Keyword != "" {
+		db = repository.WhereLike(db, "name", query.Keyword)
+	}
+	if query.EventType != "" {
+		db = repository.WhereLike(db, "event_types", query.EventType)
+	}
+	if query.Status != nil {
+		db = db.Where("status = ?", *query.Status)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if query.Page > 0 && query.PageSize > 0 {
+		db = db.Offset((query.Page - 1) * query.PageSize).Limit(query.PageSize)
+	}
+
+	var list []webhook.Subscription
+	if err := db.Order("created_at DESC").Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return repo.subscriptionListFromModel(list), total, nil
+}
+
+func (repo *subscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Subscription, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".FindByID")
+	defer span.End()
+
+	var info webhook.Subscription
+	if err := repo.txManager.GetTx(spanCtx).Where("id = ?", id).First(&info).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return repo.subscriptionFromModel(&info), nil
+}
+
+func (repo *subscriptionRepository) FindEnabledByEventType(ctx context.Context, eventType string) ([]model.Subscription, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".FindEnabledByEventType")
+	defer span.End()
+
+	db := repo.txManager.GetTx(spanCtx).Where("status = ?", sharedModel.BinaryStatusTrue)
+	db = repository.WhereLike(db, "event_types", eventType)
+
+	var list []webhook.Subscription
+	if err := db.Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	// 模糊匹配后按完整事件类型过滤
+	result := make([]model.Subscription, 0, len(list))
+	for _, m := range repo.subscriptionListFromModel(list) {
+		if m.Subscribes(eventType) {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+func (repo *subscriptionRepository) Save(ctx context.Context, subscription *model.Subscription) error {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".Save")
+	defer span.End()
+
+	if subscription == nil {
+		return errors.New("subscription cannot be nil")
+	}
+
+	tx := repo.txManager.GetTx(spanCtx)
+	if subscription.ID == uuid.Nil {
+		dataModel := repo.toModel(subscription)
+		if err := tx.Create(dataModel).Error; err != nil {
+			return err
+		}
+		subscription.ID = dataModel.ID
+		subscription.CreatedAt = dataModel.CreatedAt.Time
+		subscription.UpdatedAt = dataModel.UpdatedAt.Time
+		return nil
+	}
+
+	// 使用map更新，避免状态等零值字段被忽略
+	return tx.Model(&webhook.Subscription{}).Where("id = ?", subscription.ID).Updates(map[string]any{
+		"name":                 subscription.Name,
+		"url":                  subscription.URL,
+		"event_types":          strings.Join(subscription.EventTypes, eventTypesSeparator),
+		"secret":               subscription.Secret,
+		"status":               subscription.Status.Uint(),
+		"consecutive_failures": subscription.ConsecutiveFailures,
+		"disabled_at":          subscription.DisabledAt,
+	}).Error
+}
+
+func (repo *subscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".Delete")
+	defer span.End()
+
+	return repo.txManager.Transaction(spanCtx, func(txCtx context.Context) error {
+		tx := repo.txManager.GetTx(txCtx)
+		if err := tx.Where("subscription_id = ?", id).Delete(&webhook.Delivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&webhook.Subscription{}).Error
+	})
+}
+
+func (repo *subscriptionRepository) RecordDeliverySuccess(ctx context.Context, id uuid.UUID) error {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".RecordDeliverySuccess")
+	defer span.End()
+
+	return repo.txManager.GetTx(spanCtx).Model(&webhook.Subscription{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		Update("consecutive_failures", 0).Error
+}
+
+func (repo *subscriptionRepository) RecordDeliveryFailure(ctx context.Context, id uuid.UUID, disableThreshold int) (bool, error) {
+	spanCtx, span := trace.Tracer().Start(ctx, repo.baseTraceSpanName+".RecordDeliveryFailure")
+	defer span.End()
+
+	tx := repo.txManager.GetTx(spanCtx)
+	if err := tx.Model(&webhook.Subscription{}).
+		Where("id = ?", id).
+		Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error; err != nil {
+		return false, err
+	}
+	if disableThreshold <= 0 {
+		return false, nil
+	}
+
+	// 条件更新，并发失败时仅有一次停用生效
+	result := tx.Model(&webhook.Subscription{}).
+		Where("id = ? AND status = ? AND consecutive_failures >= ?", id, sharedModel.BinaryStatusTrue, disableThreshold).
+		Updates(map[string]any{
+			"status":      sharedModel.BinaryStatusFalse.Uint(),
+			"disabled_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (repo *subscriptionRepository) toModel(s *model.Subscription) *webhook.Subscription {
+	return &webhook.Subscription{
+		Name:                s.Name,
+		URL:                 s.URL,
+		EventTypes:          strings.Join(s.EventTypes, eventTypesSeparator),
+		Secret:              s.Secret,
+		Status:              s.Status.Uint(),
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+	}
+}
+
+func (repo *subscriptionRepository) subscriptionFromModel(m *webhook.Subscription) *model.Subscription {
+	var eventTypes []string
+	if m.EventTypes != "" {
+		eventTypes = strings.Split(m.EventTypes, eventTypesSeparator)
+	}
+	return &model.Subscription{
+		ID:                  m.ID,
+		Name:                m.Name,
+		URL:                 m.URL,
+		EventTypes:          eventTypes,
+		Secret:              m.Secret,
+		Status:              sharedModel.BinaryStatusByUint(m.Status),
+		ConsecutiveFailures: m.ConsecutiveFailures,
+		DisabledAt:          m.DisabledAt,
+		CreatedAt:           m.CreatedAt.Time,
+		UpdatedAt:           m.UpdatedAt.Time,
+	}
+}
+
+func (repo *subscriptionRepository) subscriptionListFromModel(list []webhook.Subscription) []model.Subscription {
+	result := make([]model.Subscription, len(list))
+	for i := range list {
+		result[i] = *repo.subscriptionFromModel(&list[i])
+	}
+	return result
+}
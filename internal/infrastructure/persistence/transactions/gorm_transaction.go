@@ -2,8 +2,16 @@ package transactions
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
 )
 
 // gormTransactionManager GORM事务管理器
@@ -19,28 +27,82 @@ func NewGormTransactionManager(tx *gorm.DB) TransactionManager {
 }
 
 // Transaction 开启事务上下文
-func (tm *gormTransactionManager) Transaction(ctx context.Context, fn func(txCtx context.Context) error) error {
-	// 检查是否已经在事务中
+func (tm *gormTransactionManager) Transaction(ctx context.Context, fn func(txCtx context.Context) error, opts ...TransactionOption) error {
+	// 已在事务中，通过保存点开启嵌套事务
 	if existingTx, ok := ctx.Value(TxKey{}).(*gorm.DB); ok && existingTx != nil {
-		return fn(ctx)
+		return tm.nested(ctx, existingTx, fn)
+	}
+
+	options := defaultTransactionOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := tm.transaction(ctx, options, fn)
+		if err == nil || attempt >= options.MaxRetries || !IsRetryable(err) {
+			return err
+		}
+
+		// 序列化失败或死锁，退避后重试整个事务
+		backoff := options.RetryBackoff << attempt
+		backoff += rand.N(backoff)
+		logger.Warn(
+			ctx,
+			"事务冲突，准备重试",
+			logger.AddField("attempt", attempt+1),
+			logger.AddField("backoff", backoff.String()),
+			logger.ErrorField(err),
+		)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
 	}
+}
 
-	// 开启新事务
+// transaction 执行一次最外层事务
+func (tm *gormTransactionManager) transaction(ctx context.Context, options *TransactionOptions, fn func(txCtx context.Context) error) error {
+	var txOpts []*sql.TxOptions
+	if options.Isolation != sql.LevelDefault || options.ReadOnly {
+		txOpts = append(txOpts, &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly})
+	}
+
+	hooks := &txHooks{}
 	err := tm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 创建新的事务上下文
-		txCtx := context.WithValue(ctx, TxKey{}, tx)
+		txCtx := context.WithValue(context.WithValue(ctx, TxKey{}, tx), hooksKey{}, hooks)
 
 		// 执行事务函数
-		if err := fn(txCtx); err != nil {
-			return err
-		}
+		return fn(txCtx)
+	}, txOpts...)
+	if err != nil {
+		// 执行事务回滚回调
+		hooks.runRollback(ctx)
+		return err
+	}
 
-		return nil
+	// 执行事务提交回调
+	hooks.runCommit(ctx)
+
+	return nil
+}
+
+// nested 嵌套事务，GORM 在已有事务上自动创建保存点，失败时回滚到保存点
+func (tm *gormTransactionManager) nested(ctx context.Context, tx *gorm.DB, fn func(txCtx context.Context) error) error {
+	hooks := &txHooks{}
+	err := tx.Transaction(func(nestedTx *gorm.DB) error {
+		return fn(context.WithValue(context.WithValue(ctx, TxKey{}, nestedTx), hooksKey{}, hooks))
 	})
 	if err != nil {
+		hooks.runRollback(ctx)
 		return err
 	}
 
+	if parent, ok := ctx.Value(hooksKey{}).(*txHooks); ok && parent != nil {
+		parent.merge(hooks)
+	}
 	return nil
 }
 
@@ -51,3 +113,17 @@ func (tm *gormTransactionManager) GetTx(ctx context.Context) *gorm.DB {
 	}
 	return tm.db.WithContext(ctx)
 }
+
+// IsRetryable 是否为可重试的事务冲突错误
+// Postgres: 40001 serialization_failure、40P01 deadlock_detected；MySQL: 1213 死锁
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1213
+	}
+	return false
+}
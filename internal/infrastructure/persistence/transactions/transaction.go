@@ -2,6 +2,9 @@ package transactions
 
 import (
 	"context"
+	"database/sql"
+	"sync"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -12,7 +15,149 @@ type TxKey struct{}
 // TransactionManager 事务管理器
 type TransactionManager interface {
 	// Transaction 开启事务上下文
-	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// 已在事务中时通过保存点开启嵌套事务，嵌套事务失败仅回滚到保存点，由调用方决定是否中止外层事务
+	Transaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TransactionOption) error
 	// GetTx 从上下文中获取事务
 	GetTx(ctx context.Context) *gorm.DB
 }
+
+// TransactionOptions 事务选项，隔离级别、只读与重试仅对最外层事务生效
+type TransactionOptions struct {
+	Isolation    sql.IsolationLevel // 隔离级别
+	ReadOnly     bool               // 只读事务
+	MaxRetries   int                // 序列化失败/死锁时整体重试次数，默认不重试
+	RetryBackoff time.Duration      // 重试退避基准时长，按次数指数增长
+}
+
+// TransactionOption 事务选项
+type TransactionOption func(options *TransactionOptions)
+
+func defaultTransactionOptions() *TransactionOptions {
+	return &TransactionOptions{
+		Isolation:    sql.LevelDefault,
+		RetryBackoff: 20 * time.Millisecond,
+	}
+}
+
+// WithIsolation 设置隔离级别
+func WithIsolation(level sql.IsolationLevel) TransactionOption {
+	return func(options *TransactionOptions) {
+		options.Isolation = level
+	}
+}
+
+// WithReadOnly 只读事务
+func WithReadOnly() TransactionOption {
+	return func(options *TransactionOptions) {
+		options.ReadOnly = true
+	}
+}
+
+// WithRetry 设置序列化失败/死锁时的重试次数与退避时长，maxRetries<=0 不重试
+// 重试会重新执行整个事务函数，仅在函数内无事务外副作用(发送HTTP请求、发布消息等)时开启
+func WithRetry(maxRetries int, backoff time.Duration) TransactionOption {
+	return func(options *TransactionOptions) {
+		options.MaxRetries = maxRetries
+		if backoff > 0 {
+			options.RetryBackoff = backoff
+		}
+	}
+}
+
+// hooksKey 事务回调上下文的key
+type hooksKey struct{}
+
+// txHooks 事务提交/回滚回调
+type txHooks struct {
+	mu       sync.Mutex
+	commit   []func(ctx context.Context)
+	rollback []func(ctx context.Context)
+}
+
+func (h *txHooks) addCommit(fn func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.commit = append(h.commit, fn)
+}
+
+func (h *txHooks) addRollback(fn func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rollback = append(h.rollback, fn)
+}
+
+// merge 嵌套事务成功后，其回调随外层事务提交或回滚执行
+func (h *txHooks) merge(child *txHooks) {
+	child.mu.Lock()
+	commit, rollback := child.commit, child.rollback
+	child.commit, child.rollback = nil, nil
+	child.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.commit = append(h.commit, commit...)
+	h.rollback = append(h.rollback, rollback...)
+}
+
+func (h *txHooks) runCommit(ctx context.Context) {
+	h.mu.Lock()
+	hooks := h.commit
+	h.commit, h.rollback = nil, nil
+	h.mu.Unlock()
+	for _, fn := range hooks {
+		fn(ctx)
+	}
+}
+
+func (h *txHooks) runRollback(ctx context.Context) {
+	h.mu.Lock()
+	hooks := h.rollback
+	h.commit, h.rollback = nil, nil
+	h.mu.Unlock()
+	for _, fn := range hooks {
+		fn(ctx)
+	}
+}
+
+// detachedCtx 屏蔽事务及事务回调的上下文
+type detachedCtx struct {
+	context.Context
+}
+
+func (c detachedCtx) Value(key any) any {
+	switch key.(type) {
+	case TxKey, hooksKey:
+		return nil
+	}
+	return c.Context.Value(key)
+}
+
+// WithoutTx 返回不在事务中的上下文，保留追踪、租户等其余上下文值
+// 用于脱离请求生命周期的后台任务，避免在已提交或回滚的事务上执行查询
+func WithoutTx(ctx context.Context) context.Context {
+	return detachedCtx{Context: ctx}
+}
+
+// AfterCommit 注册最外层事务提交后执行的回调，事务回滚时不执行
+// 在嵌套事务中注册时，嵌套事务回滚到保存点后回调随之丢弃
+// 上下文不在事务中时不注册并返回false
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) bool {
+	hooks, ok := ctx.Value(hooksKey{}).(*txHooks)
+	if !ok || hooks == nil {
+		return false
+	}
+	hooks.addCommit(fn)
+	return true
+}
+
+// AfterRollback 注册事务回滚后执行的回调，在嵌套事务中注册时回滚到保存点后即执行
+// 事务因序列化失败重试时，每次回滚都会执行已注册的回调
+// 上下文不在事务中时不注册并返回false
+func AfterRollback(ctx context.Context, fn func(ctx context.Context)) bool {
+	hooks, ok := ctx.Value(hooksKey{}).(*txHooks)
+	if !ok || hooks == nil {
+		return false
+	}
+	hooks.addRollback(fn)
+	return true
+}
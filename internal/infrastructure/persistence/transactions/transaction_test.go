@@ -0,0 +1,153 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+)
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&pgconn.PgError{Code: "40001"}, true},
+		{fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "40P01"}), true},
+		{&pgconn.PgError{Code: "23505"}, false},
+		{&mysql.MySQLError{Number: 1213}, true},
+		{&mysql.MySQLError{Number: 1062}, false},
+		{errors.New("other"), false},
+	}
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestHooksMerge(t *testing.T) {
+	var calls []string
+	parent, child := &txHooks{}, &txHooks{}
+	ctx := context.WithValue(context.Background(), hooksKey{}, parent)
+	childCtx := context.WithValue(ctx, hooksKey{}, child)
+
+	AfterCommit(ctx, func(context.Context) { calls = append(calls, "parent") })
+	AfterCommit(childCtx, func(context.Context) { calls = append(calls, "child") })
+	AfterRollback(childCtx, func(context.Context) { calls = append(calls, "child-rollback") })
+
+	parent.merge(child)
+	parent.runCommit(context.Background())
+	parent.runRollback(context.Background())
+
+	if fmt.Sprint(calls) != "[parent child]" {
+		t.Fatalf("calls = %v", calls)
+	}
+	if AfterCommit(context.Background(), func(context.Context) {}) {
+		t.Fatal("AfterCommit outside transaction should return false")
+	}
+}
+
+type txItem struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string
+}
+
+func TestNestedSavepoint(t *testing.T) {
+	conn := dbtest.Open(t, &txItem{})
+	tm := NewGormTransactionManager(conn)
+	ctx := context.Background()
+
+	var calls []string
+	err := tm.Transaction(ctx, func(txCtx context.Context) error {
+		if err := tm.GetTx(txCtx).Create(&txItem{ID: 1, Name: "outer"}).Error; err != nil {
+			return err
+		}
+
+		// 嵌套事务失败仅回滚到保存点，回滚回调立即执行，提交回调丢弃
+		err := tm.Transaction(txCtx, func(nestedCtx context.Context) error {
+			AfterCommit(nestedCtx, func(context.Context) { calls = append(calls, "failed-commit") })
+			AfterRollback(nestedCtx, func(context.Context) { calls = append(calls, "failed-rollback") })
+			if err := tm.GetTx(nestedCtx).Create(&txItem{ID: 2, Name: "failed"}).Error; err != nil {
+				return err
+			}
+			return errors.New("nested failed")
+		})
+		if err == nil {
+			t.Fatal("expected nested error")
+		}
+
+		// 嵌套事务成功后，提交回调随外层事务提交执行
+		return tm.Transaction(txCtx, func(nestedCtx context.Context) error {
+			AfterCommit(nestedCtx, func(context.Context) { calls = append(calls, "nested-commit") })
+			return tm.GetTx(nestedCtx).Create(&txItem{ID: 3, Name: "nested"}).Error
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []uint64
+	conn.Model(&txItem{}).Order("id").Pluck("id", &ids)
+	if fmt.Sprint(ids) != "[1 3]" {
+		t.Fatalf("ids = %v", ids)
+	}
+	if fmt.Sprint(calls) != "[failed-rollback nested-commit]" {
+		t.Fatalf("calls = %v", calls)
+	}
+}
+
+func TestTransactionRetry(t *testing.T) {
+	conn := dbtest.Open(t, &txItem{})
+	tm := NewGormTransactionManager(conn)
+	ctx := context.Background()
+	conflict := &pgconn.PgError{Code: "40001"}
+
+	// 默认不重试
+	attempts := 0
+	err := tm.Transaction(ctx, func(context.Context) error {
+		attempts++
+		return conflict
+	})
+	if !errors.Is(err, conflict) || attempts != 1 {
+		t.Fatalf("default: err = %v, attempts = %d", err, attempts)
+	}
+
+	// 开启重试后冲突错误重试整个事务，每次回滚执行回滚回调
+	attempts, rollbacks := 0, 0
+	err = tm.Transaction(ctx, func(txCtx context.Context) error {
+		attempts++
+		AfterRollback(txCtx, func(context.Context) { rollbacks++ })
+		if err := tm.GetTx(txCtx).Create(&txItem{ID: uint64(attempts)}).Error; err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return conflict
+		}
+		return nil
+	}, WithRetry(2, time.Millisecond))
+	if err != nil || attempts != 3 || rollbacks != 2 {
+		t.Fatalf("retry: err = %v, attempts = %d, rollbacks = %d", err, attempts, rollbacks)
+	}
+	var ids []uint64
+	conn.Model(&txItem{}).Pluck("id", &ids)
+	if fmt.Sprint(ids) != "[3]" {
+		t.Fatalf("ids = %v", ids)
+	}
+
+	// 非冲突错误不重试
+	attempts = 0
+	err = tm.Transaction(ctx, func(context.Context) error {
+		attempts++
+		return errors.New("other")
+	}, WithRetry(2, time.Millisecond))
+	if err == nil || attempts != 1 {
+		t.Fatalf("non-retryable: err = %v, attempts = %d", err, attempts)
+	}
+}
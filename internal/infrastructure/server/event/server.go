@@ -12,17 +12,20 @@ import (
 type Server struct {
 	cfg           *config.Config
 	eventConsumer *event.ConsumerService
+	bus           event.Bus
 	registry      *diEvent.HandlerRegistry
 }
 
 func NewEventServer(
 	cfg *config.Config,
 	eventConsumer *event.ConsumerService,
+	bus event.Bus,
 	registry *diEvent.HandlerRegistry,
 ) *Server {
 	return &Server{
 		cfg:           cfg,
 		eventConsumer: eventConsumer,
+		bus:           bus,
 		registry:      registry,
 	}
 }
@@ -40,6 +43,16 @@ func (s *Server) Name() string {
 }
 
 func (s *Server) Start() error {
+	// 进程内同步总线，处理器直接订阅到总线
+	if syncBus, ok := s.bus.(*event.SyncEventBus); ok {
+		for _, handler := range s.registry.Handlers() {
+			if err := syncBus.SubscribeHandler(handler); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// 事件注册
 	for _, handler := range s.registry.Handlers() {
 		if err := s.eventConsumer.SubscribeHandler(handler); err != nil {
@@ -50,6 +63,9 @@ func (s *Server) Start() error {
 	return s.eventConsumer.Start(ctx)
 }
 
-func (s *Server) Stop(_ context.Context) error {
-	return s.eventConsumer.Stop()
+func (s *Server) Stop(ctx context.Context) error {
+	if _, ok := s.bus.(*event.SyncEventBus); ok {
+		return nil
+	}
+	return s.eventConsumer.Stop(ctx)
 }
@@ -66,6 +66,12 @@ func (s *Server) Start() error {
 	engine.Use(middleware.CORS())
 	engine.Use(middleware.StartTrace())
 	engine.Use(middleware.Metrics())
+	if len(s.config.Database.Replicas) > 0 {
+		engine.Use(middleware.ReadYourWrites())
+	}
+	if s.config.App.Tenant.Enabled {
+		engine.Use(middleware.Tenant(s.config.App.Tenant))
+	}
 
 	// 静态资源管理
 	if s.config.Storage.Driver == "local" && s.config.Storage.Local.StaticEnabled {
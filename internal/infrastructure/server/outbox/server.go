@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/event"
+)
+
+// Server 事务发件箱投递服务，同时投递数据库定时事件
+type Server struct {
+	cfg   *config.Config
+	relay *event.OutboxRelay
+}
+
+func NewOutboxServer(cfg *config.Config, relay *event.OutboxRelay) *Server {
+	return &Server{
+		cfg:   cfg,
+		relay: relay,
+	}
+}
+
+func (s *Server) IsEnabled() bool {
+	return s.cfg.Server.Event.RelayEnabled()
+}
+
+func (s *Server) Addr() string {
+	return ""
+}
+
+func (s *Server) Name() string {
+	return "Outbox"
+}
+
+func (s *Server) Start() error {
+	return s.relay.Start(context.Background())
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	return s.relay.Stop(ctx)
+}
@@ -20,3 +20,25 @@ func TestAES(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGCM(t *testing.T) {
+	var text = "13800138000"
+	var key = []byte("0123456789abcdef0123456789abcdef")
+	b, err := GCMEncrypt([]byte(text), key, []byte("aad"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != len(text)+GCMOverhead() {
+		t.Fatalf("unexpected ciphertext length %d", len(b))
+	}
+	res, err := GCMDecrypt(b, key, []byte("aad"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res) != text {
+		t.Fatalf("got %q", res)
+	}
+	if _, err = GCMDecrypt(b, key, []byte("other")); err == nil {
+		t.Fatal("expected authentication failure with different additional data")
+	}
+}
@@ -0,0 +1,48 @@
+package aes
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+)
+
+// GCMEncrypt AES-GCM 加密，使用随机nonce，返回 nonce + 密文
+// additionalData 为附加认证数据，解密时需一致
+func GCMEncrypt(plantText, key, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plantText)+aead.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plantText, additionalData), nil
+}
+
+// GCMDecrypt AES-GCM 解密，ciphertext 为 GCMEncrypt 的输出
+func GCMDecrypt(ciphertext, key, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("aes: ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, additionalData)
+}
+
+// GCMOverhead GCMEncrypt 输出相对明文增加的字节数
+func GCMOverhead() int {
+	return 12 + 16
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
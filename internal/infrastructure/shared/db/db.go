@@ -21,6 +21,9 @@ func Driver() string {
 }
 
 func Close() error {
+	if dbResolver != nil {
+		dbResolver.close()
+	}
 	sqlDB, _ := db.DB()
 	if err := sqlDB.Close(); err != nil {
 		log.Printf("failed to close database connection: %+v", err)
@@ -0,0 +1,59 @@
+// Package dbtest 数据库测试辅助工具
+package dbtest
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// Open 创建测试用 SQLite 数据库并迁移给定实体，测试结束后自动关闭
+// 命名策略与主库一致，每个测试使用独立的数据库文件
+func Open(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	conn, err := gorm.Open(dialector{sqlite.Open(dsn).(*sqlite.Dialector)}, &gorm.Config{
+		SkipDefaultTransaction: true,
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+		Logger: logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open test database failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	if len(models) > 0 {
+		if err = conn.AutoMigrate(models...); err != nil {
+			t.Fatalf("migrate test database failed: %v", err)
+		}
+	}
+	return conn
+}
+
+// dialector 兼容实体中的 Postgres 专用列类型
+type dialector struct {
+	*sqlite.Dialector
+}
+
+func (d dialector) DataTypeOf(field *schema.Field) string {
+	if strings.HasSuffix(field.TagSettings["TYPE"], "without time zone") {
+		return "datetime"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+func (d dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	m := d.Dialector.Migrator(db).(sqlite.Migrator)
+	m.Dialector = d
+	return m
+}
@@ -138,7 +138,7 @@ func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (stri
 
 func (l *GormLogger) trace(ctx context.Context) []zap.Field {
 	span := trace.SpanFromContext(ctx)
-	var fields []zap.Field
+	fields := []zap.Field{zap.String("db_node", NodeFromContext(ctx))}
 	if span.SpanContext().HasTraceID() {
 		fields = append(fields, zap.Any("trace_id", span.SpanContext().TraceID().String()))
 	}
@@ -3,7 +3,6 @@ package db
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
@@ -11,45 +10,78 @@ import (
 	"gorm.io/gorm/schema"
 
 	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/shared/pii"
 )
 
 var db *gorm.DB
 var dbDriver string
+var dbResolver *resolver
 
 func initMainDB(cfg *config.Config) *gorm.DB {
 	var err error
-	var dsn string
-	var driver string
 
-	var maxIdleConn, maxOpenConn, connMaxLifetime int
+	dbDriver = cfg.Database.Driver
 
+	// 敏感字段加解密依赖密钥环，需在读写数据前初始化
+	if err = pii.Init(cfg.Security.Encryption); err != nil {
+		log.Fatalf("failed to init data encryption %+v", err)
+	}
+
+	db, err = openDB(cfg, dialector(cfg, cfg.Database.Host, cfg.Database.Port, cfg.Database.Username, cfg.Database.Password))
+	if err != nil {
+		log.Fatalf("failed to connect main database %+v", err)
+	}
+
+	log.Println("main database connection successful")
+
+	if err = registerOperatorCallbacks(db); err != nil {
+		log.Fatalf("failed to register database callbacks %+v", err)
+	}
+	if err = registerTenantCallbacks(db, cfg.App.Tenant.Enabled); err != nil {
+		log.Fatalf("failed to register database callbacks %+v", err)
+	}
+
+	// 读写分离
+	if len(cfg.Database.Replicas) > 0 {
+		dbResolver, err = newResolver(cfg, db)
+		if err != nil {
+			log.Fatalf("failed to connect replica database %+v", err)
+		}
+		log.Printf("replica database connection successful, %d replicas", len(cfg.Database.Replicas))
+	}
+
+	return db
+}
+
+func dialector(cfg *config.Config, host string, port int, username, password string) gorm.Dialector {
 	var dbConnector gorm.Dialector
 	switch cfg.Database.Driver {
 	case "mysql":
-		dsn = fmt.Sprintf(
+		dsn := fmt.Sprintf(
 			"%s:%s@tcp(%s:%d)/%s",
-			cfg.Database.Username,
-			cfg.Database.Password,
-			cfg.Database.Host,
-			cfg.Database.Port,
+			username,
+			password,
+			host,
+			port,
 			cfg.Database.Database,
 		) + "?charset=utf8mb4&parseTime=True&loc=Asia%2FShanghai"
 		dbConnector = mysql.Open(dsn)
 	case "postgres":
-		dsn = fmt.Sprintf(
+		dsn := fmt.Sprintf(
 			"host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Shanghai",
-			cfg.Database.Host,
-			cfg.Database.Username,
-			cfg.Database.Password,
+			host,
+			username,
+			password,
 			cfg.Database.Database,
-			cfg.Database.Port,
+			port,
 		)
 		dbConnector = postgres.Open(dsn)
 	}
+	return dbConnector
+}
 
-	dbDriver = driver
-
-	db, err = gorm.Open(dbConnector, &gorm.Config{
+func openDB(cfg *config.Config, dbConnector gorm.Dialector) (*gorm.DB, error) {
+	conn, err := gorm.Open(dbConnector, &gorm.Config{
 		SkipDefaultTransaction:                   true, // 禁用默认事务
 		PrepareStmt:                              true, // 预编译sql
 		DisableForeignKeyConstraintWhenMigrating: true, // 禁用创建外键约束
@@ -59,17 +91,18 @@ func initMainDB(cfg *config.Config) *gorm.DB {
 		Logger: NewGormLogger(), // db日志
 	})
 	if err != nil {
-		log.Fatalf("failed to connect main database %+v", err)
+		return nil, err
 	}
 
-	sqlDB, _ := db.DB()
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return nil, err
+	}
 
 	// 连接池
-	sqlDB.SetMaxIdleConns(maxIdleConn)                                     // 连接池最大允许的空闲连接数，如果没有sql任务需要执行的连接数大于该值，超过的连接会被连接池关闭。
-	sqlDB.SetMaxOpenConns(maxOpenConn)                                     // 连接池最大连接数
-	sqlDB.SetConnMaxLifetime(time.Second * time.Duration(connMaxLifetime)) // 连接空闲超时
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)       // 连接池最大允许的空闲连接数，如果没有sql任务需要执行的连接数大于该值，超过的连接会被连接池关闭。
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)       // 连接池最大连接数
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime) // 连接空闲超时
 
-	log.Println("main database connection successful")
-
-	return db
+	return conn, nil
 }
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/principal"
+)
+
+const (
+	createdByColumn = "created_by"
+	updatedByColumn = "updated_by"
+)
+
+// registerOperatorCallbacks 注册创建人/修改人回调
+// 实体包含 created_by/updated_by 字段且上下文携带请求主体时自动填充
+func registerOperatorCallbacks(conn *gorm.DB) error {
+	err := conn.Callback().Create().Before("gorm:create").
+		Register("app:operator_create", fillOperator(createdByColumn, updatedByColumn))
+	if err != nil {
+		return err
+	}
+	return conn.Callback().Update().Before("gorm:update").
+		Register("app:operator_update", fillOperator(updatedByColumn))
+}
+
+func fillOperator(columns ...string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Schema == nil {
+			return
+		}
+		p, ok := principal.FromContext(db.Statement.Context)
+		if !ok {
+			return
+		}
+		for _, column := range columns {
+			if db.Statement.Schema.LookUpField(column) != nil {
+				db.Statement.SetColumn(column, p.String(), true)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+	"github.com/dysodeng/app/internal/infrastructure/shared/principal"
+)
+
+type operatorRow struct {
+	model.PrimaryKeyID
+	Name string
+	model.OptimisticLock
+	model.Operator
+}
+
+func TestOperatorCallbacks(t *testing.T) {
+	conn, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = registerOperatorCallbacks(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := principal.WithContext(context.Background(), principal.Principal{Type: "ams", ID: "1"})
+
+	row := operatorRow{Name: "a"}
+	conn.WithContext(ctx).Create(&row)
+	if row.CreatedBy != "ams:1" || row.UpdatedBy != "ams:1" {
+		t.Fatalf("create operator = %q/%q", row.CreatedBy, row.UpdatedBy)
+	}
+
+	values := map[string]interface{}{"name": "b"}
+	conn.WithContext(ctx).Model(&operatorRow{}).Where("id = ?", 1).Updates(values)
+	if values["updated_by"] != "ams:1" {
+		t.Fatalf("update values = %v", values)
+	}
+	if _, ok := values["created_by"]; ok {
+		t.Fatal("created_by should not be touched on update")
+	}
+
+	anonymous := operatorRow{Name: "c"}
+	conn.Create(&anonymous)
+	if anonymous.CreatedBy != "" {
+		t.Fatalf("created_by without principal = %q", anonymous.CreatedBy)
+	}
+}
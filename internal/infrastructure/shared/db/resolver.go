@@ -0,0 +1,254 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+)
+
+// PrimaryNode 主库节点名称
+const PrimaryNode = "primary"
+
+type nodeKey struct{}
+
+type readYourWritesKey struct{}
+
+type writeTrackerKey struct{}
+
+// WithReadYourWrites 标记上下文始终读主库
+// 用于整体依赖最新数据的流程，如迁移、数据回填等后台任务；请求内的写后读由 WithWriteTracking 处理
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+// WithWriteTracking 开启写入跟踪，该上下文(含派生上下文)执行写入后，后续查询改走主库
+// 由HTTP中间件按请求开启，避免请求内保存后回读(如经缓存仓储回源)读到从库的旧数据
+func WithWriteTracking(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(writeTrackerKey{}).(*atomic.Bool); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, writeTrackerKey{}, new(atomic.Bool))
+}
+
+// IsReadYourWrites 上下文是否要求读主库
+func IsReadYourWrites(ctx context.Context) bool {
+	if v, _ := ctx.Value(readYourWritesKey{}).(bool); v {
+		return true
+	}
+	written, ok := ctx.Value(writeTrackerKey{}).(*atomic.Bool)
+	return ok && written.Load()
+}
+
+// markWritten 记录上下文已执行写入
+func markWritten(ctx context.Context) {
+	if written, ok := ctx.Value(writeTrackerKey{}).(*atomic.Bool); ok {
+		written.Store(true)
+	}
+}
+
+// NodeFromContext 执行SQL的数据库节点
+func NodeFromContext(ctx context.Context) string {
+	if node, ok := ctx.Value(nodeKey{}).(string); ok {
+		return node
+	}
+	return PrimaryNode
+}
+
+// replica 只读从库
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// resolver 读写分离路由
+// 事务外、未要求读主库且不加锁的查询轮询路由到健康的从库，其余请求走主库
+type resolver struct {
+	primaryPool gorm.ConnPool
+	replicas    []*replica
+	next        atomic.Uint64
+	interval    time.Duration
+	timeout     time.Duration
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+func newResolver(cfg *config.Config, primary *gorm.DB) (*resolver, error) {
+	r := &resolver{
+		primaryPool: primary.ConnPool,
+		interval:    cfg.Database.ReplicaHealth.Interval,
+		timeout:     cfg.Database.ReplicaHealth.Timeout,
+		stop:        make(chan struct{}),
+	}
+
+	for i, item := range cfg.Database.Replicas {
+		username, password := item.Username, item.Password
+		if username == "" {
+			username, password = cfg.Database.Username, cfg.Database.Password
+		}
+		port := item.Port
+		if port == 0 {
+			port = cfg.Database.Port
+		}
+		name := item.Name
+		if name == "" {
+			name = fmt.Sprintf("replica-%d", i+1)
+		}
+
+		conn, err := openDB(cfg, dialector(cfg, item.Host, port, username, password))
+		if err != nil {
+			r.close()
+			return nil, fmt.Errorf("replica %s: %w", name, err)
+		}
+		r.replicas = append(r.replicas, &replica{name: name, db: conn})
+	}
+
+	if err := r.registerCallbacks(primary); err != nil {
+		r.close()
+		return nil, err
+	}
+
+	r.checkHealth()
+	if r.interval > 0 {
+		go r.healthLoop()
+	}
+	return r, nil
+}
+
+// registerCallbacks 注册读路由与写入跟踪回调
+func (r *resolver) registerCallbacks(primary *gorm.DB) error {
+	callback := primary.Callback()
+	if err := callback.Query().Before("gorm:query").Register("resolver:query", r.routeRead); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("gorm:row").Register("resolver:row", r.routeRead); err != nil {
+		return err
+	}
+	if err := callback.Create().Before("gorm:create").Register("resolver:create", trackWrite); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("resolver:update", trackWrite); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("resolver:delete", trackWrite); err != nil {
+		return err
+	}
+	return callback.Raw().Before("gorm:raw").Register("resolver:raw", trackWrite)
+}
+
+// trackWrite 写入前标记上下文，写入失败或事务回滚时仍读主库，不影响正确性
+func trackWrite(db *gorm.DB) {
+	if db.Statement.Context != nil {
+		markWritten(db.Statement.Context)
+	}
+}
+
+// routeRead 读请求路由
+func (r *resolver) routeRead(db *gorm.DB) {
+	if db.Error != nil || !r.readable(db) {
+		return
+	}
+	node := r.pick()
+	if node == nil {
+		return
+	}
+	db.Statement.ConnPool = node.db.ConnPool
+	db.Statement.Context = context.WithValue(db.Statement.Context, nodeKey{}, node.name)
+}
+
+// readable 是否可以路由到从库
+func (r *resolver) readable(db *gorm.DB) bool {
+	stmt := db.Statement
+	// 事务中的连接池不是主库连接池
+	if stmt.ConnPool != r.primaryPool {
+		return false
+	}
+	if stmt.Context != nil && IsReadYourWrites(stmt.Context) {
+		return false
+	}
+	// SELECT ... FOR UPDATE/SHARE
+	if _, ok := stmt.Clauses["FOR"]; ok {
+		return false
+	}
+	// Raw 语句仅路由查询
+	if sql := strings.TrimSpace(stmt.SQL.String()); sql != "" {
+		sql = strings.ToUpper(sql)
+		return strings.HasPrefix(sql, "SELECT") || strings.HasPrefix(sql, "WITH")
+	}
+	return true
+}
+
+// pick 轮询选择健康的从库，均不可用时返回nil(回退主库)
+func (r *resolver) pick() *replica {
+	n := len(r.replicas)
+	start := r.next.Add(1)
+	for i := 0; i < n; i++ {
+		node := r.replicas[(start+uint64(i))%uint64(n)]
+		if node.healthy.Load() {
+			return node
+		}
+	}
+	return nil
+}
+
+func (r *resolver) healthLoop() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.checkHealth()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// checkHealth 检查从库连通性，状态变化时记录日志
+func (r *resolver) checkHealth() {
+	for _, node := range r.replicas {
+		err := r.ping(node)
+		healthy := err == nil
+		if node.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			logger.Info(context.Background(), "从库已恢复，加入读轮询", logger.AddField("node", node.name))
+		} else {
+			logger.Warn(context.Background(), "从库不可用，移出读轮询", logger.AddField("node", node.name), logger.ErrorField(err))
+		}
+	}
+}
+
+func (r *resolver) ping(node *replica) error {
+	sqlDB, err := node.db.DB()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (r *resolver) close() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+	for _, node := range r.replicas {
+		if sqlDB, err := node.db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}
+}
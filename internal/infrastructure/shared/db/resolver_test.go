@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/db/dbtest"
+)
+
+type resolverItem struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string
+}
+
+// newTestResolver 主库与从库各写入一条以节点命名的记录，按查询结果判断路由节点
+func newTestResolver(t *testing.T) *gorm.DB {
+	t.Helper()
+	primary := dbtest.Open(t, &resolverItem{})
+	replicaDB := dbtest.Open(t, &resolverItem{})
+	primary.Create(&resolverItem{ID: 1, Name: PrimaryNode})
+	replicaDB.Create(&resolverItem{ID: 1, Name: "replica"})
+
+	r := &resolver{primaryPool: primary.ConnPool, replicas: []*replica{{name: "replica", db: replicaDB}}}
+	r.replicas[0].healthy.Store(true)
+	if err := r.registerCallbacks(primary); err != nil {
+		t.Fatal(err)
+	}
+	return primary
+}
+
+func readName(t *testing.T, tx *gorm.DB) string {
+	t.Helper()
+	var item resolverItem
+	if err := tx.Take(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+	return item.Name
+}
+
+func TestResolverRouting(t *testing.T) {
+	conn := newTestResolver(t)
+	ctx := context.Background()
+
+	if got := readName(t, conn.WithContext(ctx)); got != "replica" {
+		t.Fatalf("select routed to %s", got)
+	}
+
+	var name string
+	if err := conn.Raw("SELECT name FROM resolver_item WHERE id = 1").Scan(&name).Error; err != nil || name != "replica" {
+		t.Fatalf("raw select routed to %s, err = %v", name, err)
+	}
+	if err := conn.Raw("WITH t AS (SELECT name FROM resolver_item) SELECT name FROM t").Scan(&name).Error; err != nil || name != "replica" {
+		t.Fatalf("raw with routed to %s, err = %v", name, err)
+	}
+	// 非查询的Raw语句走主库
+	if err := conn.Raw("UPDATE resolver_item SET name = name WHERE id = 1 RETURNING name").Scan(&name).Error; err != nil || name != PrimaryNode {
+		t.Fatalf("raw update routed to %s, err = %v", name, err)
+	}
+
+	// 加锁查询走主库(SQLite不支持FOR UPDATE，仅校验路由)
+	var item resolverItem
+	stmt := conn.Session(&gorm.Session{DryRun: true}).Clauses(clause.Locking{Strength: "UPDATE"}).Take(&item).Statement
+	if node := NodeFromContext(stmt.Context); node != PrimaryNode {
+		t.Fatalf("locking select routed to %s", node)
+	}
+
+	// 事务内查询走主库
+	_ = conn.Transaction(func(tx *gorm.DB) error {
+		if got := readName(t, tx); got != PrimaryNode {
+			t.Fatalf("select in transaction routed to %s", got)
+		}
+		return nil
+	})
+
+	if got := readName(t, conn.WithContext(WithReadYourWrites(ctx))); got != PrimaryNode {
+		t.Fatalf("read-your-writes select routed to %s", got)
+	}
+}
+
+func TestResolverWriteTracking(t *testing.T) {
+	conn := newTestResolver(t)
+	ctx := WithWriteTracking(context.Background())
+
+	if got := readName(t, conn.WithContext(ctx)); got != "replica" {
+		t.Fatalf("select before write routed to %s", got)
+	}
+	if err := conn.WithContext(ctx).Create(&resolverItem{ID: 2, Name: "new"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	var item resolverItem
+	if err := conn.WithContext(ctx).Take(&item, 2).Error; err != nil || item.Name != "new" {
+		t.Fatalf("select after write = %+v, err = %v", item, err)
+	}
+
+	// 其它请求不受影响
+	if got := readName(t, conn.WithContext(WithWriteTracking(context.Background()))); got != "replica" {
+		t.Fatalf("other request routed to %s", got)
+	}
+}
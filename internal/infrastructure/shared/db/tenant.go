@@ -0,0 +1,62 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
+)
+
+const tenantColumn = "tenant_id"
+
+// registerTenantCallbacks 注册租户隔离回调
+// 实体包含 tenant_id 字段且上下文携带租户时，查询、更新、删除自动追加租户条件，创建与更新自动填充租户ID；
+// 启用多租户(strict)时无租户的上下文按默认租户(空租户ID)隔离，跨租户的系统任务需通过 tenant.Bypass 显式声明；
+// 未启用多租户时上下文无租户不做处理。原生 SQL 不受影响
+func registerTenantCallbacks(conn *gorm.DB, strict bool) error {
+	stampTenant := func(db *gorm.DB) {
+		if id, ok := contextTenant(db, strict); ok {
+			db.Statement.SetColumn(tenantColumn, id, true)
+		}
+	}
+	scopeTenant := func(db *gorm.DB) {
+		if id, ok := contextTenant(db, strict); ok {
+			db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn}, Value: id},
+			}})
+		}
+	}
+
+	callbacks := conn.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("app:tenant_create", stampTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("app:tenant_query", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("app:tenant_row", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("app:tenant_update", func(db *gorm.DB) {
+		scopeTenant(db)
+		stampTenant(db)
+	}); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("app:tenant_delete", scopeTenant)
+}
+
+// contextTenant 语句需隔离的租户ID，实体无租户字段或无需隔离时 ok 为false
+func contextTenant(db *gorm.DB, strict bool) (id string, ok bool) {
+	if db.Statement.Schema == nil || db.Statement.Schema.LookUpField(tenantColumn) == nil {
+		return "", false
+	}
+	ctx := db.Statement.Context
+	if tenant.IsBypassed(ctx) {
+		return "", false
+	}
+	if id, ok = tenant.FromContext(ctx); ok {
+		return id, true
+	}
+	return "", strict
+}
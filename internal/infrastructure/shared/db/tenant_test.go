@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/model"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
+)
+
+type tenantRow struct {
+	model.PrimaryKeyID
+	Name string
+	model.Tenant
+}
+
+type plainRow struct {
+	model.PrimaryKeyID
+	Name string
+}
+
+func TestTenantCallbacks(t *testing.T) {
+	conn, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = registerTenantCallbacks(conn, false); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := tenant.WithContext(context.Background(), "t1")
+
+	row := tenantRow{Name: "a"}
+	conn.WithContext(ctx).Create(&row)
+	if row.TenantID != "t1" {
+		t.Fatalf("create tenant = %q", row.TenantID)
+	}
+
+	var rows []tenantRow
+	stmt := conn.WithContext(ctx).Where("name = ?", "a").Find(&rows).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, `"tenant_rows"."tenant_id" = $2`) {
+		t.Fatalf("query sql missing tenant scope: %s", sql)
+	}
+
+	stmt = conn.WithContext(ctx).Model(&tenantRow{}).Where("id = ?", 1).Update("name", "b").Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, `"tenant_rows"."tenant_id" = `) {
+		t.Fatalf("update sql missing tenant scope: %s", sql)
+	}
+
+	stmt = conn.WithContext(ctx).Where("id = ?", 1).Delete(&tenantRow{}).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, `"tenant_rows"."tenant_id" = `) {
+		t.Fatalf("delete sql missing tenant scope: %s", sql)
+	}
+
+	stmt = conn.WithContext(ctx).Find(&[]plainRow{}).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "tenant_id") {
+		t.Fatalf("table without tenant column should not be scoped: %s", sql)
+	}
+
+	stmt = conn.Find(&rows).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "tenant_id") {
+		t.Fatalf("query without tenant should not be scoped: %s", sql)
+	}
+}
+
+func TestTenantCallbacksStrict(t *testing.T) {
+	conn, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = registerTenantCallbacks(conn, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// 无租户的上下文按默认租户隔离
+	var rows []tenantRow
+	stmt := conn.Find(&rows).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, `"tenant_rows"."tenant_id" = $1`) || stmt.Vars[0] != "" {
+		t.Fatalf("query without tenant should be scoped to default tenant: %s %v", sql, stmt.Vars)
+	}
+
+	row := tenantRow{Name: "a", Tenant: model.Tenant{TenantID: "t1"}}
+	conn.Create(&row)
+	if row.TenantID != "" {
+		t.Fatalf("create without tenant = %q, want default tenant", row.TenantID)
+	}
+
+	// 系统任务显式跨租户时不追加租户条件
+	stmt = conn.WithContext(tenant.Bypass(context.Background())).Find(&rows).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "tenant_id") {
+		t.Fatalf("bypassed query should not be scoped: %s", sql)
+	}
+}
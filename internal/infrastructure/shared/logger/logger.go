@@ -37,9 +37,14 @@ var _logger *logger
 func InitLogger(debug bool) {
 	newZapLogger(debug)
 	_logger = &logger{_logger: _zapLogger}
+	watchLevel()
 }
 
 func (l *logger) log(ctx context.Context, level zapcore.Level, message string, fields ...Field) {
+	// 未初始化(如单元测试)时忽略
+	if l == nil {
+		return
+	}
 	fields = append(
 		fields,
 		l.trace(ctx)...,
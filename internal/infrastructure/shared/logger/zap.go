@@ -1,10 +1,12 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/dysodeng/app/internal/infrastructure/config"
@@ -17,6 +19,41 @@ const logFileExt = ".log"
 
 var _zapLogger *zap.Logger
 
+var (
+	// _level 日志级别，支持运行时修改
+	_level         = zap.NewAtomicLevel()
+	watchLevelOnce sync.Once
+)
+
+// SetLevel 修改日志级别，level 为空时调试模式为debug否则为info
+func SetLevel(level string, debug bool) error {
+	if level == "" {
+		level = "info"
+		if debug {
+			level = "debug"
+		}
+	}
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	_level.SetLevel(l)
+	return nil
+}
+
+// watchLevel 订阅日志级别配置，配置重载后即时生效
+func watchLevel() {
+	watchLevelOnce.Do(func() {
+		config.Subscribe("app.log_level", func(_, next *config.Config) {
+			if err := SetLevel(next.App.LogLevel, next.App.Debug); err != nil {
+				Error(context.Background(), "日志级别更新失败", ErrorField(err))
+				return
+			}
+			Info(context.Background(), "日志级别已更新", AddField("level", _level.String()))
+		})
+	})
+}
+
 func newZapLogger(debug bool) {
 	zapEncoderConfig := zapcore.EncoderConfig{
 		MessageKey:  "msg",                       // 结构化（json）输出：msg的key
@@ -45,18 +82,22 @@ func newZapLogger(debug bool) {
 		panic(err)
 	}
 
+	if err = SetLevel(config.GlobalConfig.App.LogLevel, debug); err != nil {
+		panic(err)
+	}
+
 	var cores []zapcore.Core
 	if debug {
 		cores = append(cores, zapcore.NewCore(
 			zapcore.NewJSONEncoder(zapEncoderConfig),
 			zapcore.NewMultiWriteSyncer(zapcore.AddSync(os.Stdout), zapcore.AddSync(fileWriter)),
-			zapcore.DebugLevel,
+			_level,
 		))
 	} else {
 		cores = append(cores, zapcore.NewCore(
 			zapcore.NewJSONEncoder(zapEncoderConfig),
 			zapcore.NewMultiWriteSyncer(zapcore.AddSync(fileWriter)),
-			zapcore.InfoLevel,
+			_level,
 		))
 	}
 
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/pii"
+)
+
+// Encrypted 敏感字段，写入时使用 AES-GCM 信封加密，读取时自动解密
+// 兼容加密前写入的明文，可通过 pii:backfill 命令加密存量数据
+// 密文不可用于查询条件，需配合 BlindIndex 做等值查询
+type Encrypted string
+
+func (e Encrypted) Value() (driver.Value, error) {
+	return pii.Encrypt(string(e))
+}
+
+func (e *Encrypted) Scan(v interface{}) error {
+	var value string
+	switch val := v.(type) {
+	case nil:
+		*e = ""
+		return nil
+	case []byte:
+		value = string(val)
+	case string:
+		value = val
+	default:
+		return fmt.Errorf("model: cannot scan %T into Encrypted", v)
+	}
+
+	plaintext, err := pii.Decrypt(value)
+	if err != nil {
+		return err
+	}
+	*e = Encrypted(plaintext)
+	return nil
+}
+
+func (e Encrypted) String() string {
+	return string(e)
+}
+
+// BlindIndex 加密字段的盲索引，空值存储为NULL，避免与唯一索引冲突
+type BlindIndex string
+
+// NewBlindIndex 计算盲索引，purpose 为字段标识，如 users.telephone
+func NewBlindIndex(purpose, value string) BlindIndex {
+	return BlindIndex(pii.BlindIndex(purpose, value))
+}
+
+func (b BlindIndex) Value() (driver.Value, error) {
+	if b == "" {
+		return nil, nil
+	}
+	return string(b), nil
+}
+
+func (b *BlindIndex) Scan(v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		*b = ""
+	case []byte:
+		*b = BlindIndex(val)
+	case string:
+		*b = BlindIndex(val)
+	default:
+		return fmt.Errorf("model: cannot scan %T into BlindIndex", v)
+	}
+	return nil
+}
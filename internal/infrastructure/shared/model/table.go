@@ -23,6 +23,22 @@ type Time struct {
 	UpdatedAt JSONTime `gorm:"type:timestamp(0) without time zone;not null" json:"updated_at,omitempty"`
 }
 
+// OptimisticLock 乐观锁版本号，更新时校验并递增
+type OptimisticLock struct {
+	Version uint64 `gorm:"not null;default:1;comment:版本号" json:"version"`
+}
+
+// Operator 创建人,修改人，由数据库回调根据请求主体自动填充
+type Operator struct {
+	CreatedBy string `gorm:"type:varchar(64);not null;default:'';comment:创建人" json:"created_by"`
+	UpdatedBy string `gorm:"type:varchar(64);not null;default:'';comment:修改人" json:"updated_by"`
+}
+
+// Tenant 所属租户，由数据库回调根据上下文租户自动过滤与填充，单租户模式下为空
+type Tenant struct {
+	TenantID string `gorm:"type:varchar(64);index;not null;default:'';comment:租户ID" json:"tenant_id"`
+}
+
 // SoftDelete 软删除
 type SoftDelete struct {
 	IsDelete   uint8    `gorm:"not null;default:0;comment:删除标识 0-未删除 1-已删除" json:"is_delete"`
@@ -0,0 +1,63 @@
+package mq
+
+import (
+	"fmt"
+
+	"github.com/dysodeng/mq/adapters/redis"
+	mqConfig "github.com/dysodeng/mq/config"
+	"github.com/dysodeng/mq/contract"
+	"github.com/dysodeng/mq/observability"
+	"github.com/dysodeng/mq/serializer"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/metrics"
+)
+
+// OrderedConsumer 有序消费者，按消息到达顺序逐条回调
+// Redis适配器的消费者由工作池并发回调，单独创建单工作协程的消费者；
+// 其余适配器按订阅串行回调，直接使用共享消费者
+func OrderedConsumer(cfg *config.Config) (contract.Consumer, error) {
+	if !cfg.MessageQueue.Enabled || cfg.MessageQueue.Driver != "redis" {
+		return mqInstance.Consumer(), nil
+	}
+
+	redisCfg := createRedisConfig(cfg)
+	redisCfg.ConsumerWorkerCount = 1
+	redisCfg.ConsumerBatchSize = 1
+	redisCfg.SetDefaults()
+
+	client, err := redis.NewClientFactory(redisCfg).CreateClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis client: %w", err)
+	}
+
+	observer := &metricsObserver{meter: metrics.Meter(), logger: logger.ZapLogger()}
+	recorder, err := observability.NewMetricsRecorder(observer, mqConfig.AdapterRedis.String())
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to create metrics recorder: %w", err)
+	}
+	ser, err := serializer.NewSerializer(serializer.Type(redisCfg.SerializationType))
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to create serializer: %w", err)
+	}
+
+	consumer := redis.NewRedisConsumer(client, observer, redisCfg, recorder, ser, redis.NewKeyGenerator(QueuePrefix))
+	return &orderedRedisConsumer{Consumer: consumer, client: client}, nil
+}
+
+// orderedRedisConsumer 独立连接的Redis消费者，关闭时一并关闭连接
+type orderedRedisConsumer struct {
+	*redis.Consumer
+	client redis.Client
+}
+
+func (c *orderedRedisConsumer) Close() error {
+	err := c.Consumer.Close()
+	if closeErr := c.client.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,195 @@
+package pii
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/shared/crypto/aes"
+)
+
+// prefix 密文前缀，完整格式 enc:密钥ID:base64(加密的数据密钥 + 加密的数据)
+const prefix = "enc:"
+
+// dataKeySize 数据密钥长度(AES-256)
+const dataKeySize = 32
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Keyring 信封加密密钥环
+// 每个值使用随机数据密钥加密，数据密钥由主密钥加密后随密文存储；
+// 使用 primary 主密钥加密，按密文中的密钥ID解密，支持主密钥轮换
+type Keyring struct {
+	enabled  bool
+	primary  string
+	keys     map[string][]byte
+	indexKey []byte
+}
+
+var defaultKeyring = &Keyring{}
+
+// Init 按配置初始化全局密钥环
+// 未开启加密时写入明文，但仍会解密已有密文，便于关闭加密后通过 pii:backfill 还原数据
+func Init(cfg config.Encryption) error {
+	kr, err := NewKeyring(cfg)
+	if err != nil {
+		return err
+	}
+	defaultKeyring = kr
+	return nil
+}
+
+// NewKeyring 创建密钥环
+func NewKeyring(cfg config.Encryption) (*Keyring, error) {
+	kr := &Keyring{enabled: cfg.Enabled, primary: cfg.PrimaryKey, keys: make(map[string][]byte)}
+	for _, item := range strings.Split(cfg.Keys, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(item, ":")
+		if !ok || !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("pii: invalid key id %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("pii: key %q: %w", id, err)
+		}
+		if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+			return nil, fmt.Errorf("pii: key %q: invalid length %d", id, len(key))
+		}
+		kr.keys[id] = key
+	}
+
+	if cfg.IndexKey != "" {
+		indexKey, err := base64.StdEncoding.DecodeString(cfg.IndexKey)
+		if err != nil {
+			return nil, fmt.Errorf("pii: index key: %w", err)
+		}
+		kr.indexKey = indexKey
+	}
+
+	if cfg.Enabled {
+		if _, ok := kr.keys[cfg.PrimaryKey]; !ok {
+			return nil, fmt.Errorf("pii: primary key %q not found", cfg.PrimaryKey)
+		}
+		if len(kr.indexKey) < 16 {
+			return nil, fmt.Errorf("pii: index key must be at least 16 bytes")
+		}
+	}
+	return kr, nil
+}
+
+// Encrypt 加密，空值与未开启加密时原样返回
+func (kr *Keyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" || !kr.enabled {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	// 密钥ID作为附加认证数据，防止篡改密文中的密钥ID
+	aad := []byte(kr.primary)
+	wrapped, err := aes.GCMEncrypt(dataKey, kr.keys[kr.primary], aad)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := aes.GCMEncrypt([]byte(plaintext), dataKey, aad)
+	if err != nil {
+		return "", err
+	}
+	return prefix + kr.primary + ":" + base64.RawStdEncoding.EncodeToString(append(wrapped, sealed...)), nil
+}
+
+// Decrypt 解密，非密文(加密前写入的明文)原样返回
+func (kr *Keyring) Decrypt(value string) (string, error) {
+	id, encoded, ok := split(value)
+	if !ok {
+		return value, nil
+	}
+	key, ok := kr.keys[id]
+	if !ok {
+		return "", fmt.Errorf("pii: unknown key %q", id)
+	}
+	blob, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("pii: malformed ciphertext: %w", err)
+	}
+	wrappedSize := dataKeySize + aes.GCMOverhead()
+	if len(blob) < wrappedSize {
+		return "", fmt.Errorf("pii: malformed ciphertext")
+	}
+
+	aad := []byte(id)
+	dataKey, err := aes.GCMDecrypt(blob[:wrappedSize], key, aad)
+	if err != nil {
+		return "", fmt.Errorf("pii: unwrap data key: %w", err)
+	}
+	plaintext, err := aes.GCMDecrypt(blob[wrappedSize:], dataKey, aad)
+	if err != nil {
+		return "", fmt.Errorf("pii: decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// Stale 存储值是否与当前配置不一致，需要重新写入
+// 开启加密时为明文或非主密钥加密的密文，未开启加密时为密文
+func (kr *Keyring) Stale(value string) bool {
+	if value == "" {
+		return false
+	}
+	id, _, ok := split(value)
+	if !kr.enabled {
+		return ok
+	}
+	return !ok || id != kr.primary
+}
+
+// BlindIndex 盲索引，明文的确定性HMAC摘要，用于加密字段的等值查询与唯一约束
+// purpose 区分不同字段，避免相同明文在不同字段得到相同索引；空值返回空
+func (kr *Keyring) BlindIndex(purpose, value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, kr.indexKey)
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func split(value string) (id, encoded string, ok bool) {
+	rest, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
+
+// Encrypt 使用全局密钥环加密
+func Encrypt(plaintext string) (string, error) {
+	return defaultKeyring.Encrypt(plaintext)
+}
+
+// Decrypt 使用全局密钥环解密
+func Decrypt(value string) (string, error) {
+	return defaultKeyring.Decrypt(value)
+}
+
+// Stale 存储值是否需要按当前配置重新写入
+func Stale(value string) bool {
+	return defaultKeyring.Stale(value)
+}
+
+// BlindIndex 使用全局密钥环计算盲索引
+func BlindIndex(purpose, value string) string {
+	return defaultKeyring.BlindIndex(purpose, value)
+}
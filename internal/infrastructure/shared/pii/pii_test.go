@@ -0,0 +1,116 @@
+package pii
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+)
+
+var (
+	key1     = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	key2     = base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))
+	indexKey = base64.StdEncoding.EncodeToString([]byte("index-key-0123456789"))
+)
+
+func newTestKeyring(t *testing.T, enabled bool, primary string) *Keyring {
+	t.Helper()
+	kr, err := NewKeyring(config.Encryption{
+		Enabled:    enabled,
+		PrimaryKey: primary,
+		Keys:       "k1:" + key1 + ", k2:" + key2,
+		IndexKey:   indexKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kr
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	kr := newTestKeyring(t, true, "k1")
+
+	a, err := kr.Encrypt("13800138000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := kr.Encrypt("13800138000")
+	if a == b {
+		t.Fatal("ciphertext should be randomized")
+	}
+	if !strings.HasPrefix(a, "enc:k1:") || len(a) > 255 {
+		t.Fatalf("unexpected ciphertext %q", a)
+	}
+
+	plaintext, err := kr.Decrypt(a)
+	if err != nil || plaintext != "13800138000" {
+		t.Fatalf("decrypt got %q, %v", plaintext, err)
+	}
+
+	// 加密前写入的明文原样返回
+	if plaintext, _ = kr.Decrypt("13800138000"); plaintext != "13800138000" {
+		t.Fatalf("plaintext passthrough got %q", plaintext)
+	}
+	if empty, _ := kr.Encrypt(""); empty != "" {
+		t.Fatalf("empty value should not be encrypted, got %q", empty)
+	}
+
+	// 篡改密钥ID后认证失败
+	if _, err = kr.Decrypt(strings.Replace(a, "enc:k1:", "enc:k2:", 1)); err == nil {
+		t.Fatal("expected error for tampered key id")
+	}
+}
+
+func TestRotation(t *testing.T) {
+	old := newTestKeyring(t, true, "k1")
+	ciphertext, _ := old.Encrypt("openid")
+
+	rotated := newTestKeyring(t, true, "k2")
+	if plaintext, err := rotated.Decrypt(ciphertext); err != nil || plaintext != "openid" {
+		t.Fatalf("decrypt with rotated keyring got %q, %v", plaintext, err)
+	}
+	if !rotated.Stale(ciphertext) || !rotated.Stale("openid") || rotated.Stale("") {
+		t.Fatal("old ciphertext and plaintext should be stale after rotation")
+	}
+	current, _ := rotated.Encrypt("openid")
+	if rotated.Stale(current) {
+		t.Fatal("ciphertext under primary key should not be stale")
+	}
+
+	disabled := newTestKeyring(t, false, "")
+	if !disabled.Stale(current) || disabled.Stale("openid") {
+		t.Fatal("ciphertext should be stale when encryption is disabled")
+	}
+	if plaintext, _ := disabled.Encrypt("openid"); plaintext != "openid" {
+		t.Fatalf("disabled keyring should write plaintext, got %q", plaintext)
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	kr := newTestKeyring(t, true, "k1")
+	a := kr.BlindIndex("users.telephone", "13800138000")
+	if len(a) != 64 || a != newTestKeyring(t, true, "k2").BlindIndex("users.telephone", "13800138000") {
+		t.Fatalf("blind index should be deterministic and independent of the primary key, got %q", a)
+	}
+	if a == kr.BlindIndex("ams_admin.telephone", "13800138000") {
+		t.Fatal("blind index should differ between purposes")
+	}
+	if kr.BlindIndex("users.telephone", "") != "" {
+		t.Fatal("blind index of empty value should be empty")
+	}
+}
+
+func TestNewKeyringValidation(t *testing.T) {
+	cases := []config.Encryption{
+		{Enabled: true, PrimaryKey: "k3", Keys: "k1:" + key1, IndexKey: indexKey},
+		{Enabled: true, PrimaryKey: "k1", Keys: "k1:" + key1},
+		{PrimaryKey: "k1", Keys: "k1:" + base64.StdEncoding.EncodeToString([]byte("short"))},
+		{PrimaryKey: "k1", Keys: "bad id:" + key1},
+	}
+	for i, cfg := range cases {
+		if _, err := NewKeyring(cfg); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
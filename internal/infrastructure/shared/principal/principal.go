@@ -0,0 +1,32 @@
+package principal
+
+import "context"
+
+// Principal 当前请求主体
+type Principal struct {
+	Type      string // 主体类型 user/ams/system
+	ID        string // 主体ID
+	IP        string // 请求来源IP
+	UserAgent string // 请求客户端标识
+}
+
+// String 主体标识，格式为 类型:ID，用于审计字段
+func (p Principal) String() string {
+	if p.ID == "" {
+		return p.Type
+	}
+	return p.Type + ":" + p.ID
+}
+
+type principalKey struct{}
+
+// WithContext 将请求主体写入上下文
+func WithContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext 从上下文获取请求主体
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
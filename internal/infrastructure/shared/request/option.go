@@ -2,6 +2,7 @@ package request
 
 import (
 	"context"
+	"net/http"
 	"time"
 )
 
@@ -12,6 +13,7 @@ const (
 
 type requestOption struct {
 	ctx            context.Context
+	client         *http.Client
 	timeout        time.Duration
 	maxBufferSize  int
 	headers        map[string]string
@@ -34,6 +36,7 @@ func (f optionFunc) apply(option *requestOption) {
 func defaultRequestOptions() *requestOption {
 	return &requestOption{
 		ctx:           context.Background(),
+		client:        &http.Client{},
 		timeout:       defaultRequestTimeout,
 		maxBufferSize: maxBufferSize,
 		headers:       make(map[string]string),
@@ -47,6 +50,13 @@ func WithContext(ctx context.Context) Option {
 	})
 }
 
+// WithClient 设置发送请求的HTTP客户端，默认使用不做任何限制的客户端
+func WithClient(client *http.Client) Option {
+	return optionFunc(func(option *requestOption) {
+		option.client = client
+	})
+}
+
 // WithTimeout 设置请求超时时间
 func WithTimeout(timeout time.Duration) Option {
 	return optionFunc(func(option *requestOption) {
@@ -92,8 +92,7 @@ func request(requestUrl, method string, body io.Reader, opts ...Option) ([]byte,
 		req.Header.Add(headerName, headerValue)
 	}
 
-	client := &http.Client{}
-	response, err = client.Do(req)
+	response, err = reqOpts.client.Do(req)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			err = errors.New("请求超时")
@@ -189,8 +188,7 @@ func streamRequest(requestUrl, method string, body io.Reader, fn func([]byte) er
 		req.Header.Add(headerName, headerValue)
 	}
 
-	client := &http.Client{}
-	response, err = client.Do(req)
+	response, err = reqOpts.client.Do(req)
 	if err != nil {
 		return 0, err
 	}
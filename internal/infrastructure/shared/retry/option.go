@@ -51,3 +51,19 @@ func WithRetryWaitTimeFunc(waitTimeFunc func(retryNum int) time.Duration) Option
 		option.waitTimeFunc = waitTimeFunc
 	})
 }
+
+// WithExponentialBackoff 指数退避等待时间，从 base 开始每次翻倍，不超过 max
+func WithExponentialBackoff(base, max time.Duration) Option {
+	return retryOptionFunc(func(option *option) {
+		option.waitTimeFunc = func(retryNum int) time.Duration {
+			wait := base
+			for i := 1; i < retryNum; i++ {
+				wait *= 2
+				if wait >= max {
+					return max
+				}
+			}
+			return wait
+		}
+	})
+}
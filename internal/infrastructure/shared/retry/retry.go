@@ -1,6 +1,7 @@
 package retry
 
 import (
+	"context"
 	"log"
 	"time"
 )
@@ -34,3 +35,33 @@ func Invoke(tryFunc func() error, opts ...Option) {
 		time.Sleep(time.Until(nextTry))
 	}
 }
+
+// Do 重试执行直至成功、达到重试次数或上下文取消，返回最后一次执行的错误
+func Do(ctx context.Context, tryFunc func() error, opts ...Option) error {
+	options := defaultRetryOptions()
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	currentRetry := 0
+	for {
+		err := tryFunc()
+		if err == nil {
+			return nil
+		}
+
+		currentRetry++
+
+		if currentRetry >= options.retryNum {
+			return err
+		}
+
+		timer := time.NewTimer(options.waitTimeFunc(currentRetry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
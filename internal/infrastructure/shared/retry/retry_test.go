@@ -0,0 +1,64 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	opt := defaultRetryOptions()
+	WithExponentialBackoff(10*time.Millisecond, 40*time.Millisecond).apply(opt)
+
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}
+	for i, w := range want {
+		if got := opt.waitTimeFunc(i + 1); got != w {
+			t.Fatalf("wait(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	var waits []time.Duration
+	backoff := WithRetryWaitTimeFunc(func(retryNum int) time.Duration {
+		waits = append(waits, time.Duration(retryNum)*time.Millisecond)
+		return time.Millisecond
+	})
+
+	// 达到重试次数后返回最后一次错误
+	attempts := 0
+	err := Do(context.Background(), func() error {
+		attempts++
+		return errors.New("failed")
+	}, WithRetryNum(3), backoff)
+	if err == nil || attempts != 3 || len(waits) != 2 || waits[1] != 2*time.Millisecond {
+		t.Fatalf("err = %v, attempts = %d, waits = %v", err, attempts, waits)
+	}
+
+	// 成功后不再重试
+	attempts = 0
+	err = Do(context.Background(), func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("failed")
+		}
+		return nil
+	}, WithRetryNum(3), backoff)
+	if err != nil || attempts != 2 {
+		t.Fatalf("err = %v, attempts = %d", err, attempts)
+	}
+
+	// 上下文取消时停止等待
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts = 0
+	start := time.Now()
+	err = Do(ctx, func() error {
+		attempts++
+		return errors.New("failed")
+	}, WithRetryNum(3), WithRetryWaitTime(time.Hour))
+	if err == nil || attempts != 1 || time.Since(start) > time.Second {
+		t.Fatalf("err = %v, attempts = %d", err, attempts)
+	}
+}
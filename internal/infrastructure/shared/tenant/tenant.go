@@ -0,0 +1,56 @@
+package tenant
+
+import (
+	"context"
+	"path"
+	"regexp"
+)
+
+// ClaimKey token 中的租户声明
+const ClaimKey = "tenant_id"
+
+// idPattern 租户ID格式，限制字符集以便安全地拼入缓存key与存储路径
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// Valid 校验租户ID格式
+func Valid(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+type tenantKey struct{}
+
+// WithContext 将租户ID写入上下文，空ID不写入
+func WithContext(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext 从上下文获取租户ID
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+type bypassKey struct{}
+
+// Bypass 标记上下文显式跨租户访问，数据库查询不追加租户条件，仅用于迁移、数据回填等系统任务
+func Bypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// IsBypassed 上下文是否显式跨租户访问
+func IsBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(bypassKey{}).(bool)
+	return bypassed
+}
+
+// StoragePrefix 租户的存储路径前缀，无租户时为空
+func StoragePrefix(ctx context.Context) string {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return path.Join("tenants", id)
+}
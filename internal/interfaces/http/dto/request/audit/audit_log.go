@@ -0,0 +1,16 @@
+package audit
+
+import "time"
+
+// AuditLogListRequest 审计日志列表/导出请求
+type AuditLogListRequest struct {
+	ActorType  string     `form:"actor_type"`
+	ActorID    string     `form:"actor_id"`
+	Action     string     `form:"action"`
+	TargetType string     `form:"target_type"`
+	TargetID   string     `form:"target_id"`
+	StartTime  *time.Time `form:"start_time" time_format:"2006-01-02 15:04:05"`
+	EndTime    *time.Time `form:"end_time" time_format:"2006-01-02 15:04:05"`
+	Cursor     string     `form:"cursor"`
+	PageSize   int        `form:"page_size" binding:"omitempty,max=500"`
+}
@@ -0,0 +1,14 @@
+package cache
+
+// CacheKeyRequest 缓存key查询请求
+type CacheKeyRequest struct {
+	Namespace string   `form:"namespace" binding:"required"`
+	Key       string   `form:"key" binding:"required"`
+	Tags      []string `form:"tags"`
+}
+
+// CachePurgeRequest 缓存清理请求
+type CachePurgeRequest struct {
+	Namespace string   `json:"namespace" binding:"required"`
+	Tags      []string `json:"tags"`
+}
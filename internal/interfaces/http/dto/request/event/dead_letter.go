@@ -0,0 +1,15 @@
+package event
+
+// DeadLetterListRequest 死信列表请求
+type DeadLetterListRequest struct {
+	Status    uint8  `form:"status"`
+	EventType string `form:"event_type"`
+	Handler   string `form:"handler"`
+	Page      int    `form:"page"`
+	PageSize  int    `form:"page_size"`
+}
+
+// DeadLetterIDRequest 死信ID请求
+type DeadLetterIDRequest struct {
+	ID uint64 `uri:"id" binding:"required"`
+}
@@ -0,0 +1,18 @@
+package file
+
+import "time"
+
+// FileListRequest 文件列表请求
+// cursor 为上一页返回的 next_cursor，非空时忽略 page；page_size 默认20，最大500；with_total 为true时统计总数
+type FileListRequest struct {
+	MediaType uint8      `form:"media_type"`
+	Keyword   string     `form:"keyword"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02 15:04:05"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02 15:04:05"`
+	OrderBy   string     `form:"order_by" binding:"omitempty,oneof=created_at name size"`
+	OrderType string     `form:"order_type" binding:"omitempty,oneof=asc desc"`
+	Cursor    string     `form:"cursor"`
+	Page      int        `form:"page" binding:"omitempty,min=1"`
+	PageSize  int        `form:"page_size" binding:"omitempty,max=500"`
+	WithTotal bool       `form:"with_total"`
+}
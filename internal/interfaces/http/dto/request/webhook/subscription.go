@@ -0,0 +1,31 @@
+package webhook
+
+// SubscriptionListRequest 订阅列表请求
+type SubscriptionListRequest struct {
+	Keyword   string `form:"keyword"`
+	EventType string `form:"event_type"`
+	Status    *uint8 `form:"status"`
+	Page      int    `form:"page"`
+	PageSize  int    `form:"page_size"`
+}
+
+// SubscriptionIDRequest 订阅ID请求
+type SubscriptionIDRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// SubscriptionSaveRequest 创建/修改订阅请求
+type SubscriptionSaveRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	URL        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+	Secret     string   `json:"secret"`
+}
+
+// DeliveryListRequest 投递记录列表请求
+type DeliveryListRequest struct {
+	EventType string `form:"event_type"`
+	Status    uint8  `form:"status"`
+	Page      int    `form:"page"`
+	PageSize  int    `form:"page_size"`
+}
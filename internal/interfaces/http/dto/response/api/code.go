@@ -24,6 +24,7 @@ const (
 	CodeForbidden           Code = 403 // 无权限
 	CodeNotFound            Code = 404 // 未找到
 	CodeMethodNotAllowed    Code = 405 // 请求方法不允许
+	CodeConflict            Code = 409 // 数据冲突
 	CodeInternalServerError Code = 500 // 服务器内部错误
 )
 
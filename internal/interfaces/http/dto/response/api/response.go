@@ -2,7 +2,10 @@ package api
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
+	sharedErrors "github.com/dysodeng/app/internal/domain/shared/errors"
 	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
 )
 
@@ -43,3 +46,12 @@ func Fail(ctx context.Context, message string, code Code) Response[any] {
 		TraceID: trace.ParseContextTraceId(ctx),
 	}
 }
+
+// FailWithError 按错误类型构造失败响应，返回HTTP状态码与响应体
+// 并发修改冲突返回 409，其余业务错误保持 200
+func FailWithError(ctx context.Context, err error) (int, Response[any]) {
+	if errors.Is(err, sharedErrors.ErrCommonConflict) {
+		return http.StatusConflict, Fail(ctx, sharedErrors.ErrCommonConflict.Message, CodeConflict)
+	}
+	return http.StatusOK, Fail(ctx, err.Error(), CodeFail)
+}
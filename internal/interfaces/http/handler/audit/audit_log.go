@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dysodeng/app/internal/application/audit/dto/query"
+	"github.com/dysodeng/app/internal/application/audit/service"
+	"github.com/dysodeng/app/internal/infrastructure/shared/logger"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+	auditReq "github.com/dysodeng/app/internal/interfaces/http/dto/request/audit"
+	"github.com/dysodeng/app/internal/interfaces/http/dto/response/api"
+	"github.com/dysodeng/app/internal/interfaces/http/validator"
+)
+
+// AuditLogHandler 审计日志
+type AuditLogHandler struct {
+	baseTraceSpanName string
+	auditLogService   service.AuditLogApplicationService
+}
+
+// NewAuditLogHandler 创建审计日志控制器
+func NewAuditLogHandler(auditLogService service.AuditLogApplicationService) *AuditLogHandler {
+	return &AuditLogHandler{
+		baseTraceSpanName: "interfaces.http.handler.audit.AuditLogHandler",
+		auditLogService:   auditLogService,
+	}
+}
+
+// List 审计日志列表
+func (h *AuditLogHandler) List(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".List")
+	defer span.End()
+
+	var req auditReq.AuditLogListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	res, err := h.auditLogService.List(spanCtx, toListQuery(&req))
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// Export 导出审计日志(CSV)
+func (h *AuditLogHandler) Export(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Export")
+	defer span.End()
+
+	var req auditReq.AuditLogListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit_logs_%s.csv"`, time.Now().Format("20060102150405")))
+	if err := h.auditLogService.Export(spanCtx, toListQuery(&req), ctx.Writer); err != nil {
+		if ctx.Writer.Written() {
+			// 已开始输出，无法再返回错误响应
+			logger.Error(spanCtx, "审计日志导出失败", logger.ErrorField(err))
+			return
+		}
+		ctx.Header("Content-Disposition", "")
+		ctx.JSON(api.FailWithError(spanCtx, err))
+	}
+}
+
+func toListQuery(req *auditReq.AuditLogListRequest) *query.AuditLogListQuery {
+	return &query.AuditLogListQuery{
+		ActorType:  req.ActorType,
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		Cursor:     req.Cursor,
+		PageSize:   req.PageSize,
+	}
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dysodeng/app/internal/application/cache/dto/command"
+	"github.com/dysodeng/app/internal/application/cache/dto/query"
+	"github.com/dysodeng/app/internal/application/cache/service"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+	cacheReq "github.com/dysodeng/app/internal/interfaces/http/dto/request/cache"
+	"github.com/dysodeng/app/internal/interfaces/http/dto/response/api"
+	"github.com/dysodeng/app/internal/interfaces/http/validator"
+)
+
+// Handler 缓存诊断
+type Handler struct {
+	baseTraceSpanName string
+	cacheService      service.CacheApplicationService
+}
+
+// NewCacheHandler 创建缓存诊断控制器
+func NewCacheHandler(cacheService service.CacheApplicationService) *Handler {
+	return &Handler{
+		baseTraceSpanName: "interfaces.http.handler.cache.Handler",
+		cacheService:      cacheService,
+	}
+}
+
+// Namespaces 缓存命名空间列表
+func (h *Handler) Namespaces(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Namespaces")
+	defer span.End()
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, h.cacheService.Namespaces(spanCtx)))
+}
+
+// Inspect 查看缓存key及其标签版本
+func (h *Handler) Inspect(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Inspect")
+	defer span.End()
+
+	var req cacheReq.CacheKeyRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	res, err := h.cacheService.Inspect(spanCtx, &query.CacheKeyQuery{
+		Namespace: req.Namespace,
+		Key:       req.Key,
+		Tags:      req.Tags,
+	})
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// Purge 按标签失效或清空命名空间
+func (h *Handler) Purge(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Purge")
+	defer span.End()
+
+	var req cacheReq.CachePurgeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	if err := h.cacheService.Purge(spanCtx, &command.PurgeCacheCommand{
+		Namespace: req.Namespace,
+		Tags:      req.Tags,
+	}); err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, struct{}{}))
+}
@@ -0,0 +1,116 @@
+package event
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dysodeng/app/internal/application/event/dto/query"
+	"github.com/dysodeng/app/internal/application/event/dto/response"
+	"github.com/dysodeng/app/internal/application/event/service"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+	eventReq "github.com/dysodeng/app/internal/interfaces/http/dto/request/event"
+	"github.com/dysodeng/app/internal/interfaces/http/dto/response/api"
+	"github.com/dysodeng/app/internal/interfaces/http/validator"
+)
+
+// DeadLetterHandler 事件死信管理
+type DeadLetterHandler struct {
+	baseTraceSpanName string
+	deadLetterService service.DeadLetterApplicationService
+}
+
+// NewDeadLetterHandler 创建事件死信管理控制器
+func NewDeadLetterHandler(deadLetterService service.DeadLetterApplicationService) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		baseTraceSpanName: "interfaces.http.handler.event.DeadLetterHandler",
+		deadLetterService: deadLetterService,
+	}
+}
+
+// List 死信列表
+func (h *DeadLetterHandler) List(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".List")
+	defer span.End()
+
+	var req eventReq.DeadLetterListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	list, total, err := h.deadLetterService.List(spanCtx, &query.DeadLetterListQuery{
+		Status:    req.Status,
+		EventType: req.EventType,
+		Handler:   req.Handler,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, api.Record[[]response.DeadLetterResponse]{
+		Record: list,
+		Total:  total,
+	}))
+}
+
+// Info 死信详情
+func (h *DeadLetterHandler) Info(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Info")
+	defer span.End()
+
+	var req eventReq.DeadLetterIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	res, err := h.deadLetterService.Info(spanCtx, req.ID)
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// Replay 重放死信
+func (h *DeadLetterHandler) Replay(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Replay")
+	defer span.End()
+
+	var req eventReq.DeadLetterIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	if err := h.deadLetterService.Replay(spanCtx, req.ID); err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, struct{}{}))
+}
+
+// Discard 丢弃死信
+func (h *DeadLetterHandler) Discard(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Discard")
+	defer span.End()
+
+	var req eventReq.DeadLetterIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	if err := h.deadLetterService.Discard(spanCtx, req.ID); err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, struct{}{}))
+}
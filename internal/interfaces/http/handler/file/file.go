@@ -0,0 +1,72 @@
+package file
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dysodeng/app/internal/application/file/dto/query"
+	"github.com/dysodeng/app/internal/application/file/service"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+	fileReq "github.com/dysodeng/app/internal/interfaces/http/dto/request/file"
+	"github.com/dysodeng/app/internal/interfaces/http/dto/response/api"
+	"github.com/dysodeng/app/internal/interfaces/http/validator"
+)
+
+// FileHandler 文件管理
+type FileHandler struct {
+	baseTraceSpanName string
+	fileService       service.FileApplicationService
+}
+
+// NewFileHandler 创建文件管理控制器
+func NewFileHandler(fileService service.FileApplicationService) *FileHandler {
+	return &FileHandler{
+		baseTraceSpanName: "interfaces.http.handler.file.FileHandler",
+		fileService:       fileService,
+	}
+}
+
+// List 文件列表，支持页码与游标分页
+func (h *FileHandler) List(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".List")
+	defer span.End()
+
+	var req fileReq.FileListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	res, err := h.fileService.List(spanCtx, &query.FileListQuery{
+		MediaType: req.MediaType,
+		Keyword:   req.Keyword,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		OrderBy:   req.OrderBy,
+		OrderType: req.OrderType,
+		Cursor:    req.Cursor,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		WithTotal: req.WithTotal,
+	})
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// Delete 删除文件
+func (h *FileHandler) Delete(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Delete")
+	defer span.End()
+
+	if err := h.fileService.Delete(spanCtx, ctx.Param("id")); err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, struct{}{}))
+}
@@ -45,7 +45,7 @@ func (c *UploaderHandler) UploadFile(ctx *gin.Context) {
 
 	file, err := c.uploaderService.UploadFile(spanCtx, header)
 	if err != nil {
-		ctx.JSON(http.StatusOK, api.Fail(spanCtx, err.Error(), api.CodeFail))
+		ctx.JSON(api.FailWithError(spanCtx, err))
 		return
 	}
 
@@ -65,7 +65,7 @@ func (c *UploaderHandler) InitMultipartUpload(ctx *gin.Context) {
 
 	res, err := c.uploaderService.InitMultipartUpload(spanCtx, req.Filename, req.FileSize)
 	if err != nil {
-		ctx.JSON(http.StatusOK, api.Fail(spanCtx, err.Error(), api.CodeFail))
+		ctx.JSON(api.FailWithError(spanCtx, err))
 		return
 	}
 
@@ -103,7 +103,7 @@ func (c *UploaderHandler) UploadPart(ctx *gin.Context) {
 
 	res, err := c.uploaderService.UploadPart(spanCtx, filePath, uploadID, partNumber, header)
 	if err != nil {
-		ctx.JSON(http.StatusOK, api.Fail(spanCtx, err.Error(), api.CodeFail))
+		ctx.JSON(api.FailWithError(spanCtx, err))
 		return
 	}
 
@@ -123,7 +123,7 @@ func (c *UploaderHandler) CompleteMultipartUpload(ctx *gin.Context) {
 
 	file, err := c.uploaderService.CompleteMultipartUpload(spanCtx, req.UploadID, fileReq.PartList(req.Parts).ToAppDTO())
 	if err != nil {
-		ctx.JSON(http.StatusOK, api.Fail(spanCtx, err.Error(), api.CodeFail))
+		ctx.JSON(api.FailWithError(spanCtx, err))
 		return
 	}
 
@@ -143,7 +143,7 @@ func (c *UploaderHandler) MultipartUploadStatus(ctx *gin.Context) {
 
 	res, err := c.uploaderService.MultipartUploadStatus(spanCtx, req.UploadID)
 	if err != nil {
-		ctx.JSON(http.StatusOK, api.Fail(spanCtx, err.Error(), api.CodeFail))
+		ctx.JSON(api.FailWithError(spanCtx, err))
 		return
 	}
 
@@ -49,7 +49,7 @@ func (h *Handler) Login(ctx *gin.Context) {
 		Password:     req.Password,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusOK, api.Fail(spanCtx, err.Error(), api.CodeFail))
+		ctx.JSON(api.FailWithError(spanCtx, err))
 		return
 	}
 
@@ -68,7 +68,7 @@ func (h *Handler) RefreshToken(ctx *gin.Context) {
 
 	res, err := h.passportService.RefreshToken(spanCtx, req.RefreshToken)
 	if err != nil {
-		ctx.JSON(http.StatusOK, api.Fail(spanCtx, err.Error(), api.CodeFail))
+		ctx.JSON(api.FailWithError(spanCtx, err))
 		return
 	}
 
@@ -0,0 +1,258 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/dysodeng/app/internal/application/webhook/dto/command"
+	"github.com/dysodeng/app/internal/application/webhook/dto/query"
+	"github.com/dysodeng/app/internal/application/webhook/dto/response"
+	"github.com/dysodeng/app/internal/application/webhook/service"
+	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/trace"
+	webhookReq "github.com/dysodeng/app/internal/interfaces/http/dto/request/webhook"
+	"github.com/dysodeng/app/internal/interfaces/http/dto/response/api"
+	"github.com/dysodeng/app/internal/interfaces/http/validator"
+)
+
+// SubscriptionHandler Webhook订阅管理
+type SubscriptionHandler struct {
+	baseTraceSpanName   string
+	subscriptionService service.SubscriptionApplicationService
+}
+
+// NewSubscriptionHandler 创建Webhook订阅管理控制器
+func NewSubscriptionHandler(subscriptionService service.SubscriptionApplicationService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		baseTraceSpanName:   "interfaces.http.handler.webhook.SubscriptionHandler",
+		subscriptionService: subscriptionService,
+	}
+}
+
+// EventTypes 可订阅的事件类型
+func (h *SubscriptionHandler) EventTypes(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".EventTypes")
+	defer span.End()
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, h.subscriptionService.EventTypes(spanCtx)))
+}
+
+// List 订阅列表
+func (h *SubscriptionHandler) List(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".List")
+	defer span.End()
+
+	var req webhookReq.SubscriptionListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	list, total, err := h.subscriptionService.List(spanCtx, &query.SubscriptionListQuery{
+		Keyword:   req.Keyword,
+		EventType: req.EventType,
+		Status:    req.Status,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, api.Record[[]response.SubscriptionResponse]{
+		Record: list,
+		Total:  total,
+	}))
+}
+
+// Info 订阅详情
+func (h *SubscriptionHandler) Info(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Info")
+	defer span.End()
+
+	id, ok := h.bindID(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := h.subscriptionService.Info(spanCtx, id)
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// Create 创建订阅
+func (h *SubscriptionHandler) Create(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Create")
+	defer span.End()
+
+	var req webhookReq.SubscriptionSaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	res, err := h.subscriptionService.Create(spanCtx, &command.CreateSubscriptionCommand{
+		Name:       req.Name,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// Update 修改订阅
+func (h *SubscriptionHandler) Update(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Update")
+	defer span.End()
+
+	id, ok := h.bindID(ctx)
+	if !ok {
+		return
+	}
+	var req webhookReq.SubscriptionSaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	res, err := h.subscriptionService.Update(spanCtx, &command.UpdateSubscriptionCommand{
+		ID:         id,
+		Name:       req.Name,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// RotateSecret 重新生成签名密钥
+func (h *SubscriptionHandler) RotateSecret(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".RotateSecret")
+	defer span.End()
+
+	id, ok := h.bindID(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := h.subscriptionService.RotateSecret(spanCtx, id)
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, res))
+}
+
+// Delete 删除订阅
+func (h *SubscriptionHandler) Delete(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Delete")
+	defer span.End()
+
+	id, ok := h.bindID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.subscriptionService.Delete(spanCtx, id); err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, struct{}{}))
+}
+
+// Enable 启用订阅
+func (h *SubscriptionHandler) Enable(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Enable")
+	defer span.End()
+
+	id, ok := h.bindID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.subscriptionService.Enable(spanCtx, id); err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, struct{}{}))
+}
+
+// Disable 停用订阅
+func (h *SubscriptionHandler) Disable(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Disable")
+	defer span.End()
+
+	id, ok := h.bindID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.subscriptionService.Disable(spanCtx, id); err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, struct{}{}))
+}
+
+// Deliveries 投递记录列表
+func (h *SubscriptionHandler) Deliveries(ctx *gin.Context) {
+	spanCtx, span := trace.Tracer().Start(trace.Gin(ctx), h.baseTraceSpanName+".Deliveries")
+	defer span.End()
+
+	id, ok := h.bindID(ctx)
+	if !ok {
+		return
+	}
+	var req webhookReq.DeliveryListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(spanCtx, validator.TransError(err), api.CodeFail))
+		return
+	}
+
+	list, total, err := h.subscriptionService.Deliveries(spanCtx, &query.DeliveryListQuery{
+		SubscriptionID: id,
+		EventType:      req.EventType,
+		Status:         req.Status,
+		Page:           req.Page,
+		PageSize:       req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(api.FailWithError(spanCtx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api.Success(spanCtx, api.Record[[]response.DeliveryResponse]{
+		Record: list,
+		Total:  total,
+	}))
+}
+
+// bindID 绑定路径中的订阅ID，失败时直接响应错误
+func (h *SubscriptionHandler) bindID(ctx *gin.Context) (uuid.UUID, bool) {
+	var req webhookReq.SubscriptionIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusOK, api.Fail(trace.Gin(ctx), validator.TransError(err), api.CodeFail))
+		return uuid.Nil, false
+	}
+	return uuid.MustParse(req.ID), true
+}
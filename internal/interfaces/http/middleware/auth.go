@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dysodeng/app/internal/infrastructure/shared/helper"
+	"github.com/dysodeng/app/internal/infrastructure/shared/principal"
+	"github.com/dysodeng/app/internal/infrastructure/shared/token"
+	"github.com/dysodeng/app/internal/interfaces/http/dto/response/api"
+)
+
+// AmsAuth 运营平台管理员认证中间件
+func AmsAuth() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenString := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if tokenString == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, api.Fail(ctx, "缺少token", api.CodeUnauthorized))
+			return
+		}
+
+		claims, err := token.VerifyToken(tokenString)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, api.Fail(ctx, err.Error(), api.CodeUnauthorized))
+			return
+		}
+		if isRefresh, _ := claims["is_refresh_token"].(bool); isRefresh {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, api.Fail(ctx, "token类型错误", api.CodeUnauthorized))
+			return
+		}
+		if userType, _ := claims["user_type"].(string); userType != "ams" {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, api.Fail(ctx, api.ErrorForbidden, api.CodeForbidden))
+			return
+		}
+
+		// 写入请求主体，供审计字段等使用
+		ctx.Request = ctx.Request.WithContext(principal.WithContext(ctx.Request.Context(), principal.Principal{
+			Type:      "ams",
+			ID:        helper.IfaceConvertString(claims["admin_id"]),
+			IP:        ctx.ClientIP(),
+			UserAgent: ctx.Request.UserAgent(),
+		}))
+
+		ctx.Next()
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -10,6 +11,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/shared/db"
 	"github.com/dysodeng/app/internal/infrastructure/shared/telemetry/metrics"
 )
 
@@ -79,10 +82,15 @@ func Recovery() gin.HandlerFunc {
 	return gin.Recovery()
 }
 
-// CORS 跨域中间件
+// CORS 跨域中间件，允许的来源每次请求读取当前配置，支持热更新
 func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		if origin := allowedOrigin(c.GetHeader("Origin")); origin != "" {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			if origin != "*" {
+				c.Writer.Header().Add("Vary", "Origin")
+			}
+		}
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
@@ -96,6 +104,22 @@ func CORS() gin.HandlerFunc {
 	}
 }
 
+// allowedOrigin 返回允许的跨域来源，不允许时为空
+func allowedOrigin(origin string) string {
+	cfg := config.Current()
+	if cfg == nil {
+		return "*"
+	}
+	origins := cfg.Server.HTTP.CORS.AllowedOrigins
+	if slices.Contains(origins, "*") {
+		return "*"
+	}
+	if origin != "" && slices.Contains(origins, origin) {
+		return origin
+	}
+	return ""
+}
+
 var (
 	// httpRequestCounter HTTP请求计数器
 	httpRequestCounter metric.Int64Counter
@@ -156,3 +180,11 @@ func Metrics() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// ReadYourWrites 请求内写后读主库中间件，请求执行写入后的查询不再路由到从库
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(db.WithWriteTracking(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dysodeng/app/internal/infrastructure/config"
+	"github.com/dysodeng/app/internal/infrastructure/shared/helper"
+	"github.com/dysodeng/app/internal/infrastructure/shared/tenant"
+	"github.com/dysodeng/app/internal/infrastructure/shared/token"
+	"github.com/dysodeng/app/internal/interfaces/http/dto/response/api"
+)
+
+// Tenant 租户解析中间件
+// 按 token 租户声明、请求头、子域名的优先级解析租户并写入请求上下文；
+// token 声明与请求头/子域名指定的租户不一致时拒绝请求，防止跨租户访问；
+// 已认证但缺少租户声明的 token 不能通过请求头选择租户，直接拒绝
+func Tenant(cfg config.Tenant) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requested := strings.TrimSpace(ctx.GetHeader(cfg.Header))
+		if requested == "" && cfg.Domain != "" {
+			requested = subdomainTenant(ctx.Request.Host, cfg.Domain)
+		}
+		if requested != "" && !tenant.Valid(requested) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, api.Fail(ctx, "租户标识无效", api.CodeFail))
+			return
+		}
+
+		id := requested
+		if claimed, authenticated, ok := tokenTenant(ctx); authenticated {
+			if !ok {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, api.Fail(ctx, "token缺少租户声明", api.CodeUnauthorized))
+				return
+			}
+			if requested != "" && requested != claimed {
+				ctx.AbortWithStatusJSON(http.StatusForbidden, api.Fail(ctx, api.ErrorForbidden, api.CodeForbidden))
+				return
+			}
+			id = claimed
+		}
+
+		if id == "" {
+			if cfg.Required {
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, api.Fail(ctx, "缺少租户标识", api.CodeFail))
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(tenant.WithContext(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
+
+// subdomainTenant 从子域名解析租户，如基础域名 example.com 时 t1.example.com 解析为 t1
+func subdomainTenant(host, domain string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	sub, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(domain))
+	if !ok || strings.Contains(sub, ".") {
+		return ""
+	}
+	return sub
+}
+
+// tokenTenant 从已签名的 token 中读取租户声明
+// authenticated 表示请求携带有效 token，token 无效时忽略，由认证中间件拒绝；ok 表示 token 包含租户声明(默认租户为空值)
+func tokenTenant(ctx *gin.Context) (claimed string, authenticated, ok bool) {
+	tokenString := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if tokenString == "" {
+		return "", false, false
+	}
+	claims, err := token.VerifyToken(tokenString)
+	if err != nil {
+		return "", false, false
+	}
+	value, ok := claims[tenant.ClaimKey]
+	return helper.IfaceConvertString(value), true, ok
+}
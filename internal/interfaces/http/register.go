@@ -1,22 +1,41 @@
 package http
 
 import (
+	"github.com/dysodeng/app/internal/interfaces/http/handler/audit"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/cache"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/event"
 	"github.com/dysodeng/app/internal/interfaces/http/handler/file"
 	"github.com/dysodeng/app/internal/interfaces/http/handler/passport"
+	"github.com/dysodeng/app/internal/interfaces/http/handler/webhook"
 )
 
 // HandlerRegistry 控制器注册表
 type HandlerRegistry struct {
-	PassportHandler *passport.Handler
-	UploaderHandler *file.UploaderHandler
+	PassportHandler   *passport.Handler
+	UploaderHandler   *file.UploaderHandler
+	FileHandler       *file.FileHandler
+	DeadLetterHandler *event.DeadLetterHandler
+	WebhookHandler    *webhook.SubscriptionHandler
+	CacheHandler      *cache.Handler
+	AuditLogHandler   *audit.AuditLogHandler
 }
 
 func NewHandlerRegistry(
 	passportHandler *passport.Handler,
 	uploaderHandler *file.UploaderHandler,
+	fileHandler *file.FileHandler,
+	deadLetterHandler *event.DeadLetterHandler,
+	webhookHandler *webhook.SubscriptionHandler,
+	cacheHandler *cache.Handler,
+	auditLogHandler *audit.AuditLogHandler,
 ) *HandlerRegistry {
 	return &HandlerRegistry{
-		PassportHandler: passportHandler,
-		UploaderHandler: uploaderHandler,
+		PassportHandler:   passportHandler,
+		UploaderHandler:   uploaderHandler,
+		FileHandler:       fileHandler,
+		DeadLetterHandler: deadLetterHandler,
+		WebhookHandler:    webhookHandler,
+		CacheHandler:      cacheHandler,
+		AuditLogHandler:   auditLogHandler,
 	}
 }
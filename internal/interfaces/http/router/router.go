@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/dysodeng/app/internal/interfaces/http"
+	"github.com/dysodeng/app/internal/interfaces/http/middleware"
 )
 
 // RegisterRouter 注册路由
@@ -24,6 +25,48 @@ func RegisterRouter(router *gin.Engine, registry *http.HandlerRegistry) {
 			file.POST("upload/multipart/complete", registry.UploaderHandler.CompleteMultipartUpload)
 			file.POST("upload/multipart/status", registry.UploaderHandler.MultipartUploadStatus)
 		}
+
+		// 运营平台
+		ams := api.Group("ams", middleware.AmsAuth())
+		{
+			ams.GET("file", registry.FileHandler.List)
+			ams.DELETE("file/:id", registry.FileHandler.Delete)
+
+			deadLetter := ams.Group("event/dead_letter")
+			{
+				deadLetter.GET("", registry.DeadLetterHandler.List)
+				deadLetter.GET(":id", registry.DeadLetterHandler.Info)
+				deadLetter.POST(":id/replay", registry.DeadLetterHandler.Replay)
+				deadLetter.POST(":id/discard", registry.DeadLetterHandler.Discard)
+			}
+
+			webhook := ams.Group("webhook")
+			{
+				webhook.GET("event_types", registry.WebhookHandler.EventTypes)
+				webhook.GET("subscription", registry.WebhookHandler.List)
+				webhook.POST("subscription", registry.WebhookHandler.Create)
+				webhook.GET("subscription/:id", registry.WebhookHandler.Info)
+				webhook.PUT("subscription/:id", registry.WebhookHandler.Update)
+				webhook.DELETE("subscription/:id", registry.WebhookHandler.Delete)
+				webhook.POST("subscription/:id/enable", registry.WebhookHandler.Enable)
+				webhook.POST("subscription/:id/disable", registry.WebhookHandler.Disable)
+				webhook.POST("subscription/:id/rotate_secret", registry.WebhookHandler.RotateSecret)
+				webhook.GET("subscription/:id/deliveries", registry.WebhookHandler.Deliveries)
+			}
+
+			cache := ams.Group("cache")
+			{
+				cache.GET("namespaces", registry.CacheHandler.Namespaces)
+				cache.GET("key", registry.CacheHandler.Inspect)
+				cache.POST("purge", registry.CacheHandler.Purge)
+			}
+
+			audit := ams.Group("audit")
+			{
+				audit.GET("logs", registry.AuditLogHandler.List)
+				audit.GET("logs/export", registry.AuditLogHandler.Export)
+			}
+		}
 	}
 
 	// 健康检查